@@ -0,0 +1,106 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+const componentsSchemasPrefix = "#/components/schemas/"
+
+// AsyncAPIComponents is the "components" object of an AsyncAPI document,
+// holding the schemas and messages generated by an AsyncAPI builder.
+type AsyncAPIComponents struct {
+	Schemas  map[string]*Property        `json:"schemas,omitempty"`
+	Messages map[string]*AsyncAPIMessage `json:"messages,omitempty"`
+}
+
+// AsyncAPIMessage is an AsyncAPI message object.
+type AsyncAPIMessage struct {
+	Name        string    `json:"name,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Summary     string    `json:"summary,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Payload     *Property `json:"payload,omitempty"`
+}
+
+// AsyncAPI builds the components of an AsyncAPI document from message types,
+// so event-driven services can publish AsyncAPI documents from their Go models.
+// Each message payload type becomes a schema named after the message, and
+// references between schemas point to "#/components/schemas/".
+type AsyncAPI struct {
+	options     []Options
+	definitions map[string]interface{}
+	messages    map[string]interface{}
+	contentType string
+}
+
+// NewAsyncAPI returns an AsyncAPI builder for JSON messages.
+func NewAsyncAPI(options ...Options) *AsyncAPI {
+	return &AsyncAPI{
+		options:     options,
+		definitions: map[string]interface{}{},
+		messages:    map[string]interface{}{},
+		contentType: "application/json",
+	}
+}
+
+// WithMessage registers the payload type of the message with the given name.
+// payload may be an instance of the type or its reflect.Type.
+func (a *AsyncAPI) WithMessage(name string, payload interface{}) *AsyncAPI {
+	a.messages[name] = payload
+	return a
+}
+
+// WithDefinition registers an additional schema which the message payloads may reference.
+func (a *AsyncAPI) WithDefinition(name string, d interface{}) *AsyncAPI {
+	a.definitions[name] = d
+	return a
+}
+
+// WithContentType sets the content type of the messages.
+func (a *AsyncAPI) WithContentType(contentType string) *AsyncAPI {
+	a.contentType = contentType
+	return a
+}
+
+// Generate generates the AsyncAPI components.
+func (a *AsyncAPI) Generate() (*AsyncAPIComponents, error) {
+	g := NewGenerator(a.options...).WithDefinitions(a.definitions)
+	for name, payload := range a.messages {
+		if _, ok := a.definitions[name]; ok {
+			return nil, fmt.Errorf("message %s conflicts with a definition of the same name", name)
+		}
+		g.WithDefinition(name, payload)
+	}
+
+	js, err := g.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &AsyncAPIComponents{
+		Schemas:  make(map[string]*Property, len(js.Definitions)),
+		Messages: make(map[string]*AsyncAPIMessage, len(a.messages)),
+	}
+	RewriteRefs(js, func(ref string) string {
+		if strings.HasPrefix(ref, "#/definitions/") {
+			return componentsSchemasPrefix + strings.TrimPrefix(ref, "#/definitions/")
+		}
+		return ref
+	})
+	for name, definition := range js.Definitions {
+		p := definition
+		c.Schemas[name] = &p
+	}
+	for name := range a.messages {
+		c.Messages[name] = &AsyncAPIMessage{
+			Name:        name,
+			Title:       c.Schemas[name].Title,
+			Description: c.Schemas[name].Description,
+			ContentType: a.contentType,
+			Payload:     &Property{Ref: componentsSchemasPrefix + name},
+		}
+	}
+	return c, nil
+}
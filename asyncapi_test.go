@@ -0,0 +1,65 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type asyncAPISuite struct{}
+
+var _ = Suite(&asyncAPISuite{})
+
+type ExampleJSONOrderCreated struct {
+	meta  string                                `title:"Order created" description:"Sent when an order is created."`
+	Order ExampleJSONNestedStructReferenceChild `json:"order"`
+}
+
+func (self *asyncAPISuite) TestComponents(c *C) {
+	components, err := NewAsyncAPI().
+		WithMessage("orderCreated", &ExampleJSONOrderCreated{}).
+		WithDefinition("order", ExampleJSONNestedStructReferenceChild{}).
+		Generate()
+	c.Assert(err, IsNil)
+
+	b, err := json.MarshalIndent(components, "", "  ")
+	c.Assert(err, IsNil)
+
+	expected := `{
+  "schemas": {
+    "order": {
+      "type": "object",
+      "properties": {
+        "Foo": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "Foo"
+      ]
+    },
+    "orderCreated": {
+      "type": "object",
+      "properties": {
+        "order": {
+          "$ref": "#/components/schemas/order"
+        }
+      },
+      "description": "Sent when an order is created.",
+      "title": "Order created"
+    }
+  },
+  "messages": {
+    "orderCreated": {
+      "name": "orderCreated",
+      "title": "Order created",
+      "description": "Sent when an order is created.",
+      "contentType": "application/json",
+      "payload": {
+        "$ref": "#/components/schemas/orderCreated"
+      }
+    }
+  }
+}`
+	c.Assert(findDiff(string(b), expected), Equals, "")
+}
@@ -0,0 +1,71 @@
+package jsonschema
+
+import (
+	"path"
+	"reflect"
+)
+
+// DefinitionNaming names the definitions of the struct types hoisted by
+// WithAutoDefinitions. Names taken are numbered, e.g. "line2".
+type DefinitionNaming func(t reflect.Type) string
+
+// LowerCamelNames names definitions after their types in lower camel case,
+// e.g. "orderLine" for OrderLine.
+func LowerCamelNames(t reflect.Type) string {
+	return lowerCamel(t.Name())
+}
+
+// TypeNames names definitions after their types, e.g. "OrderLine".
+func TypeNames(t reflect.Type) string {
+	return t.Name()
+}
+
+// QualifiedNames names definitions after their types qualified by the name
+// of their package, e.g. "shop.OrderLine", for types of several packages
+// sharing names.
+func QualifiedNames(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// WithAutoDefinitions hoists the named struct types read, other than the
+// root and the types registered, into definitions named by naming,
+// LowerCamelNames by default, and references them with $refs rather than
+// describing them inline.
+func (g *Generator) WithAutoDefinitions(naming ...DefinitionNaming) *Generator {
+	g.naming = LowerCamelNames
+	if len(naming) > 0 {
+		g.naming = naming[0]
+	}
+	return g
+}
+
+// hoisting reports whether the struct t is hoisted into a definition.
+func (r *reader) hoisting(t goType) bool {
+	return r.naming != nil && t.Name() != ""
+}
+
+// readRoot reads the node of the root type t into n, described by the doc
+// comment of the type read from source. A struct hoisted elsewhere is
+// described in place at the root. A recursive struct, promoted to a
+// definition as it recurses, is referenced by the root rather than described
+// twice, unless it is unrolled.
+func (r *reader) readRoot(n *Node, t goType) error {
+	elem := pointedType(t)
+	_, known := r.reference(elem)
+	if !known && elem.Kind() == reflect.Struct && r.hoisting(elem) {
+		return r.readDefinition(n, elem)
+	}
+	if err := r.read(n, t); err != nil {
+		return err
+	}
+	if ref, ok := r.reference(elem); ok && !known && r.options.UnrollRecursion <= 0 {
+		root := newNode()
+		root.setRef(ref)
+		*n = *root
+		r.tracef("%s: recursive root, referenced as %s", elem, ref)
+	}
+	if n.Kind != KindRef {
+		r.describe(n.Schema, elem.Doc())
+	}
+	return nil
+}
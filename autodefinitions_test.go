@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	"reflect"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type autoDefinitionsSuite struct{}
+
+var _ = Suite(&autoDefinitionsSuite{})
+
+type ExampleJSONShelf struct {
+	Books    []ExampleJSONBook `json:"books"`
+	Featured *ExampleJSONBook  `json:"featured"`
+	Updated  time.Time         `json:"updated"`
+	Location struct {
+		Aisle int `json:"aisle"`
+	} `json:"location"`
+}
+
+type ExampleJSONBook struct {
+	Title  string                `json:"title"`
+	Author ExampleJSONBookAuthor `json:"author"`
+}
+
+type ExampleJSONBookAuthor struct {
+	Name string `json:"name"`
+}
+
+func (self *autoDefinitionsSuite) TestAutoDefinitions(c *C) {
+	j := NewGenerator().WithAutoDefinitions().WithRoot(&ExampleJSONShelf{}).MustGenerate()
+	c.Assert(j.Type, Equals, "object")
+	c.Assert(j.Properties["books"].Items, DeepEquals, &Property{Ref: "#/definitions/exampleJSONBook"})
+	c.Assert(j.Properties["featured"], DeepEquals, &Property{Ref: "#/definitions/exampleJSONBook"})
+	c.Assert(j.Properties["updated"].Format, Equals, "date-time")
+	c.Assert(j.Properties["location"].Properties["aisle"].Type, Equals, "integer")
+	c.Assert(j.Definitions, HasLen, 2)
+	c.Assert(j.Definitions["exampleJSONBook"].Properties["author"].Ref, Equals, "#/definitions/exampleJSONBookAuthor")
+	c.Assert(j.Definitions["exampleJSONBookAuthor"].Properties["name"].Type, Equals, "string")
+	name, ok := j.DefinitionFor(reflect.TypeOf(ExampleJSONBookAuthor{}))
+	c.Assert(ok, Equals, true)
+	c.Assert(name, Equals, "exampleJSONBookAuthor")
+
+	// registered types keep their names, and taken names are numbered
+	j = NewGenerator().
+		WithAutoDefinitions(TypeNames).
+		WithDefinition("author", ExampleJSONBookAuthor{}).
+		WithDefinition("ExampleJSONBook", ExampleJSONTree{}).
+		WithRoot(&ExampleJSONShelf{}).
+		MustGenerate()
+	c.Assert(j.Properties["books"].Items.Ref, Equals, "#/definitions/ExampleJSONBook2")
+	c.Assert(j.Definitions["ExampleJSONBook2"].Properties["author"].Ref, Equals, "#/definitions/author")
+
+	j = NewGenerator().WithAutoDefinitions(QualifiedNames).WithRoot(&ExampleJSONShelf{}).MustGenerate()
+	c.Assert(j.Properties["featured"].Ref, Equals, "#/definitions/go-json-schema.ExampleJSONBook")
+}
@@ -0,0 +1,198 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// avroEmitter emits Avro schemas. Objects become records, named after their
+// definition or their field, and the fields which aren't required are
+// nullable with a null default, as they may be absent from documents.
+type avroEmitter struct{}
+
+func (e avroEmitter) Emit(model *Property) ([]byte, error) {
+	return e.EmitDocument(&JSONSchema{Property: *model})
+}
+
+func (e avroEmitter) EmitDocument(schema *JSONSchema) ([]byte, error) {
+	m := schema.Model()
+	c := &avroConverter{model: m, defined: map[string]bool{}, definitions: map[string]string{}}
+	t, err := c.convert(m.Root, firstNonEmpty(m.Root.Title, "Root"))
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(t, "", "  ")
+}
+
+var (
+	avroInvalidName = regexp.MustCompile(`[^A-Za-z0-9_]`)
+	avroValidName   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+type avroConverter struct {
+	model *Model
+	// defined holds the names of the named types already defined
+	defined map[string]bool
+	// definitions holds the names of the named types of the definitions
+	definitions map[string]string
+}
+
+// name returns a name for a new named type, unique in the schema.
+func (c *avroConverter) name(s string) string {
+	s = avroInvalidName.ReplaceAllString(s, "_")
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		s = "_" + s
+	}
+	s = strings.ToUpper(s[:1]) + s[1:]
+	name := s
+	for i := 2; c.defined[name]; i++ {
+		name = fmt.Sprintf("%s%d", s, i)
+	}
+	c.defined[name] = true
+	return name
+}
+
+func (c *avroConverter) convert(n *Node, name string) (interface{}, error) {
+	t, err := c.convertKind(n, name, func(string) {})
+	if err != nil {
+		return nil, err
+	}
+	if n.Nullable {
+		t = nullableAvro(t)
+	}
+	return t, nil
+}
+
+// convertKind converts n, calling define with the name of the named type it
+// becomes, if any, before converting its subschemas.
+func (c *avroConverter) convertKind(n *Node, name string, define func(named string)) (interface{}, error) {
+	switch n.Kind {
+	case KindRef:
+		if n.Definition == "" {
+			return nil, fmt.Errorf("reference %s can't be described in Avro", n.Ref)
+		}
+		if named, ok := c.definitions[n.Definition]; ok {
+			return named, nil
+		}
+		def, ok := c.model.Definitions[n.Definition]
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference %s", n.Ref)
+		}
+		t, err := c.convertKind(def, n.Definition, func(named string) {
+			c.definitions[n.Definition] = named
+		})
+		if err == nil && def.Nullable {
+			t = nullableAvro(t)
+		}
+		return t, err
+	case KindUnion:
+		union := make([]interface{}, 0, len(n.Variants))
+		for _, variant := range n.Variants {
+			t, err := c.convert(variant, name)
+			if err != nil {
+				return nil, err
+			}
+			union = append(union, t)
+		}
+		return union, nil
+	case KindString:
+		if len(n.Values) == 0 {
+			return "string", nil
+		}
+		symbols := make([]string, len(n.Values))
+		for i, v := range n.Values {
+			symbol, ok := v.(string)
+			if !ok || !avroValidName.MatchString(symbol) {
+				// not representable as an enum
+				return "string", nil
+			}
+			symbols[i] = symbol
+		}
+		named := c.name(name)
+		define(named)
+		return map[string]interface{}{"type": "enum", "name": named, "symbols": symbols}, nil
+	case KindInteger:
+		if n.Format == "int32" {
+			return "int", nil
+		}
+		return "long", nil
+	case KindNumber:
+		return "double", nil
+	case KindBoolean, KindNull:
+		return string(n.Kind), nil
+	case KindArray:
+		if n.Elem == nil {
+			return nil, fmt.Errorf("%s: items of any type can't be described in Avro", name)
+		}
+		items, err := c.convert(n.Elem, name+"Item")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case KindMap:
+		if n.Elem == nil {
+			return nil, fmt.Errorf("%s: values of any type can't be described in Avro", name)
+		}
+		t, err := c.convert(n.Elem, name+"Value")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "map", "values": t}, nil
+	case KindObject:
+		return c.record(n, name, define)
+	}
+	return nil, fmt.Errorf("%s: values of any type can't be described in Avro", name)
+}
+
+// record converts an object into a record. Properties which aren't fields
+// can't be represented.
+func (c *avroConverter) record(n *Node, name string, define func(named string)) (interface{}, error) {
+	named := c.name(name)
+	define(named)
+	record := map[string]interface{}{"type": "record", "name": named}
+	if n.Description != "" {
+		record["doc"] = n.Description
+	}
+
+	fields := []map[string]interface{}{}
+	for _, field := range n.Fields {
+		if !avroValidName.MatchString(field.Name) {
+			return nil, fmt.Errorf("%s: property %s isn't a valid Avro field name", name, field.Name)
+		}
+		t, err := c.convert(field.Node, field.Name)
+		if err != nil {
+			return nil, err
+		}
+		f := map[string]interface{}{"name": field.Name}
+		if !field.Required {
+			t = nullableAvro(t)
+		}
+		f["type"] = t
+		if union, ok := t.([]interface{}); ok && len(union) > 0 && union[0] == "null" {
+			f["default"] = nil
+		}
+		if field.Description != "" {
+			f["doc"] = field.Description
+		}
+		fields = append(fields, f)
+	}
+	record["fields"] = fields
+	return record, nil
+}
+
+// nullableAvro returns the union of null and t, with null first so that it
+// may be the default value.
+func nullableAvro(t interface{}) interface{} {
+	union, ok := t.([]interface{})
+	if !ok {
+		return []interface{}{"null", t}
+	}
+	for _, member := range union {
+		if member == "null" {
+			return union
+		}
+	}
+	return append([]interface{}{"null"}, union...)
+}
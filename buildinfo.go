@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// The extension keywords stamped into the root of the schemas generated with
+// Options.Metadata.
+const (
+	GeneratedByExtension      = "x-generated-by"
+	GeneratorVersionExtension = "x-generator-version"
+	GitCommitExtension        = "x-git-commit"
+	GeneratedAtExtension      = "x-generated-at"
+)
+
+// BuildMetadata tells which build produced a schema. Fields left empty
+// default to those of the build of the running program, when it was built
+// with module and VCS information.
+type BuildMetadata struct {
+	// GeneratedBy is the name of the service or tool generating the schema,
+	// by default the path of the main module.
+	GeneratedBy string
+	// Version is the version of the service or tool, by default the version
+	// of the main module.
+	Version string
+	// Commit is the git commit the service or tool was built from.
+	Commit string
+	// Timestamp stamps the time of the generation, in RFC 3339 format. Leave
+	// it off for reproducible builds.
+	Timestamp bool
+}
+
+// stamp sets the extension keywords of the metadata on the root p, without
+// the time of the generation when reproducible.
+func (m *BuildMetadata) stamp(p *Property, reproducible bool) {
+	generatedBy, version, commit := m.GeneratedBy, m.Version, m.Commit
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if generatedBy == "" {
+			generatedBy = info.Main.Path
+		}
+		if version == "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && commit == "" {
+				commit = setting.Value
+			}
+		}
+	}
+	for key, value := range map[string]string{
+		GeneratedByExtension:      generatedBy,
+		GeneratorVersionExtension: version,
+		GitCommitExtension:        commit,
+	} {
+		if value != "" {
+			setExtension(p, key, value)
+		}
+	}
+	if m.Timestamp && !reproducible {
+		setExtension(p, GeneratedAtExtension, time.Now().UTC().Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,39 @@
+package jsonschema
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type buildInfoSuite struct{}
+
+var _ = Suite(&buildInfoSuite{})
+
+type ExampleJSONContract struct {
+	ID   string `json:"id" maxLength:"36" required:"true"`
+	Note string `json:"note"`
+}
+
+func (self *buildInfoSuite) TestMetadata(c *C) {
+	js := NewGenerator(Options{Metadata: &BuildMetadata{
+		GeneratedBy: "orders-service",
+		Version:     "v1.4.2",
+		Commit:      "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+	}}).WithRoot(&ExampleJSONContract{}).MustGenerate()
+	c.Assert(js.Extensions, DeepEquals, map[string]interface{}{
+		GeneratedByExtension:      "orders-service",
+		GeneratorVersionExtension: "v1.4.2",
+		GitCommitExtension:        "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+	})
+
+	js = NewGenerator(Options{Metadata: &BuildMetadata{GeneratedBy: "orders-service", Timestamp: true}}).
+		WithRoot(&ExampleJSONContract{}).
+		MustGenerate()
+	generatedAt, err := time.Parse(time.RFC3339, js.Extensions[GeneratedAtExtension].(string))
+	c.Assert(err, IsNil)
+	c.Assert(time.Since(generatedAt) < time.Minute, Equals, true)
+
+	js = NewGenerator().WithRoot(&ExampleJSONContract{}).MustGenerate()
+	c.Assert(js.Extensions, IsNil)
+}
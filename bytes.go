@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Encodings of byte slices, for Options.BytesFormat and the bytesFormat tag.
+const (
+	// BytesBase64 is the encoding of encoding/json.
+	BytesBase64 = "base64"
+	// BytesHex is for types marshaling to hexadecimal strings, e.g. hashes.
+	BytesHex = "hex"
+	// BytesBinary is for raw binary content, e.g. in multipart bodies.
+	BytesBinary = "binary"
+)
+
+const hexPattern = "^([0-9a-fA-F]{2})*$"
+
+// setBytesFormat describes the encoding of the string representing a byte slice.
+func (p *Property) setBytesFormat(format string) error {
+	p.ContentEncoding, p.Pattern, p.Format = "", "", ""
+	switch format {
+	case "":
+	case BytesBase64:
+		p.ContentEncoding = "base64"
+	case BytesHex:
+		p.ContentEncoding = "base16"
+		p.Pattern = hexPattern
+	case BytesBinary:
+		p.Format = "binary"
+	default:
+		return fmt.Errorf("unknown bytes format %q", format)
+	}
+	return nil
+}
+
+func (p *Property) addBytesFormatFromTags(tag *reflect.StructTag, t goType) error {
+	format, ok := tag.Lookup("bytesFormat")
+	if !ok {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf(`"bytesFormat" tag on %s, which is not a byte slice`, t)
+	}
+	err := p.setBytesFormat(format)
+	if err != nil {
+		return fmt.Errorf(`invalid "bytesFormat" tag value: %s`, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type bytesSuite struct{}
+
+var _ = Suite(&bytesSuite{})
+
+type ExampleJSONBlob struct {
+	Data     []byte  `json:"data"`
+	Hash     []byte  `json:"hash" bytesFormat:"hex"`
+	Short    []byte  `json:"short" bytesFormat:"hex" pattern:"^[0-9a-f]{8}$"`
+	Content  []byte  `json:"content" bytesFormat:"binary"`
+	Optional *[]byte `json:"optional" bytesFormat:"base64"`
+}
+
+func (self *bytesSuite) TestBytesFormat(c *C) {
+	j := NewGenerator(Options{BytesFormat: BytesBase64}).WithRoot(&ExampleJSONBlob{}).MustGenerate()
+
+	c.Assert(j.Properties["data"], DeepEquals, &Property{Type: "string", ContentEncoding: "base64"})
+	c.Assert(j.Properties["hash"], DeepEquals, &Property{Type: "string", ContentEncoding: "base16", Pattern: hexPattern})
+	c.Assert(j.Properties["short"], DeepEquals, &Property{Type: "string", ContentEncoding: "base16", Pattern: "^[0-9a-f]{8}$"})
+	c.Assert(j.Properties["content"], DeepEquals, &Property{Type: "string", Format: "binary"})
+	c.Assert(j.Properties["optional"], DeepEquals, &Property{Type: "string", ContentEncoding: "base64"})
+}
+
+func (self *bytesSuite) TestBytesFormatDefault(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONBlob{}).MustGenerate()
+
+	c.Assert(j.Properties["data"], DeepEquals, &Property{Type: "string"})
+	c.Assert(j.Properties["hash"].ContentEncoding, Equals, "base16")
+}
+
+type ExampleJSONInvalidBytesFormat struct {
+	Name string `json:"name" bytesFormat:"hex"`
+}
+
+type ExampleJSONUnknownBytesFormat struct {
+	Data []byte `json:"data" bytesFormat:"base32"`
+}
+
+func (self *bytesSuite) TestBytesFormatErrors(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONInvalidBytesFormat{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Name:"bytesFormat" tag on string, which is not a byte slice`)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONUnknownBytesFormat{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Data:invalid "bytesFormat" tag value: unknown bytes format "base32"`)
+
+	_, err = NewGenerator(Options{BytesFormat: "base32"}).WithRoot([]byte{}).Generate()
+	c.Assert(err, ErrorMatches, `.*unknown bytes format "base32"`)
+}
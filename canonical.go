@@ -0,0 +1,258 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CanonicalizeOptions controls CanonicalizeInstance.
+type CanonicalizeOptions struct {
+	// StripUnknown removes the properties which are not described by the schema.
+	StripUnknown bool
+}
+
+// CanonicalizeInstance returns the canonical form of a JSON document described
+// by the schema, so that equal documents are byte-identical, for hashing and signing.
+//
+// The canonical form has no insignificant whitespace, the properties of objects
+// described by the schema come first, in the order they are emitted in the schema,
+// followed by the other properties in lexical order, and numbers are formatted
+// in their shortest form, without exponent for integers. Integer literals are
+// kept at any precision, e.g. 18446744073709551615, rather than rounded to a
+// float64.
+func CanonicalizeInstance(schema *JSONSchema, document []byte, options ...CanonicalizeOptions) ([]byte, error) {
+	var o CanonicalizeOptions
+	if len(options) > 0 {
+		o = options[0]
+	}
+
+	doc, err := decodeInstance(document)
+	if err != nil {
+		return nil, err
+	}
+	if o.StripUnknown {
+		doc = schema.strip(&schema.Property, doc)
+	}
+
+	buf := &bytes.Buffer{}
+	err = schema.writeCanonical(buf, &schema.Property, doc)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeInstance(document []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(document))
+	// numbers are decoded as their literals, which float64 would round
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return doc, nil
+}
+
+func encodeInstance(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Strip returns a copy of the JSON document without the properties which
+// are not described by the schema, following references, array items and
+// the additionalProperties of objects, e.g. before forwarding client
+// payloads to internal services.
+func (d *JSONSchema) Strip(document []byte) ([]byte, error) {
+	doc, err := decodeInstance(document)
+	if err != nil {
+		return nil, err
+	}
+	return encodeInstance(d.strip(&d.Property, doc))
+}
+
+// schemasOf returns the resolved schemas which apply to a value described by p,
+// that is p itself and the branches of its anyOf and oneOf keywords.
+func (d *JSONSchema) schemasOf(p *Property) []*Property {
+	p = d.resolve(p)
+	if p == nil {
+		return nil
+	}
+	schemas := []*Property{p}
+	for _, s := range p.AnyOf {
+		schemas = append(schemas, d.schemasOf(s)...)
+	}
+	for _, s := range p.OneOf {
+		schemas = append(schemas, d.schemasOf(s)...)
+	}
+	return schemas
+}
+
+// knownProperty returns the schema describing the named property of an object
+// described by p, and whether the property is allowed at all.
+func (d *JSONSchema) knownProperty(p *Property, name string) (*Property, bool) {
+	if p == nil {
+		return nil, true
+	}
+	schemas := d.schemasOf(p)
+	for _, s := range schemas {
+		if ps, ok := propertySchema(s, name); ok {
+			return ps, true
+		}
+	}
+	for _, s := range schemas {
+		if s.AdditionalProperties || (s.Type == "" && s.Properties == nil && s.Ref == "") {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+func (d *JSONSchema) itemsOf(p *Property) *Property {
+	for _, s := range d.schemasOf(p) {
+		if s.Items != nil {
+			return s.Items
+		}
+	}
+	return nil
+}
+
+// strip removes the properties of objects not described by the schema.
+func (d *JSONSchema) strip(p *Property, v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			s, ok := d.knownProperty(p, k)
+			if !ok {
+				delete(t, k)
+				continue
+			}
+			t[k] = d.strip(s, value)
+		}
+	case []interface{}:
+		items := d.itemsOf(p)
+		for i, value := range t {
+			t[i] = d.strip(items, value)
+		}
+	}
+	return v
+}
+
+func (d *JSONSchema) propertyOrder(p *Property, obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	seen := map[string]bool{}
+	if p != nil {
+		var declared []string
+		for _, s := range d.schemasOf(p) {
+			for name := range s.Properties {
+				if _, ok := obj[name]; ok && !seen[name] {
+					seen[name] = true
+					declared = append(declared, name)
+				}
+			}
+		}
+		sort.Strings(declared)
+		keys = append(keys, declared...)
+	}
+	var others []string
+	for k := range obj {
+		if !seen[k] {
+			others = append(others, k)
+		}
+	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
+
+func (d *JSONSchema) writeCanonical(buf *bytes.Buffer, p *Property, v interface{}) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, k := range d.propertyOrder(p, t) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			s, _ := d.knownProperty(p, k)
+			if err := d.writeCanonical(buf, s, t[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		items := d.itemsOf(p)
+		buf.WriteByte('[')
+		for i, value := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := d.writeCanonical(buf, items, value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		n, err := canonicalNumber(t)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case string:
+		writeCanonicalString(buf, t)
+	case bool:
+		buf.WriteString(strconv.FormatBool(t))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return fmt.Errorf("unexpected value of type %T", v)
+	}
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	// Encode terminates the value with a newline
+	buf.Truncate(buf.Len() - 1)
+}
+
+// canonicalNumber formats n in its shortest form: integer literals exactly,
+// whatever their magnitude, and other numbers like ECMAScript does, e.g. 1.5,
+// 1e+21 or 1e-7.
+func canonicalNumber(n json.Number) (string, error) {
+	if i, ok := new(big.Int).SetString(string(n), 10); ok {
+		return i.String(), nil
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", err
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("number %s is out of range", n)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	// Go pads the exponent to two digits, ECMAScript does not
+	mantissa, exponent := s[:strings.IndexByte(s, 'e')+2], s[strings.IndexByte(s, 'e')+2:]
+	return mantissa + strings.TrimLeft(exponent, "0"), nil
+}
@@ -0,0 +1,82 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type canonicalSuite struct{}
+
+var _ = Suite(&canonicalSuite{})
+
+func (self *canonicalSuite) TestCanonicalizeInstance(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONAccount{}).WithDefinition("card", ExampleJSONCard{}).MustGenerate()
+
+	document := []byte(`{
+		"zzz": {"b": 1, "a": 2},
+		"name": "Jane <jane@example.com>",
+		"email": "jane@example.com",
+		"cards": [{"number": "4111", "brand": "visa", "color": "blue"}],
+		"labels": {"b": "2", "a": "1"},
+		"primary": {"brand": 10.50, "number": 1E3, "score": 0.0000001, "big": 1e21, "zero": -0.0}
+	}`)
+
+	canonical, err := CanonicalizeInstance(j, document)
+	c.Assert(err, IsNil)
+	c.Assert(string(canonical), Equals, `{"cards":[{"brand":"visa","number":"4111","color":"blue"}],`+
+		`"email":"jane@example.com","labels":{"a":"1","b":"2"},"name":"Jane <jane@example.com>",`+
+		`"primary":{"brand":10.5,"number":1000,"big":1e+21,"score":1e-7,"zero":0},"zzz":{"a":2,"b":1}}`)
+
+	stripped, err := CanonicalizeInstance(j, document, CanonicalizeOptions{StripUnknown: true})
+	c.Assert(err, IsNil)
+	c.Assert(string(stripped), Equals, `{"cards":[{"brand":"visa","number":"4111"}],`+
+		`"email":"jane@example.com","labels":{"a":"1","b":"2"},"name":"Jane <jane@example.com>",`+
+		`"primary":{"brand":10.5,"number":1000}}`)
+
+	var v interface{}
+	c.Assert(json.Unmarshal(stripped, &v), IsNil)
+
+	_, err = CanonicalizeInstance(j, []byte(`{} {}`))
+	c.Assert(err, NotNil)
+}
+
+func (self *canonicalSuite) TestCanonicalizeLargeIntegers(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONAccount{}).MustGenerate()
+
+	canonical, err := CanonicalizeInstance(j, []byte(`{"max": 18446744073709551615, "min": -9223372036854775809, "zero": -0, "float": 18446744073709551615.0}`))
+	c.Assert(err, IsNil)
+	c.Assert(string(canonical), Equals, `{"float":18446744073709552000,"max":18446744073709551615,"min":-9223372036854775809,"zero":0}`)
+}
+
+type ExampleJSONGatewayRequest struct {
+	Account  ExampleJSONAccount     `json:"account"`
+	Cards    [][]ExampleJSONCard    `json:"cards"`
+	Metadata map[string]interface{} `json:"metadata"`
+	Payload  interface{}            `json:"payload"`
+	Note     *string                `json:"note"`
+}
+
+func (self *canonicalSuite) TestStrip(c *C) {
+	document := []byte(`{
+		"account": {"name": "<b>Jane</b>", "admin": true, "cards": [{"brand": "visa", "cvv": "123"}]},
+		"cards": [[{"brand": "visa", "cvv": "123"}]],
+		"metadata": {"anything": {"goes": 1}},
+		"payload": {"kept": [1, 2.50]},
+		"note": "hi",
+		"role": "admin"
+	}`)
+
+	for _, g := range []*Generator{
+		NewGenerator().WithRoot(&ExampleJSONGatewayRequest{}),
+		NewGenerator().WithRoot(&ExampleJSONGatewayRequest{}).WithDefinitions(map[string]interface{}{
+			"account": ExampleJSONAccount{},
+			"card":    &ExampleJSONCard{},
+		}),
+	} {
+		stripped, err := g.MustGenerate().Strip(document)
+		c.Assert(err, IsNil)
+		c.Assert(string(stripped), Equals, `{"account":{"cards":[{"brand":"visa"}],"name":"<b>Jane</b>"},`+
+			`"cards":[[{"brand":"visa"}]],"metadata":{"anything":{"goes":1}},"note":"hi","payload":{"kept":[1,2.50]}}`)
+	}
+}
@@ -0,0 +1,65 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Changelog returns a Markdown changelog describing the differences between
+// two versions of a schema, grouped by severity, suitable for release notes.
+func Changelog(old, new *JSONSchema) string {
+	return FormatChangelog(Diff(old, new))
+}
+
+// ChangelogJSON is like Changelog, but reads both versions from their
+// JSON encoding, such as two revisions of a generated schema file.
+func ChangelogJSON(old, new []byte) (string, error) {
+	var o, n JSONSchema
+	if err := json.Unmarshal(old, &o); err != nil {
+		return "", fmt.Errorf("invalid old schema: %s", err)
+	}
+	if err := json.Unmarshal(new, &n); err != nil {
+		return "", fmt.Errorf("invalid new schema: %s", err)
+	}
+	return Changelog(&o, &n), nil
+}
+
+// FormatChangelog formats changes as a Markdown changelog.
+func FormatChangelog(changes []Change) string {
+	if len(changes) == 0 {
+		return "No changes.\n"
+	}
+
+	sections := []struct {
+		title    string
+		severity Severity
+	}{
+		{"Breaking changes", SeverityBreaking},
+		{"Warnings", SeverityWarning},
+		{"Other changes", SeverityInfo},
+	}
+
+	buf := &bytes.Buffer{}
+	for _, section := range sections {
+		first := true
+		for _, c := range changes {
+			if c.Severity != section.severity {
+				continue
+			}
+			if first {
+				if buf.Len() > 0 {
+					buf.WriteString("\n")
+				}
+				fmt.Fprintf(buf, "### %s\n\n", section.title)
+				first = false
+			}
+			path := c.Path
+			if path == "" {
+				path = "/"
+			}
+			fmt.Fprintf(buf, "- `%s`: %s\n", path, c.Description())
+		}
+	}
+	return buf.String()
+}
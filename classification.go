@@ -0,0 +1,109 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ClassificationExtension is the extension keyword holding the data
+// classification labels of a property, such as "pii.email".
+const ClassificationExtension = "x-classification"
+
+// Classifications returns the data classification labels of the property.
+func (p *Property) Classifications() []string {
+	switch t := p.Extensions[ClassificationExtension].(type) {
+	case []string:
+		return t
+	case []interface{}:
+		labels := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				labels = append(labels, s)
+			}
+		}
+		return labels
+	}
+	return nil
+}
+
+func (p *Property) addClassificationFromTags(tag *reflect.StructTag) error {
+	raw, ok := tag.Lookup("classification")
+	if !ok {
+		return nil
+	}
+	labels := strings.Split(raw, "|")
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, ".") || strings.HasSuffix(label, ".") || strings.Contains(label, "..") {
+			return fmt.Errorf(`invalid "classification" tag value %q`, raw)
+		}
+	}
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[ClassificationExtension] = labels
+	return nil
+}
+
+// Classification is a classified field of the documents described by a schema.
+type Classification struct {
+	// Path is the JSON pointer of the field in the documents, where "*"
+	// stands for any array item or map value.
+	Path   string
+	Labels []string
+}
+
+// Classify lists the classified fields of the documents described by the schema,
+// sorted by path, for data governance tooling.
+func Classify(schema *JSONSchema) []Classification {
+	c := &classifier{schema: schema, visiting: map[string]bool{}}
+	c.walk("", &schema.Property)
+	sort.SliceStable(c.classifications, func(i, j int) bool {
+		return c.classifications[i].Path < c.classifications[j].Path
+	})
+	return c.classifications
+}
+
+type classifier struct {
+	schema          *JSONSchema
+	visiting        map[string]bool
+	classifications []Classification
+}
+
+func (c *classifier) walk(path string, p *Property) {
+	if p == nil {
+		return
+	}
+	if ref := p.Ref; ref != "" {
+		// recursive definitions are only visited once per path
+		if c.visiting[ref] {
+			return
+		}
+		c.visiting[ref] = true
+		defer delete(c.visiting, ref)
+	}
+	labels := p.Classifications()
+	if resolved := c.schema.resolve(p); resolved != p {
+		labels = append(labels, resolved.Classifications()...)
+		p = resolved
+	}
+	if len(labels) > 0 {
+		c.classifications = append(c.classifications, Classification{Path: path, Labels: labels})
+	}
+
+	for name, s := range p.Properties {
+		if name == ".*" {
+			name = "*"
+		}
+		c.walk(path+"/"+escapePointer(name), s)
+	}
+	c.walk(path+"/*", p.Items)
+	c.walk(path+"/*", p.AdditionalPropertiesSchema)
+	for _, s := range p.AnyOf {
+		c.walk(path, s)
+	}
+	for _, s := range p.OneOf {
+		c.walk(path, s)
+	}
+}
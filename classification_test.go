@@ -0,0 +1,60 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type classificationSuite struct{}
+
+var _ = Suite(&classificationSuite{})
+
+type ExampleJSONCustomer struct {
+	Email    string                          `json:"email" classification:"pii.email"`
+	Phones   []string                        `json:"phones" classification:"pii.phone|gdpr.personal"`
+	Address  ExampleJSONAddress              `json:"address"`
+	Contacts map[string]*ExampleJSONCustomer `json:"contacts"`
+	Notes    string                          `json:"notes"`
+}
+
+type ExampleJSONAddress struct {
+	meta   string `classification:"pii.address"`
+	Street string `json:"street"`
+	Zip    string `json:"zip" classification:"pii.address.zip"`
+}
+
+func (self *classificationSuite) TestClassificationTag(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONCustomer{}).MustGenerate()
+
+	// the customer is recursive, so the root references its definition
+	c.Assert(j.Ref, Equals, "#/definitions/exampleJSONCustomer")
+	customer := j.Definitions["exampleJSONCustomer"]
+	c.Assert(customer.Properties["email"].Extensions, DeepEquals, map[string]interface{}{
+		"x-classification": []string{"pii.email"},
+	})
+	c.Assert(customer.Properties["phones"].Classifications(), DeepEquals, []string{"pii.phone", "gdpr.personal"})
+	c.Assert(customer.Properties["notes"].Classifications(), IsNil)
+}
+
+type ExampleJSONInvalidClassification struct {
+	Email string `classification:"pii..email"`
+}
+
+func (self *classificationSuite) TestInvalidClassificationTag(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONInvalidClassification{}).Generate()
+	c.Assert(err, ErrorMatches, `.*invalid "classification" tag value "pii..email"`)
+}
+
+func (self *classificationSuite) TestClassify(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONCustomer{}).
+		WithDefinitions(map[string]interface{}{
+			"customer": ExampleJSONCustomer{},
+			"address":  ExampleJSONAddress{},
+		}).MustGenerate()
+
+	c.Assert(Classify(j), DeepEquals, []Classification{
+		{Path: "/address", Labels: []string{"pii.address"}},
+		{Path: "/address/zip", Labels: []string{"pii.address.zip"}},
+		{Path: "/email", Labels: []string{"pii.email"}},
+		{Path: "/phones", Labels: []string{"pii.phone", "gdpr.personal"}},
+	})
+}
@@ -0,0 +1,57 @@
+package jsonschema
+
+// CloudEventsSpecVersion is the CloudEvents specification version described
+// by CloudEventSchema.
+const CloudEventsSpecVersion = "1.0"
+
+// CloudEventDataSchema generates the schema of the root of g, identified by
+// dataSchema, for publication at the URI set in the "dataschema" attribute
+// of CloudEvents carrying that payload.
+func CloudEventDataSchema(g *Generator, dataSchema string) (*JSONSchema, error) {
+	js, err := g.Generate()
+	if err != nil {
+		return nil, err
+	}
+	js.ID = dataSchema
+	return js, nil
+}
+
+// CloudEventSchema generates the schema of a CloudEvent in the structured
+// JSON format, with the schema generated for the root of g embedded under
+// "data". When eventType or dataSchema are not empty, the "type" and
+// "dataschema" attributes are restricted to them.
+func CloudEventSchema(g *Generator, eventType, dataSchema string) (*JSONSchema, error) {
+	payload, err := g.Generate()
+	if err != nil {
+		return nil, err
+	}
+	data := payload.Property
+	minLength := int64ptr(1)
+
+	envelope := &JSONSchema{
+		Schema:      payload.Schema,
+		Definitions: payload.Definitions,
+		Property: Property{
+			Type: "object",
+			Properties: map[string]*Property{
+				"specversion":     {Type: "string", Const: CloudEventsSpecVersion},
+				"id":              {Type: "string", MinLength: minLength},
+				"source":          {Type: "string", Format: "uri-reference", MinLength: minLength},
+				"type":            {Type: "string", MinLength: minLength},
+				"datacontenttype": {Type: "string"},
+				"dataschema":      {Type: "string", Format: "uri", MinLength: minLength},
+				"subject":         {Type: "string", MinLength: minLength},
+				"time":            {Type: "string", Format: "date-time"},
+				"data":            &data,
+			},
+			Required: []string{"specversion", "id", "source", "type"},
+		},
+	}
+	if eventType != "" {
+		envelope.Properties["type"].Const = eventType
+	}
+	if dataSchema != "" {
+		envelope.Properties["dataschema"].Const = dataSchema
+	}
+	return envelope, nil
+}
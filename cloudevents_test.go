@@ -0,0 +1,37 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type cloudEventsSuite struct{}
+
+var _ = Suite(&cloudEventsSuite{})
+
+func (self *cloudEventsSuite) TestCloudEventDataSchema(c *C) {
+	j, err := CloudEventDataSchema(NewGenerator().WithRoot(&ExampleJSONChatMessage{}), "https://example.com/chat.json")
+	c.Assert(err, IsNil)
+	c.Assert(j.ID, Equals, "https://example.com/chat.json")
+	c.Assert(j.Properties["text"], DeepEquals, &Property{Type: "string"})
+}
+
+func (self *cloudEventsSuite) TestCloudEventSchema(c *C) {
+	g := NewGenerator().
+		WithRoot(&ExampleJSONNestedStructReferenceParent{}).
+		WithDefinition("child", ExampleJSONNestedStructReferenceChild{})
+
+	j, err := CloudEventSchema(g, "com.example.parent.created", "https://example.com/parent.json")
+	c.Assert(err, IsNil)
+
+	c.Assert(j.Required, DeepEquals, []string{"specversion", "id", "source", "type"})
+	c.Assert(j.Properties["specversion"].Const, Equals, "1.0")
+	c.Assert(j.Properties["type"].Const, Equals, "com.example.parent.created")
+	c.Assert(j.Properties["dataschema"].Const, Equals, "https://example.com/parent.json")
+	c.Assert(j.Properties["data"].Properties["Child"].Ref, Equals, "#/definitions/child")
+	c.Assert(j.Definitions["child"].Required, DeepEquals, []string{"Foo"})
+
+	j, err = CloudEventSchema(g, "", "")
+	c.Assert(err, IsNil)
+	c.Assert(j.Properties["type"].Const, IsNil)
+	c.Assert(j.Properties["dataschema"].Const, IsNil)
+}
@@ -0,0 +1,37 @@
+// Command jsonschema-vet reports the problems with the tags read by the
+// schema generator in the struct types of packages, e.g. in CI:
+//
+//	jsonschema-vet ./...
+//
+// It exits with status 1 when problems are found.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/naveego/go-json-schema"
+)
+
+func main() {
+	patterns := os.Args[1:]
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	found := false
+	for _, pattern := range patterns {
+		problems, err := jsonschema.VetTypes(pattern)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		for _, p := range problems {
+			fmt.Println(p)
+			found = true
+		}
+	}
+	if found {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,362 @@
+// Package codegen generates Go declarations from JSON schemas, the reverse of
+// the generator of package jsonschema, for contract-first development: a
+// struct per object, with json tags, and a named type per definition, which
+// references resolve to.
+//
+//	var js jsonschema.JSONSchema
+//	if err := json.Unmarshal(b, &js); err != nil {
+//		panic(err)
+//	}
+//	src, err := codegen.Generate(&js, codegen.Options{Package: "api", Root: "Order"})
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/naveego/go-json-schema"
+)
+
+// Options configures the generation of Go declarations.
+type Options struct {
+	// Package is the name of the package of the generated file, "schema" by
+	// default.
+	Package string
+	// Root is the name of the type of the root, by default derived from its
+	// title, or Root. The root isn't declared when it only references a
+	// definition.
+	Root string
+}
+
+// Generate returns the Go source declaring the types of the schema, gofmted.
+//
+// Required properties are held by value and tagged `required:"true"`, so
+// that the generator describes the types with the same schema again. Other
+// properties are held by pointer and omitted when empty, as are nullable
+// values. Definitions become named types, and string enums of definitions
+// named types with a constant per value. Objects described in place become
+// types named after the field holding them, arrays of any kinds slices, maps
+// maps, and unions interface{}.
+func Generate(js *jsonschema.JSONSchema, options Options) ([]byte, error) {
+	if options.Package == "" {
+		options.Package = "schema"
+	}
+	m := js.Model()
+	g := &generator{names: map[string]string{}, taken: map[string]bool{}, imports: map[string]bool{}}
+	definitions := make([]string, 0, len(m.Definitions))
+	for name := range m.Definitions {
+		definitions = append(definitions, name)
+	}
+	sort.Strings(definitions)
+	for _, name := range definitions {
+		g.names[name] = g.unique(exportedName(name))
+	}
+
+	for _, name := range definitions {
+		if err := g.declareAll(g.names[name], m.Definitions[name]); err != nil {
+			return nil, fmt.Errorf("definition %s: %s", name, err)
+		}
+	}
+	if root := m.Root; root.Kind != jsonschema.KindRef || options.Root != "" {
+		name := options.Root
+		if name == "" {
+			name = exportedName(root.Title)
+			if root.Title == "" {
+				name = "Root"
+			}
+		}
+		if err := g.declareAll(g.unique(name), root); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by go-json-schema/codegen. DO NOT EDIT.\n\npackage %s\n\n", options.Package)
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			imports = append(imports, strconv.Quote(path))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&src, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	src.Write(g.decls.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated source: %s", err)
+	}
+	return formatted, nil
+}
+
+type generator struct {
+	// names holds the type names of the definitions
+	names map[string]string
+	// taken holds the type names declared
+	taken   map[string]bool
+	imports map[string]bool
+	decls   bytes.Buffer
+	// pending holds the objects described in place, declared after the
+	// type holding them
+	pending []declaration
+}
+
+type declaration struct {
+	name string
+	node *jsonschema.Node
+}
+
+// declareAll declares the type name described by n, then the objects it
+// describes in place.
+func (g *generator) declareAll(name string, n *jsonschema.Node) error {
+	if err := g.declare(name, n, true); err != nil {
+		return err
+	}
+	for len(g.pending) > 0 {
+		d := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.declare(d.name, d.node, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unique returns name, numbered if taken, and takes it.
+func (g *generator) unique(name string) string {
+	unique := name
+	for i := 2; g.taken[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.taken[unique] = true
+	return unique
+}
+
+// declare writes the declaration of the type name described by n. Definitions
+// which are string enums declare their values as constants, and references
+// aliases.
+func (g *generator) declare(name string, n *jsonschema.Node, definition bool) error {
+	if n.Kind == jsonschema.KindObject {
+		var body bytes.Buffer
+		if err := g.structBody(&body, name, n); err != nil {
+			return err
+		}
+		writeComment(&g.decls, n)
+		fmt.Fprintf(&g.decls, "type %s %s\n\n", name, body.String())
+		return nil
+	}
+	if values, ok := stringValues(n); ok && definition && !n.Nullable {
+		writeComment(&g.decls, n)
+		fmt.Fprintf(&g.decls, "type %s string\n\nconst (\n", name)
+		for _, v := range values {
+			fmt.Fprintf(&g.decls, "%s %s = %s\n", g.unique(name+exportedName(v)), name, strconv.Quote(v))
+		}
+		g.decls.WriteString(")\n\n")
+		return nil
+	}
+	t, err := g.typeOf(name, n)
+	if err != nil {
+		return err
+	}
+	writeComment(&g.decls, n)
+	if n.Kind == jsonschema.KindRef && !n.Nullable {
+		// references are aliases of the types of their definitions
+		t = "= " + t
+	}
+	fmt.Fprintf(&g.decls, "type %s %s\n\n", name, t)
+	return nil
+}
+
+// structBody returns the struct type of an object, whose fields of objects
+// described in place are declared as types named after the struct and field.
+func (g *generator) structBody(buf *bytes.Buffer, name string, n *jsonschema.Node) error {
+	buf.WriteString("struct {\n")
+	fields := map[string]bool{}
+	for _, field := range n.Fields {
+		fieldName := exportedName(field.Name)
+		unique := fieldName
+		for i := 2; fields[unique]; i++ {
+			unique = fieldName + strconv.Itoa(i)
+		}
+		fields[unique] = true
+
+		t, err := g.typeOf(name+unique, field.Node)
+		if err != nil {
+			return fmt.Errorf("property %s: %s", field.Name, err)
+		}
+		tag := strconv.Quote(field.Name) + ` required:"true"`
+		if !field.Required {
+			t, tag = pointer(t), strconv.Quote(field.Name+",omitempty")
+		}
+		writeComment(buf, field.Node)
+		fmt.Fprintf(buf, "%s %s `json:%s`\n", unique, t, tag)
+	}
+	buf.WriteString("}")
+	return nil
+}
+
+// typeOf returns the Go type of the values described by n, declaring the
+// objects it describes in place as types named after name.
+func (g *generator) typeOf(name string, n *jsonschema.Node) (string, error) {
+	t, err := g.typeOfKind(name, n)
+	if err != nil {
+		return "", err
+	}
+	if n.Nullable {
+		t = pointer(t)
+	}
+	return t, nil
+}
+
+func (g *generator) typeOfKind(name string, n *jsonschema.Node) (string, error) {
+	switch n.Kind {
+	case jsonschema.KindRef:
+		if n.Definition == "" {
+			return "", fmt.Errorf("reference %s can't be resolved to a type", n.Ref)
+		}
+		typeName, ok := g.names[n.Definition]
+		if !ok {
+			return "", fmt.Errorf("unresolvable reference %s", n.Ref)
+		}
+		return typeName, nil
+	case jsonschema.KindBoolean:
+		return "bool", nil
+	case jsonschema.KindInteger:
+		if n.Format == "int32" {
+			return "int32", nil
+		}
+		return "int64", nil
+	case jsonschema.KindNumber:
+		return "float64", nil
+	case jsonschema.KindString:
+		switch {
+		case n.Format == "date-time":
+			g.imports["time"] = true
+			return "time.Time", nil
+		case n.Schema != nil && n.Schema.ContentEncoding == "base64":
+			return "[]byte", nil
+		}
+		return "string", nil
+	case jsonschema.KindArray:
+		if n.Elem == nil {
+			return "[]interface{}", nil
+		}
+		items, err := g.typeOf(name+"Item", n.Elem)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + items, nil
+	case jsonschema.KindMap:
+		if n.Elem == nil {
+			return "map[string]interface{}", nil
+		}
+		values, err := g.typeOf(name+"Value", n.Elem)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + values, nil
+	case jsonschema.KindObject:
+		typeName := g.unique(name)
+		g.pending = append(g.pending, declaration{typeName, &jsonschema.Node{Kind: n.Kind, Fields: n.Fields, Elem: n.Elem}})
+		return typeName, nil
+	}
+	return "interface{}", nil
+}
+
+// pointer returns a pointer to t, unless values of t may already be nil.
+func pointer(t string) string {
+	if strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "interface{}" {
+		return t
+	}
+	return "*" + t
+}
+
+// stringValues returns the values of a string enum.
+func stringValues(n *jsonschema.Node) ([]string, bool) {
+	if n.Kind != jsonschema.KindString || len(n.Values) == 0 {
+		return nil, false
+	}
+	values := make([]string, len(n.Values))
+	for i, v := range n.Values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		values[i] = s
+	}
+	return values, true
+}
+
+// writeComment writes the description of n and whether it's deprecated as a
+// comment.
+func writeComment(buf *bytes.Buffer, n *jsonschema.Node) {
+	var lines []string
+	if n.Description != "" {
+		lines = strings.Split(n.Description, "\n")
+	}
+	if n.Deprecated {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Deprecated: deprecated by the schema.")
+	}
+	for _, line := range lines {
+		fmt.Fprintf(buf, "// %s\n", strings.TrimRight(line, " "))
+	}
+}
+
+// initialisms are written in upper case in names, as Go does.
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// exportedName returns an exported Go identifier derived from the name of a
+// property or definition, e.g. PostalCode for postal_code and UserID for
+// userId.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+// words splits a name at the characters which aren't letters or digits, and
+// before the capitals following lower case letters.
+func words(name string) []string {
+	var words []string
+	var word []rune
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && unicode.IsLower(word[len(word)-1]):
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
@@ -0,0 +1,155 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/naveego/go-json-schema"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type codegenSuite struct{}
+
+var _ = Suite(&codegenSuite{})
+
+type ExampleCodegenAddress struct {
+	PostalCode string `json:"postal_code" required:"true"`
+	Country    string `json:"country"`
+}
+
+type ExampleCodegenOrder struct {
+	ID       string                `json:"id" required:"true" description:"The id of the order."`
+	Status   string                `json:"status" enum:"open|closed" required:"true"`
+	Created  time.Time             `json:"created"`
+	Total    float64               `json:"total"`
+	Quantity int                   `json:"quantity"`
+	Shipping ExampleCodegenAddress `json:"shipping"`
+	Lines    []struct {
+		SKU string `json:"sku" required:"true"`
+	} `json:"lines"`
+	Labels map[string]string    `json:"labels"`
+	Parent *ExampleCodegenOrder `json:"parent"`
+}
+
+func (self *codegenSuite) TestGenerate(c *C) {
+	js := jsonschema.NewGenerator().
+		WithRoot(&ExampleCodegenOrder{}).
+		WithDefinition("order", ExampleCodegenOrder{}).
+		WithDefinition("address", ExampleCodegenAddress{}).
+		MustGenerate()
+
+	src, err := Generate(js, Options{Package: "api"})
+	c.Assert(err, IsNil)
+	c.Assert(string(src), Equals, `// Code generated by go-json-schema/codegen. DO NOT EDIT.
+
+package api
+
+import (
+	"time"
+)
+
+type Address struct {
+	Country    *string `+"`json:\"country,omitempty\"`"+`
+	PostalCode string  `+"`json:\"postal_code\" required:\"true\"`"+`
+}
+
+type Order struct {
+	Created *time.Time `+"`json:\"created,omitempty\"`"+`
+	// The id of the order.
+	ID       string            `+"`json:\"id\" required:\"true\"`"+`
+	Labels   map[string]string `+"`json:\"labels,omitempty\"`"+`
+	Lines    []OrderLinesItem  `+"`json:\"lines,omitempty\"`"+`
+	Parent   *Order            `+"`json:\"parent,omitempty\"`"+`
+	Quantity *int64            `+"`json:\"quantity,omitempty\"`"+`
+	Shipping *Address          `+"`json:\"shipping,omitempty\"`"+`
+	Status   string            `+"`json:\"status\" required:\"true\"`"+`
+	Total    *float64          `+"`json:\"total,omitempty\"`"+`
+}
+
+type OrderLinesItem struct {
+	Sku string `+"`json:\"sku\" required:\"true\"`"+`
+}
+`)
+
+	src, err = Generate(js, Options{Package: "api", Root: "Request"})
+	c.Assert(err, IsNil)
+	c.Assert(string(src), Matches, `(?s).*\ntype Request = Order\n$`)
+}
+
+func (self *codegenSuite) TestGenerateFromJSON(c *C) {
+	var js jsonschema.JSONSchema
+	err := json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {
+			"status": {"type": "string", "enum": ["open", "on-hold"], "description": "The status of a ticket."},
+			"user_ref": {"type": "object", "properties": {"userId": {"type": "integer", "format": "int32"}}, "required": ["userId"]}
+		},
+		"title": "ticket",
+		"type": "object",
+		"properties": {
+			"status": {"$ref": "#/$defs/status"},
+			"assignee": {"anyOf": [{"$ref": "#/$defs/user_ref"}, {"type": "null"}]},
+			"reporter": {"$ref": "#/$defs/user_ref"},
+			"payload": {"type": "string", "contentEncoding": "base64"},
+			"value": {"type": ["string", "number"]},
+			"meta": {"type": "object", "properties": {"seen": {"type": "boolean", "deprecated": true}}}
+		},
+		"required": ["status", "assignee"]
+	}`), &js)
+	c.Assert(err, IsNil)
+
+	src, err := Generate(&js, Options{})
+	c.Assert(err, IsNil)
+	c.Assert(string(src), Equals, `// Code generated by go-json-schema/codegen. DO NOT EDIT.
+
+package schema
+
+// The status of a ticket.
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusOnHold Status = "on-hold"
+)
+
+type UserRef struct {
+	UserID int32 `+"`json:\"userId\" required:\"true\"`"+`
+}
+
+type Ticket struct {
+	Assignee *UserRef    `+"`json:\"assignee\" required:\"true\"`"+`
+	Meta     *TicketMeta `+"`json:\"meta,omitempty\"`"+`
+	Payload  []byte      `+"`json:\"payload,omitempty\"`"+`
+	Reporter *UserRef    `+"`json:\"reporter,omitempty\"`"+`
+	Status   Status      `+"`json:\"status\" required:\"true\"`"+`
+	Value    interface{} `+"`json:\"value,omitempty\"`"+`
+}
+
+type TicketMeta struct {
+	// Deprecated: deprecated by the schema.
+	Seen *bool `+"`json:\"seen,omitempty\"`"+`
+}
+`)
+
+	js.Properties["reporter"].Ref = "#/definitions/user"
+	_, err = Generate(&js, Options{})
+	c.Assert(err, ErrorMatches, "property reporter: unresolvable reference #/definitions/user")
+}
+
+func (self *codegenSuite) TestExportedName(c *C) {
+	for name, expected := range map[string]string{
+		"postal_code": "PostalCode",
+		"userId":      "UserID",
+		"api-url":     "APIURL",
+		"HTTPServer":  "HTTPServer",
+		"2fa":         "X2fa",
+		"":            "X",
+	} {
+		c.Check(exportedName(name), Equals, expected, Commentf(name))
+	}
+}
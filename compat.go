@@ -0,0 +1,282 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Incompatibility is a way in which a document valid under a schema may
+// fail to unmarshal into a Go value, or lose data in the process.
+type Incompatibility struct {
+	// Path is the JSON pointer of the property in the schema.
+	Path string
+	// Field is the path of the Go field, e.g. "Domain.NestedItem.Value".
+	Field   string
+	Message string
+}
+
+func (i Incompatibility) String() string {
+	return fmt.Sprintf("%s (%s): %s", i.Field, i.Path, i.Message)
+}
+
+var rTypeTime = reflect.TypeOf(time.Time{})
+
+// isUnmarshaler reports whether values of type t unmarshal themselves, with
+// a value or a pointer receiver. A type assertion is used rather than
+// Type.Implements, which TinyGo doesn't fully support.
+func isUnmarshaler(t reflect.Type) bool {
+	_, ok := reflect.New(t).Interface().(json.Unmarshaler)
+	return ok
+}
+
+// CheckStructAgainstSchema verifies that the documents valid under an external
+// schema unmarshal into the type of instance: that the types align, that the
+// required properties have a field, that integer ranges fit and that the enum
+// values are accepted by the field, for consumer-driven contract testing.
+func CheckStructAgainstSchema(instance interface{}, schema []byte) []Incompatibility {
+	t := reflect.TypeOf(instance)
+	var js JSONSchema
+	if err := json.Unmarshal(schema, &js); err != nil {
+		return []Incompatibility{{Path: "/", Field: t.String(), Message: fmt.Sprintf("invalid schema: %s", err)}}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	c := &compatChecker{schema: &js, visiting: map[string]bool{}}
+	c.check(t.Name(), "", t, "", &js.Property)
+	return c.incompatibilities
+}
+
+type compatChecker struct {
+	schema            *JSONSchema
+	visiting          map[string]bool
+	incompatibilities []Incompatibility
+}
+
+func (c *compatChecker) add(field, path, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	c.incompatibilities = append(c.incompatibilities, Incompatibility{
+		Path:    path,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// check verifies that values described by p unmarshal into type t. enum holds
+// the value of the enum tag of the field, if any.
+func (c *compatChecker) check(field, path string, t reflect.Type, enum string, p *Property) {
+	if p.Ref != "" {
+		if c.visiting[p.Ref+"|"+t.String()] {
+			return
+		}
+		c.visiting[p.Ref+"|"+t.String()] = true
+		defer delete(c.visiting, p.Ref+"|"+t.String())
+	}
+	p = c.schema.resolve(p)
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var branches []*Property
+	for _, s := range append(append([]*Property{}, p.AnyOf...), p.OneOf...) {
+		if s.Type != "null" {
+			branches = append(branches, s)
+		}
+	}
+	if len(branches) > 0 && p.Type == "" {
+		for _, s := range branches {
+			c.check(field, path, t, enum, s)
+		}
+		return
+	}
+
+	switch {
+	case t.Kind() == reflect.Interface:
+		return
+	case t == rTypeTime:
+		if p.Type != "string" || p.Format != "date-time" {
+			c.add(field, path, "time.Time only accepts date-time strings, the schema allows %s", describeSchemaType(p))
+		}
+		return
+	case isUnmarshaler(t):
+		// custom unmarshaling can't be checked
+		return
+	}
+
+	if p.Type == "" {
+		c.add(field, path, "%s does not accept any value, the schema allows any value", t)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		c.expectType(field, path, t, p, "string")
+		c.checkEnum(field, path, enum, p)
+	case reflect.Bool:
+		c.expectType(field, path, t, p, "boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if c.expectType(field, path, t, p, "integer") {
+			c.checkRange(field, path, t, p)
+		}
+	case reflect.Float32, reflect.Float64:
+		if p.Type != "number" && p.Type != "integer" {
+			c.add(field, path, "%s does not accept %s values", t, p.Type)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			c.expectType(field, path, t, p, "string")
+			return
+		}
+		if c.expectType(field, path, t, p, "array") && p.Items != nil {
+			c.check(field, path+"/items", t.Elem(), "", p.Items)
+		}
+	case reflect.Map:
+		if !c.expectType(field, path, t, p, "object") {
+			return
+		}
+		for _, name := range sortedPropertyNames(p.Properties) {
+			c.check(field, path+"/properties/"+escapePointer(name), t.Elem(), "", p.Properties[name])
+		}
+		if p.AdditionalPropertiesSchema != nil {
+			c.check(field, path+"/additionalProperties", t.Elem(), "", p.AdditionalPropertiesSchema)
+		}
+	case reflect.Struct:
+		if c.expectType(field, path, t, p, "object") {
+			c.checkFields(field, path, t, p)
+		}
+	default:
+		c.add(field, path, "%s can't be unmarshaled from JSON", t)
+	}
+}
+
+func (c *compatChecker) expectType(field, path string, t reflect.Type, p *Property, expected string) bool {
+	if p.Type != expected {
+		c.add(field, path, "%s does not accept %s values", t, p.Type)
+		return false
+	}
+	return true
+}
+
+// checkFields verifies that the properties of the schema have matching fields,
+// using the same case-insensitive matching as encoding/json.
+func (c *compatChecker) checkFields(field, path string, t reflect.Type, p *Property) {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _ := parseTag(f.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+
+	for _, name := range sortedPropertyNames(p.Properties) {
+		propertyPath := path + "/properties/" + escapePointer(name)
+		f, ok := fields[name]
+		if !ok {
+			for n, candidate := range fields {
+				if strings.EqualFold(n, name) {
+					f, ok = candidate, true
+					break
+				}
+			}
+		}
+		if !ok {
+			if containsString(p.Required, name) {
+				c.add(field, propertyPath, "required property %s has no field", name)
+			}
+			continue
+		}
+		c.check(field+"."+f.Name, propertyPath, f.Type, f.Tag.Get("enum"), p.Properties[name])
+	}
+}
+
+func (c *compatChecker) checkEnum(field, path, enum string, p *Property) {
+	if enum == "" {
+		return
+	}
+	accepted := strings.Split(enum, "|")
+	if len(p.Enum) == 0 {
+		c.add(field, path, "the field only accepts %s, the schema allows any string", strings.Join(accepted, ", "))
+		return
+	}
+	for _, v := range p.Enum {
+		if s, ok := v.(string); !ok || !containsString(accepted, s) {
+			c.add(field, path, "enum value %s is not accepted by the field", formatValue(v))
+		}
+	}
+}
+
+func (c *compatChecker) checkRange(field, path string, t reflect.Type, p *Property) {
+	var min, max float64
+	bits := float64(t.Bits())
+	unsigned := false
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		min, max = 0, math.Pow(2, bits)-1
+		unsigned = true
+	default:
+		min, max = -math.Pow(2, bits-1), math.Pow(2, bits-1)-1
+	}
+
+	lower := math.Inf(-1)
+	if p.Minimum != nil {
+		lower = *p.Minimum
+	}
+	if p.ExclusiveMinimum != nil && *p.ExclusiveMinimum >= lower {
+		lower = *p.ExclusiveMinimum + 1
+	}
+	upper := math.Inf(1)
+	if p.Maximum != nil {
+		upper = *p.Maximum
+	}
+	if p.ExclusiveMaximum != nil && *p.ExclusiveMaximum <= upper {
+		upper = *p.ExclusiveMaximum - 1
+	}
+
+	// 64 bit integers hold all the integers used in practice, so they are
+	// only reported when the schema explicitly allows values out of range.
+	if bits == 64 && math.IsInf(upper, 1) {
+		upper = max
+	}
+	if bits == 64 && !unsigned && math.IsInf(lower, -1) {
+		lower = min
+	}
+
+	if lower < min || upper > max {
+		c.add(field, path, "%s only holds values from %v to %v, the schema allows %s to %s",
+			t, min, max, formatBound(lower), formatBound(upper))
+	}
+}
+
+func formatBound(f float64) string {
+	if math.IsInf(f, 0) {
+		return "unbounded"
+	}
+	return fmt.Sprint(f)
+}
+
+func describeSchemaType(p *Property) string {
+	if p.Type == "" {
+		return "any value"
+	}
+	if p.Format != "" {
+		return fmt.Sprintf("%s values of format %s", p.Type, p.Format)
+	}
+	return p.Type + " values"
+}
@@ -0,0 +1,76 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type compatSuite struct{}
+
+var _ = Suite(&compatSuite{})
+
+type ExampleJSONConsumer struct {
+	ID       int8               `json:"id"`
+	Count    uint               `json:"count"`
+	Ratio    float32            `json:"ratio"`
+	Fruit    string             `json:"fruit" enum:"apple|banana"`
+	Created  time.Time          `json:"created"`
+	Tags     []string           `json:"tags"`
+	Labels   map[string]int     `json:"labels"`
+	Raw      json.RawMessage    `json:"raw"`
+	Any      interface{}        `json:"any"`
+	Nickname *string            `json:"nickname"`
+	Address  ExampleJSONAddress `json:"address"`
+}
+
+func (self *compatSuite) TestCompatible(c *C) {
+	schema := NewGenerator().WithRoot(&ExampleJSONConsumer{}).MustGenerate()
+	schema.Properties["id"].Minimum = float64ptr(-10)
+	schema.Properties["id"].ExclusiveMaximum = float64ptr(128)
+	schema.Properties["count"].Minimum = float64ptr(0)
+
+	c.Assert(CheckStructAgainstSchema(&ExampleJSONConsumer{}, []byte(schema.String())), HasLen, 0)
+}
+
+func (self *compatSuite) TestIncompatible(c *C) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "maximum": 1000},
+			"count": {"type": "integer", "minimum": -1},
+			"ratio": {"type": "string"},
+			"fruit": {"type": "string", "enum": ["apple", "cherry"]},
+			"created": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "integer"}},
+			"labels": {"type": "object", "properties": {".*": {"type": "number"}}},
+			"raw": {"type": "boolean"},
+			"nickname": {"anyOf": [{"type": "string"}, {"type": "null"}]},
+			"ADDRESS": {"$ref": "#/definitions/address"},
+			"version": {"type": "integer"}
+		},
+		"required": ["version"],
+		"definitions": {
+			"address": {"type": "object", "properties": {"street": {"type": "boolean"}}}
+		}
+	}`)
+
+	var lines []string
+	for _, i := range CheckStructAgainstSchema(&ExampleJSONConsumer{}, schema) {
+		lines = append(lines, i.String())
+	}
+	c.Assert(lines, DeepEquals, []string{
+		"ExampleJSONConsumer.Address.Street (/properties/ADDRESS/properties/street): string does not accept boolean values",
+		"ExampleJSONConsumer.Count (/properties/count): uint only holds values from 0 to 1.8446744073709552e+19, the schema allows -1 to 1.8446744073709552e+19",
+		"ExampleJSONConsumer.Created (/properties/created): time.Time only accepts date-time strings, the schema allows string values",
+		"ExampleJSONConsumer.Fruit (/properties/fruit): enum value \"cherry\" is not accepted by the field",
+		"ExampleJSONConsumer.ID (/properties/id): int8 only holds values from -128 to 127, the schema allows unbounded to 1000",
+		"ExampleJSONConsumer.Labels (/properties/labels/properties/.*): int does not accept number values",
+		"ExampleJSONConsumer.Ratio (/properties/ratio): float32 does not accept string values",
+		"ExampleJSONConsumer.Tags (/properties/tags/items): string does not accept integer values",
+		"ExampleJSONConsumer (/properties/version): required property version has no field",
+	})
+
+	c.Assert(CheckStructAgainstSchema(&ExampleJSONConsumer{}, []byte("{")), HasLen, 1)
+}
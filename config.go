@@ -0,0 +1,122 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigOptions returns the options describing configuration structs loaded
+// with mapstructure, e.g. by viper, as the files users write: property names
+// are read from the mapstructure tag, falling back to the yaml and json tags,
+// and fields without name are named in lower case, as viper matches keys
+// case-insensitively. The fields of squashed structs are properties of the
+// enclosing object, and a remain field holds the properties not described.
+func ConfigOptions() Options {
+	return Options{
+		JSONTagName:      "mapstructure",
+		FallbackTagNames: []string{"yaml", "json"},
+		LowercaseNames:   true,
+	}
+}
+
+// nameTag returns the value of the tag holding the name and options of a
+// property: the tag named by Options.JSONTagName, or else the first of the
+// fallback tags set.
+func (r *reader) nameTag(tag reflect.StructTag) string {
+	for _, name := range append([]string{r.options.JSONTagName}, r.options.FallbackTagNames...) {
+		if value, ok := tag.Lookup(name); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// squash reads the fields of the struct field into n, as mapstructure does
+// for fields with the squash option.
+func (r *reader) squash(n *Node, field goField) error {
+	t := field.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf(`"squash" option on %s, which is not a struct`, field.Type)
+	}
+	embedded := newNode()
+	if err := r.readFromStruct(embedded, t); err != nil {
+		return err
+	}
+	squashInto(n, embedded)
+	return nil
+}
+
+// squashInto merges the fields of the squashed struct embedded into n.
+func squashInto(n, embedded *Node) {
+	// the fields of the enclosing struct take precedence
+	fields := append([]*Field{}, embedded.Fields...)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	for _, f := range fields {
+		if n.field(f.Name) == nil {
+			n.Fields = append(n.Fields, f)
+		}
+	}
+	p := n.Schema
+	for name, s := range embedded.Schema.Dependencies {
+		if _, ok := p.Dependencies[name]; !ok {
+			*dependency(&p.Dependencies, name) = *s
+		}
+	}
+	for name, s := range embedded.Schema.DependentSchemas {
+		if _, ok := p.DependentSchemas[name]; !ok {
+			*dependency(&p.DependentSchemas, name) = *s
+		}
+	}
+	for name, required := range embedded.Schema.DependentRequired {
+		if _, ok := p.DependentRequired[name]; !ok {
+			if p.DependentRequired == nil {
+				p.DependentRequired = map[string][]string{}
+			}
+			p.DependentRequired[name] = required
+		}
+	}
+}
+
+// remain describes the properties of n not described by its fields with the
+// values of the map field, as mapstructure collects them in fields with the
+// remain option.
+func (r *reader) remain(n *Node, field goField) error {
+	t := field.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Map {
+		return fmt.Errorf(`"remain" option on %s, which is not a map`, field.Type)
+	}
+	values := newNode()
+	if err := r.read(values, t.Elem()); err != nil {
+		return err
+	}
+	remainInto(n, values)
+	return nil
+}
+
+// remainInto describes the properties of n not described by its fields with
+// values.
+func remainInto(n, values *Node) {
+	if values.Kind == KindAny && !values.Nullable {
+		// values of any type
+		n.Schema.AdditionalProperties = true
+		return
+	}
+	n.Elem = values
+	n.patternValues = false
+}
+
+// propertyName returns the name of the property of a field without name tag.
+func (r *reader) propertyName(fieldName string) string {
+	if r.options.LowercaseNames {
+		return strings.ToLower(fieldName)
+	}
+	return fieldName
+}
@@ -0,0 +1,57 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type configSuite struct{}
+
+var _ = Suite(&configSuite{})
+
+type ExampleConfigLogging struct {
+	Level  string `mapstructure:"level" enum:"debug|info|warn|error"`
+	Format string `yaml:"format"`
+}
+
+type ExampleConfigCommon struct {
+	Name    string `mapstructure:"name" required:"true"`
+	Verbose bool
+}
+
+type ExampleConfig struct {
+	ExampleConfigCommon `mapstructure:",squash"`
+	ListenAddress       string                 `mapstructure:"listen_address" json:"listenAddress"`
+	Timeout             int                    `json:"timeout_seconds"`
+	Logging             ExampleConfigLogging   `mapstructure:"logging"`
+	Extra               map[string]interface{} `mapstructure:",remain"`
+}
+
+func (self *configSuite) TestConfigOptions(c *C) {
+	j := NewGenerator(ConfigOptions()).WithRoot(&ExampleConfig{}).MustGenerate()
+
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{
+		"listen_address", "logging", "name", "timeout_seconds", "verbose",
+	})
+	c.Assert(j.Required, DeepEquals, []string{"name"})
+	c.Assert(j.AdditionalProperties, Equals, true)
+	c.Assert(sortedPropertyNames(j.Properties["logging"].Properties), DeepEquals, []string{"format", "level"})
+	c.Assert(j.Properties["logging"].Properties["level"].Enum, DeepEquals, []interface{}{"debug", "info", "warn", "error"})
+}
+
+type ExampleConfigTypedRemain struct {
+	Name   string            `mapstructure:"name"`
+	Labels map[string]string `mapstructure:",remain"`
+}
+
+type ExampleConfigInvalidSquash struct {
+	Name string `mapstructure:",squash"`
+}
+
+func (self *configSuite) TestRemainAndSquash(c *C) {
+	j := NewGenerator(ConfigOptions()).WithRoot(&ExampleConfigTypedRemain{}).MustGenerate()
+	c.Assert(j.AdditionalPropertiesSchema, DeepEquals, &Property{Type: "string"})
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{"name"})
+
+	_, err := NewGenerator(ConfigOptions()).WithRoot(&ExampleConfigInvalidSquash{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Name:"squash" option on string, which is not a struct`)
+}
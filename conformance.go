@@ -0,0 +1,150 @@
+package jsonschema
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// KeywordSupport is the support of a keyword of a draft by the generator and
+// the validator.
+type KeywordSupport struct {
+	Keyword string
+	// Generated is whether the generator emits the keyword.
+	Generated bool
+	// Validated is whether validators check the keyword: the tests of the
+	// JSON-Schema-Test-Suite for the keyword which are run all pass.
+	Validated bool
+	// Note qualifies the support of the keyword, when partial.
+	Note string
+}
+
+// conformanceSuite holds the tests of the JSON-Schema-Test-Suite for the
+// keywords the generator emits, by draft, which ConformanceReport runs.
+//
+//go:embed conformance/*/*.json
+var conformanceSuite embed.FS
+
+// draftOrder orders the drafts by publication.
+var draftOrder = map[Draft]int{
+	Draft04:     1,
+	Draft07:     2,
+	Draft201909: 3,
+	Draft202012: 4,
+}
+
+// suiteDrafts names the directories of the tests of the drafts in the suite.
+var suiteDrafts = map[Draft]string{
+	Draft04:     "draft4",
+	Draft07:     "draft7",
+	Draft201909: "draft2019-09",
+	Draft202012: "draft2020-12",
+}
+
+// keywordSupports is the support of the assertion and applicator keywords by
+// the generator, with notes on the partial support found by the runs of the
+// suite. Keywords are part of the drafts from since to until, when set.
+var keywordSupports = []struct {
+	KeywordSupport
+	since, until Draft
+}{
+	{KeywordSupport: KeywordSupport{Keyword: "$ref", Generated: true, Note: "JSON pointers within the schema only, not anchors or remote references; the siblings of $ref apply in every draft"}},
+	{KeywordSupport: KeywordSupport{Keyword: "definitions", Generated: true, Note: "only tested against the meta-schema, which isn't loaded"}, until: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "$defs", Generated: true, Note: "only tested against the meta-schema, which isn't loaded"}, since: Draft201909},
+	{KeywordSupport: KeywordSupport{Keyword: "type", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "enum", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "const", Generated: true, Note: "a null const isn't told from no const"}, since: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "format", Generated: true, Note: "checked by validators created with WithFormatAssertion"}},
+	{KeywordSupport: KeywordSupport{Keyword: "minimum", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "maximum", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "exclusiveMinimum", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "exclusiveMaximum", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "multipleOf", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "minLength", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "maxLength", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "pattern", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "items", Generated: true, Note: "a single schema for all the items"}},
+	{KeywordSupport: KeywordSupport{Keyword: "additionalItems"}, until: Draft201909},
+	{KeywordSupport: KeywordSupport{Keyword: "prefixItems"}, since: Draft202012},
+	{KeywordSupport: KeywordSupport{Keyword: "minItems", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "maxItems", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "uniqueItems"}},
+	{KeywordSupport: KeywordSupport{Keyword: "contains"}, since: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "required", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "properties", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "additionalProperties", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "patternProperties"}},
+	{KeywordSupport: KeywordSupport{Keyword: "propertyNames"}, since: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "minProperties"}},
+	{KeywordSupport: KeywordSupport{Keyword: "maxProperties"}},
+	{KeywordSupport: KeywordSupport{Keyword: "dependencies", Generated: true, Note: "dependencies on schemas only, not on arrays of names"}, until: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "dependentRequired", Generated: true}, since: Draft201909},
+	{KeywordSupport: KeywordSupport{Keyword: "dependentSchemas", Generated: true}, since: Draft201909},
+	{KeywordSupport: KeywordSupport{Keyword: "allOf"}},
+	{KeywordSupport: KeywordSupport{Keyword: "anyOf", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "oneOf", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "not", Generated: true}},
+	{KeywordSupport: KeywordSupport{Keyword: "if"}, since: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "then"}, since: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "else"}, since: Draft07},
+	{KeywordSupport: KeywordSupport{Keyword: "unevaluatedItems"}, since: Draft201909},
+	{KeywordSupport: KeywordSupport{Keyword: "unevaluatedProperties"}, since: Draft201909},
+}
+
+// ConformanceReport returns the support of the assertion and applicator
+// keywords of the draft by the generator and the validator, sorted by
+// keyword, so that the features relied on can be checked. Validated is found
+// by running the tests of the JSON-Schema-Test-Suite embedded in the package
+// for the keywords emitted, with RunTestSuite.
+func ConformanceReport(draft Draft) ([]KeywordSupport, error) {
+	order, ok := draftOrder[draft]
+	if !ok {
+		return nil, fmt.Errorf("unknown draft %q", draft)
+	}
+	validated, err := validatedKeywords(suiteDrafts[draft])
+	if err != nil {
+		return nil, err
+	}
+	var report []KeywordSupport
+	for _, k := range keywordSupports {
+		if k.since != "" && order < draftOrder[k.since] || k.until != "" && order > draftOrder[k.until] {
+			continue
+		}
+		support := k.KeywordSupport
+		support.Validated = validated[support.Keyword]
+		report = append(report, support)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Keyword < report[j].Keyword
+	})
+	return report, nil
+}
+
+// validatedKeywords runs the embedded tests of a draft of the suite, and
+// tells for each keyword tested whether the tests run all pass. Keywords
+// whose tests are all skipped aren't validated.
+func validatedKeywords(draft string) (map[string]bool, error) {
+	fsys, err := fs.Sub(conformanceSuite, "conformance")
+	if err != nil {
+		return nil, err
+	}
+	run, err := RunTestSuite(fsys, draft)
+	if err != nil {
+		return nil, err
+	}
+	validated := map[string]bool{}
+	for _, result := range run.Results {
+		if result.Skipped != "" {
+			continue
+		}
+		// the files are named after the keywords without their "$"
+		keyword := result.Keyword
+		if keyword == "ref" || keyword == "defs" {
+			keyword = "$" + keyword
+		}
+		passed, ok := validated[keyword]
+		validated[keyword] = (passed || !ok) && result.Passed
+	}
+	return validated, nil
+}
@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Inconsistency is a combination of keywords which no value can satisfy, or
+// which contradict each other, e.g. a minimum greater than the maximum.
+type Inconsistency struct {
+	// Path is the JSON pointer of the subschema in the schema.
+	Path    string
+	Message string
+}
+
+func (i Inconsistency) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// CheckConsistency reports the subschemas of the schema whose keywords are
+// inconsistent, typically because of contradicting tags: bounds which no
+// value is within, defaults and consts which are not among the values of the
+// enum, or enum values not satisfying the length constraints.
+func (d *JSONSchema) CheckConsistency() []Inconsistency {
+	var found []Inconsistency
+	checkConsistency(&found, "", &d.Property)
+	for _, name := range sortedDefinitionNames(d.Definitions) {
+		def := d.Definitions[name]
+		checkConsistency(&found, "/definitions/"+escapePointer(name), &def)
+	}
+	return found
+}
+
+// inconsistencyError fails the generation of a schema with inconsistencies in
+// Strict mode.
+func inconsistencyError(inconsistencies []Inconsistency) error {
+	messages := make([]string, len(inconsistencies))
+	for i, inconsistency := range inconsistencies {
+		messages[i] = inconsistency.String()
+	}
+	return fmt.Errorf("inconsistent schema: %s", strings.Join(messages, "; "))
+}
+
+func checkConsistency(found *[]Inconsistency, path string, p *Property) {
+	if p == nil {
+		return
+	}
+	add := func(format string, args ...interface{}) {
+		*found = append(*found, Inconsistency{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	lower, lowerKeyword, lowerExclusive := p.Minimum, "minimum", false
+	if p.ExclusiveMinimum != nil && (lower == nil || *p.ExclusiveMinimum >= *lower) {
+		lower, lowerKeyword, lowerExclusive = p.ExclusiveMinimum, "exclusiveMinimum", true
+	}
+	upper, upperKeyword, upperExclusive := p.Maximum, "maximum", false
+	if p.ExclusiveMaximum != nil && (upper == nil || *p.ExclusiveMaximum <= *upper) {
+		upper, upperKeyword, upperExclusive = p.ExclusiveMaximum, "exclusiveMaximum", true
+	}
+	if lower != nil && upper != nil {
+		if *lower > *upper || *lower == *upper && (lowerExclusive || upperExclusive) {
+			add("no value is within %s %v and %s %v", lowerKeyword, *lower, upperKeyword, *upper)
+		}
+	}
+	if p.MinLength != nil && p.MaxLength != nil && *p.MinLength > *p.MaxLength {
+		add("minLength %d is greater than maxLength %d", *p.MinLength, *p.MaxLength)
+	}
+	if p.MinItems != nil && p.MaxItems != nil && *p.MinItems > *p.MaxItems {
+		add("minItems %d is greater than maxItems %d", *p.MinItems, *p.MaxItems)
+	}
+
+	if len(p.Enum) > 0 {
+		values, _ := enumStrings(p.Enum)
+		for _, v := range values {
+			length := int64(utf8.RuneCountInString(v))
+			if p.MinLength != nil && length < *p.MinLength {
+				add("enum value %q is shorter than minLength %d", v, *p.MinLength)
+			}
+			if p.MaxLength != nil && length > *p.MaxLength {
+				add("enum value %q is longer than maxLength %d", v, *p.MaxLength)
+			}
+		}
+		if p.Const != nil && !inEnum(p.Enum, p.Const) {
+			add("const %v is not one of the enum values %s", formatValue(p.Const), quoteList(p.Enum))
+		}
+		if p.Default != nil && !inEnum(p.Enum, p.Default) {
+			add("default %v is not one of the enum values %s", formatValue(p.Default), quoteList(p.Enum))
+		}
+	}
+	if p.Const != nil && p.Default != nil && !jsonEqual(p.Const, p.Default) {
+		add("default %v is not the const %v", formatValue(p.Default), formatValue(p.Const))
+	}
+
+	checkConsistency(found, path+"/items", p.Items)
+	checkConsistency(found, path+"/additionalProperties", p.AdditionalPropertiesSchema)
+	checkConsistency(found, path+"/not", p.Not)
+	for _, name := range sortedPropertyNames(p.Properties) {
+		checkConsistency(found, path+"/properties/"+escapePointer(name), p.Properties[name])
+	}
+	for _, name := range sortedPropertyNames(p.Dependencies) {
+		checkConsistency(found, path+"/dependencies/"+escapePointer(name), p.Dependencies[name])
+	}
+	for _, name := range sortedPropertyNames(p.DependentSchemas) {
+		checkConsistency(found, path+"/dependentSchemas/"+escapePointer(name), p.DependentSchemas[name])
+	}
+	for i, branch := range p.AnyOf {
+		checkConsistency(found, fmt.Sprintf("%s/anyOf/%d", path, i), branch)
+	}
+	for i, branch := range p.OneOf {
+		checkConsistency(found, fmt.Sprintf("%s/oneOf/%d", path, i), branch)
+	}
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if jsonEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,76 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type consistencySuite struct{}
+
+var _ = Suite(&consistencySuite{})
+
+type ExampleJSONInconsistent struct {
+	Percent int      `json:"percent" min:"100" max:"0"`
+	Ratio   float64  `json:"ratio" exclusiveMin:"1" max:"1"`
+	Code    string   `json:"code" minLength:"3" maxLength:"2"`
+	Level   string   `json:"level" enum:"low|high" const:"medium"`
+	Size    string   `json:"size" enum:"s|xl" minLength:"2"`
+	Items   []string `json:"items"`
+}
+
+type ExampleJSONConsistent struct {
+	Percent int    `json:"percent" min:"0" max:"100"`
+	Level   string `json:"level" enum:"low|high"`
+}
+
+func (self *consistencySuite) TestWarnings(c *C) {
+	g := NewGenerator().WithRoot(&ExampleJSONInconsistent{})
+	_, err := g.Generate()
+	c.Assert(err, IsNil)
+
+	messages := []string{}
+	for _, w := range g.Warnings() {
+		messages = append(messages, w.String())
+	}
+	c.Assert(messages, DeepEquals, []string{
+		`/properties/code: minLength 3 is greater than maxLength 2`,
+		`/properties/level: const "medium" is not one of the enum values "low", "high"`,
+		`/properties/percent: no value is within minimum 100 and maximum 0`,
+		`/properties/ratio: no value is within exclusiveMinimum 1 and maximum 1`,
+		`/properties/size: enum value "s" is shorter than minLength 2`,
+	})
+
+	g = NewGenerator().WithRoot(&ExampleJSONConsistent{})
+	g.MustGenerate()
+	c.Assert(g.Warnings(), HasLen, 0)
+}
+
+func (self *consistencySuite) TestStrict(c *C) {
+	_, err := NewGenerator(Options{Strict: true}).WithRoot(&ExampleJSONInconsistent{}).Generate()
+	c.Assert(err, ErrorMatches, `inconsistent schema: /properties/code: minLength 3 is greater than maxLength 2; .*`)
+
+	_, err = NewGenerator(Options{Strict: true}).WithRoot(&ExampleJSONConsistent{}).Generate()
+	c.Assert(err, IsNil)
+}
+
+func (self *consistencySuite) TestDefaults(c *C) {
+	js := &JSONSchema{
+		Definitions: map[string]Property{
+			"level": {Type: "string", Enum: []interface{}{"low", "high"}, Default: "medium"},
+			"kind":  {Type: "string", Const: "order", Default: "invoice"},
+			"count": {Type: "integer", Minimum: float64ptr(1), ExclusiveMaximum: float64ptr(1)},
+		},
+	}
+	c.Assert(js.CheckConsistency(), DeepEquals, []Inconsistency{
+		{Path: "/definitions/count", Message: "no value is within minimum 1 and exclusiveMaximum 1"},
+		{Path: "/definitions/kind", Message: `default "invoice" is not the const "order"`},
+		{Path: "/definitions/level", Message: `default "medium" is not one of the enum values "low", "high"`},
+	})
+}
+
+func (self *consistencySuite) TestGenerateSetWarnings(c *C) {
+	g := NewGenerator().WithDefinition("consistent", ExampleJSONConsistent{})
+	_, err := g.GenerateSet(map[string]interface{}{"inconsistent": ExampleJSONInconsistent{}})
+	c.Assert(err, IsNil)
+	c.Assert(g.Warnings(), HasLen, 5)
+	c.Assert(g.Warnings()[0].Path, Equals, "/roots/inconsistent/properties/code")
+}
@@ -0,0 +1,170 @@
+package jsonschema
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldConstraints describes a field of the documents described by a schema,
+// as listed for data stewards.
+type FieldConstraints struct {
+	// Path is the JSON pointer of the field in the documents, where "*"
+	// stands for any array item or map value.
+	Path     string
+	Type     string
+	Required bool
+	// Constraints holds the validation keywords of the field, e.g. "maxLength=8".
+	Constraints     []string
+	Description     string
+	Classifications []string
+}
+
+// ListConstraints lists the fields of the documents described by the schema,
+// sorted by path, with their type and constraints.
+func ListConstraints(schema *JSONSchema) []FieldConstraints {
+	l := &constraintLister{schema: schema, visiting: map[string]bool{}}
+	l.walkChildren("", l.schema.resolve(&schema.Property))
+	sort.SliceStable(l.fields, func(i, j int) bool {
+		return l.fields[i].Path < l.fields[j].Path
+	})
+	return l.fields
+}
+
+// WriteConstraintsCSV writes the fields listed by ListConstraints as CSV, with
+// a header row, for spreadsheets. Constraints are separated by semicolons and
+// classification labels by vertical bars.
+func WriteConstraintsCSV(w io.Writer, schema *JSONSchema) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"path", "type", "required", "constraints", "description", "classification"})
+	for _, f := range ListConstraints(schema) {
+		cw.Write([]string{
+			f.Path,
+			f.Type,
+			strconv.FormatBool(f.Required),
+			strings.Join(f.Constraints, "; "),
+			f.Description,
+			strings.Join(f.Classifications, "|"),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type constraintLister struct {
+	schema   *JSONSchema
+	visiting map[string]bool
+	fields   []FieldConstraints
+}
+
+func (l *constraintLister) walk(path string, p *Property, required bool) {
+	if p == nil {
+		return
+	}
+	if ref := p.Ref; ref != "" {
+		// recursive definitions are only visited once per path
+		if l.visiting[ref] {
+			return
+		}
+		l.visiting[ref] = true
+		defer delete(l.visiting, ref)
+	}
+
+	f := FieldConstraints{Path: path, Required: required, Description: p.Description}
+	f.Classifications = p.Classifications()
+	if resolved := l.schema.resolve(p); resolved != p {
+		if f.Description == "" {
+			f.Description = resolved.Description
+		}
+		f.Classifications = append(f.Classifications, resolved.Classifications()...)
+		p = resolved
+	}
+
+	types := p.Types
+	value := p
+	if i, ok := nullableBranch(p.AnyOf); ok && p.Type == "" {
+		value = l.schema.resolve(p.AnyOf[i])
+		types = []string{value.Type, "null"}
+	}
+	if len(types) == 0 && value.Type != "" {
+		types = []string{value.Type}
+	}
+	f.Type = strings.Join(types, "|")
+	f.Constraints = constraintsOf(value)
+	if value != p {
+		// the keywords validating nullable values may be set along the anyOf
+		f.Constraints = append(constraintsOf(p), f.Constraints...)
+	}
+	if p.Deprecated {
+		f.Constraints = append(f.Constraints, "deprecated")
+	}
+	l.fields = append(l.fields, f)
+
+	l.walkChildren(path, value)
+}
+
+func (l *constraintLister) walkChildren(path string, p *Property) {
+	for name, s := range p.Properties {
+		required := containsString(p.Required, name)
+		if name == ".*" {
+			name = "*"
+		}
+		l.walk(path+"/"+escapePointer(name), s, required)
+	}
+	l.walk(path+"/*", p.Items, false)
+	l.walk(path+"/*", p.AdditionalPropertiesSchema, false)
+}
+
+// constraintsOf returns the validation keywords of p, formatted as keyword=value.
+func constraintsOf(p *Property) []string {
+	var c []string
+	add := func(keyword string, value interface{}) {
+		c = append(c, fmt.Sprintf("%s=%v", keyword, value))
+	}
+	if p.Format != "" {
+		add("format", p.Format)
+	}
+	if p.ContentEncoding != "" {
+		add("contentEncoding", p.ContentEncoding)
+	}
+	if len(p.Enum) > 0 {
+		add("enum", joinEnum(p.Enum))
+	}
+	if p.Const != nil {
+		add("const", p.Const)
+	}
+	if p.Pattern != "" {
+		add("pattern", p.Pattern)
+	}
+	if p.MinLength != nil {
+		add("minLength", *p.MinLength)
+	}
+	if p.MaxLength != nil {
+		add("maxLength", *p.MaxLength)
+	}
+	if p.MinItems != nil {
+		add("minItems", *p.MinItems)
+	}
+	if p.MaxItems != nil {
+		add("maxItems", *p.MaxItems)
+	}
+	if p.Minimum != nil {
+		add("minimum", *p.Minimum)
+	}
+	if p.Maximum != nil {
+		add("maximum", *p.Maximum)
+	}
+	if p.ExclusiveMinimum != nil {
+		add("exclusiveMinimum", *p.ExclusiveMinimum)
+	}
+	if p.ExclusiveMaximum != nil {
+		add("exclusiveMaximum", *p.ExclusiveMaximum)
+	}
+	if p.MultipleOf != nil {
+		add("multipleOf", *p.MultipleOf)
+	}
+	return c
+}
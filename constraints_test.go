@@ -0,0 +1,62 @@
+package jsonschema
+
+import (
+	"bytes"
+
+	. "gopkg.in/check.v1"
+)
+
+type constraintsSuite struct{}
+
+var _ = Suite(&constraintsSuite{})
+
+type ExampleJSONStewardContact struct {
+	Email string `json:"email" classification:"pii.email" description:"Contact email"`
+}
+
+type ExampleJSONStewardCustomer struct {
+	ID       string                     `json:"id" required:"true" minLength:"3" maxLength:"8" description:"Customer id"`
+	Status   string                     `json:"status" enum:"new|active"`
+	Age      *int                       `json:"age" min:"0" max:"150"`
+	Nickname *string                    `json:"nickname"`
+	Contact  *ExampleJSONStewardContact `json:"contact" description:"Main contact, \"primary\""`
+	Tags     []string                   `json:"tags"`
+}
+
+func (self *constraintsSuite) TestListConstraints(c *C) {
+	js := NewGenerator().
+		WithDefinition("contact", ExampleJSONStewardContact{}).
+		WithRoot(&ExampleJSONStewardCustomer{}).
+		MustGenerate()
+
+	c.Assert(ListConstraints(js), DeepEquals, []FieldConstraints{
+		{Path: "/age", Type: "integer", Constraints: []string{"minimum=0", "maximum=150"}},
+		{Path: "/contact", Type: "object", Description: `Main contact, "primary"`},
+		{Path: "/contact/email", Type: "string", Description: "Contact email", Classifications: []string{"pii.email"}},
+		{Path: "/id", Type: "string", Required: true, Description: "Customer id", Constraints: []string{"minLength=3", "maxLength=8"}},
+		{Path: "/nickname", Type: "string|null"},
+		{Path: "/status", Type: "string", Constraints: []string{"enum=new|active"}},
+		{Path: "/tags", Type: "array"},
+		{Path: "/tags/*", Type: "string"},
+	})
+}
+
+func (self *constraintsSuite) TestWriteConstraintsCSV(c *C) {
+	js := NewGenerator().
+		WithDefinition("contact", ExampleJSONStewardContact{}).
+		WithRoot(&ExampleJSONStewardCustomer{}).
+		MustGenerate()
+
+	var b bytes.Buffer
+	c.Assert(WriteConstraintsCSV(&b, js), IsNil)
+	c.Assert(b.String(), Equals, `path,type,required,constraints,description,classification
+/age,integer,false,minimum=0; maximum=150,,
+/contact,object,false,,"Main contact, ""primary""",
+/contact/email,string,false,,Contact email,pii.email
+/id,string,true,minLength=3; maxLength=8,Customer id,
+/nickname,string|null,false,,,
+/status,string,false,enum=new|active,,
+/tags,array,false,,,
+/tags/*,string,false,,,
+`)
+}
@@ -0,0 +1,75 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// crossFieldRule holds the constraints a field has on the other fields of its struct.
+type crossFieldRule struct {
+	field string
+	name  string
+	// requiredWith lists the properties whose presence makes this one required.
+	requiredWith []string
+	// exclusiveWith lists the properties which may not be present along with this one.
+	exclusiveWith []string
+}
+
+func crossFieldRuleFromTags(field, name string, tag *reflect.StructTag) crossFieldRule {
+	rule := crossFieldRule{field: field, name: name}
+	if v := tag.Get("requiredWith"); v != "" {
+		rule.requiredWith = strings.Split(v, "|")
+	}
+	if v := tag.Get("mutuallyExclusiveWith"); v != "" {
+		rule.exclusiveWith = strings.Split(v, "|")
+	}
+	return rule
+}
+
+// addCrossFieldRules adds the rules of the fields of the object n to the
+// dependencies of its schema, which the serializer spells with
+// dependentRequired and dependentSchemas since draft 2019-09.
+func (r *reader) addCrossFieldRules(n *Node, rules []crossFieldRule) error {
+	p := n.Schema
+	for _, rule := range rules {
+		for _, other := range append(append([]string{}, rule.requiredWith...), rule.exclusiveWith...) {
+			if n.field(other) == nil || other == rule.name {
+				return fmt.Errorf("property:%s:unknown property %s in cross-field tags", rule.field, other)
+			}
+		}
+
+		for _, other := range rule.requiredWith {
+			d := dependency(&p.Dependencies, other)
+			d.Required = append(d.Required, rule.name)
+		}
+
+		for _, other := range rule.exclusiveWith {
+			d := dependency(&p.Dependencies, rule.name)
+			excluded := &Property{Required: []string{other}}
+			switch {
+			case d.Not == nil:
+				d.Not = excluded
+			case d.Not.AnyOf == nil:
+				d.Not = &Property{AnyOf: []*Property{d.Not, excluded}}
+			default:
+				d.Not.AnyOf = append(d.Not.AnyOf, excluded)
+			}
+		}
+	}
+	return nil
+}
+
+// dependency returns the schema applying when the named property is present,
+// creating it if needed.
+func dependency(dependencies *map[string]*Property, name string) *Property {
+	if *dependencies == nil {
+		*dependencies = map[string]*Property{}
+	}
+	d, ok := (*dependencies)[name]
+	if !ok {
+		d = &Property{}
+		(*dependencies)[name] = d
+	}
+	return d
+}
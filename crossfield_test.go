@@ -0,0 +1,52 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type crossFieldSuite struct{}
+
+var _ = Suite(&crossFieldSuite{})
+
+type ExampleJSONPayment struct {
+	Card   string `json:"card" mutuallyExclusiveWith:"iban|paypal"`
+	Expiry string `json:"expiry" requiredWith:"card"`
+	CVC    string `json:"cvc" requiredWith:"card"`
+	IBAN   string `json:"iban" mutuallyExclusiveWith:"paypal"`
+	PayPal string `json:"paypal"`
+}
+
+func (self *crossFieldSuite) TestCrossFieldDependencies(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONPayment{}).MustGenerate()
+
+	c.Assert(j.Dependencies, DeepEquals, map[string]*Property{
+		"card": {
+			Required: []string{"expiry", "cvc"},
+			Not: &Property{AnyOf: []*Property{
+				{Required: []string{"iban"}},
+				{Required: []string{"paypal"}},
+			}},
+		},
+		"iban": {Not: &Property{Required: []string{"paypal"}}},
+	})
+	c.Assert(j.DependentRequired, IsNil)
+}
+
+func (self *crossFieldSuite) TestCrossFieldModernDraft(c *C) {
+	j := NewGenerator(Options{Schema: Draft202012Schema}).WithRoot(&ExampleJSONPayment{}).MustGenerate()
+
+	c.Assert(j.Dependencies, IsNil)
+	c.Assert(j.DependentRequired, DeepEquals, map[string][]string{"card": {"expiry", "cvc"}})
+	c.Assert(j.DependentSchemas["iban"], DeepEquals, &Property{Not: &Property{Required: []string{"paypal"}}})
+	c.Assert(j.DependentSchemas["card"].Not.AnyOf, HasLen, 2)
+}
+
+type ExampleJSONUnknownCrossField struct {
+	Expiry string `json:"expiry" requiredWith:"Card"`
+	Card   string `json:"card"`
+}
+
+func (self *crossFieldSuite) TestCrossFieldUnknownProperty(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONUnknownCrossField{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Expiry:unknown property Card in cross-field tags`)
+}
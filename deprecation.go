@@ -0,0 +1,74 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// SunsetExtension is the extension keyword holding the date after which
+// a deprecated property or definition may be removed.
+const SunsetExtension = "x-sunset"
+
+const sunsetLayout = "2006-01-02"
+
+// Deprecate marks the property as deprecated. If sunset is not the zero time
+// it is emitted as the x-sunset extension.
+func (p *Property) Deprecate(sunset time.Time) {
+	p.Deprecated = true
+	if sunset.IsZero() {
+		return
+	}
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[SunsetExtension] = sunset.Format(sunsetLayout)
+}
+
+// Sunset returns the sunset date of a deprecated property, if it has one.
+func (p *Property) Sunset() (time.Time, bool) {
+	raw, ok := p.Extensions[SunsetExtension].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	sunset, err := time.Parse(sunsetLayout, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return sunset, true
+}
+
+// WithDeprecatedDefinition marks the named definition as deprecated, with an optional sunset date.
+func (g *Generator) WithDeprecatedDefinition(name string, sunset time.Time) *Generator {
+	if g.deprecations == nil {
+		g.deprecations = map[string]time.Time{}
+	}
+	g.deprecations[name] = sunset
+	return g
+}
+
+func (p *Property) addDeprecationFromTags(tag *reflect.StructTag) error {
+	var sunset time.Time
+	if raw, ok := tag.Lookup(SunsetExtension); ok {
+		var err error
+		sunset, err = time.Parse(sunsetLayout, raw)
+		if err != nil {
+			return fmt.Errorf(`invalid %q tag value %q: expected a date like 2025-06-01`, SunsetExtension, raw)
+		}
+	}
+
+	deprecated := false
+	if raw, ok := tag.Lookup("deprecated"); ok {
+		var err error
+		deprecated, err = strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf(`invalid "deprecated" tag value %q: %s`, raw, err)
+		}
+	}
+
+	if deprecated || !sunset.IsZero() {
+		p.Deprecate(sunset)
+	}
+	return nil
+}
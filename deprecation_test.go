@@ -0,0 +1,60 @@
+package jsonschema
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type deprecationSuite struct{}
+
+var _ = Suite(&deprecationSuite{})
+
+type ExampleJSONDeprecated struct {
+	Name    string `json:"name"`
+	OldName string `json:"oldName" deprecated:"true" x-sunset:"2025-06-01"`
+	Legacy  int    `json:"legacy" deprecated:"true"`
+}
+
+func (self *deprecationSuite) TestDeprecationTags(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONDeprecated{}).MustGenerate()
+
+	c.Assert(j.Properties["name"].Deprecated, Equals, false)
+	c.Assert(j.Properties["legacy"], DeepEquals, &Property{Type: "integer", Deprecated: true})
+	c.Assert(j.Properties["oldName"], DeepEquals, &Property{
+		Type:       "string",
+		Deprecated: true,
+		Extensions: map[string]interface{}{SunsetExtension: "2025-06-01"},
+	})
+
+	sunset, ok := j.Properties["oldName"].Sunset()
+	c.Assert(ok, Equals, true)
+	c.Assert(sunset, Equals, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+}
+
+type ExampleJSONInvalidSunset struct {
+	OldName string `deprecated:"true" x-sunset:"June"`
+}
+
+func (self *deprecationSuite) TestInvalidSunset(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONInvalidSunset{}).Generate()
+	c.Assert(err, ErrorMatches, `.*invalid "x-sunset" tag value "June".*`)
+}
+
+func (self *deprecationSuite) TestDeprecatedDefinition(c *C) {
+	j := NewGenerator().
+		WithDefinition("child", ExampleJSONNestedStructReferenceChild{}).
+		WithDefinitionAlias("oldChild", "child").
+		WithDeprecatedDefinition("oldChild", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)).
+		MustGenerate()
+
+	c.Assert(j.Definitions["child"].Deprecated, Equals, false)
+	c.Assert(j.Definitions["oldChild"], DeepEquals, Property{
+		Ref:        "#/definitions/child",
+		Deprecated: true,
+		Extensions: map[string]interface{}{SunsetExtension: "2025-06-01"},
+	})
+
+	_, err := NewGenerator().WithDeprecatedDefinition("missing", time.Time{}).Generate()
+	c.Assert(err, ErrorMatches, "cannot deprecate unknown definition missing")
+}
@@ -0,0 +1,156 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// draftEmitter emits JSON Schema documents, for the given meta-schema if set.
+type draftEmitter struct {
+	schema string
+}
+
+func (e draftEmitter) Emit(model *Property) ([]byte, error) {
+	return e.EmitDocument(&JSONSchema{Property: *model})
+}
+
+func (e draftEmitter) EmitDocument(schema *JSONSchema) ([]byte, error) {
+	if e.schema != "" {
+		schema.Schema = e.schema
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// openAPIEmitter emits the schema objects of OpenAPI 3.0.
+type openAPIEmitter struct{}
+
+func (e openAPIEmitter) Emit(model *Property) ([]byte, error) {
+	return e.EmitDocument(&JSONSchema{Property: *model})
+}
+
+func (e openAPIEmitter) EmitDocument(schema *JSONSchema) ([]byte, error) {
+	RewriteRefs(schema, func(ref string) string {
+		if strings.HasPrefix(ref, definitionsPrefix) {
+			return componentsSchemasPrefix + strings.TrimPrefix(ref, definitionsPrefix)
+		}
+		return ref
+	})
+
+	var document struct {
+		Schema     *Property `json:"schema"`
+		Components *struct {
+			Schemas map[string]*Property `json:"schemas"`
+		} `json:"components,omitempty"`
+	}
+	document.Schema = &schema.Property
+	toOpenAPI30(document.Schema)
+	if len(schema.Definitions) > 0 {
+		document.Components = &struct {
+			Schemas map[string]*Property `json:"schemas"`
+		}{Schemas: make(map[string]*Property, len(schema.Definitions))}
+		for name, def := range schema.Definitions {
+			p := def
+			toOpenAPI30(&p)
+			document.Components.Schemas[name] = &p
+		}
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// toOpenAPI30 rewrites p and its subschemas with the keywords of OpenAPI 3.0:
+// nullable values use the nullable keyword rather than null types, exclusive
+// bounds are booleans qualifying the bounds, consts are single value enums and
+// the values of maps are described by additionalProperties.
+func toOpenAPI30(p *Property) {
+	walkProperties(p, func(p *Property) {
+		if values, ok := p.Properties[".*"]; ok && p.AdditionalPropertiesSchema == nil {
+			delete(p.Properties, ".*")
+			if len(p.Properties) == 0 {
+				p.Properties = nil
+			}
+			p.AdditionalProperties = false
+			p.AdditionalPropertiesSchema = values
+		}
+
+		if i, ok := nullableBranch(p.AnyOf); ok && p.Type == "" && p.AnyOf[i].Ref == "" {
+			value := *p.AnyOf[i]
+			value.Title = firstNonEmpty(p.Title, value.Title)
+			value.Description = firstNonEmpty(p.Description, value.Description)
+			value.Deprecated = p.Deprecated || value.Deprecated
+			for k, v := range p.Extensions {
+				setExtension(&value, k, v)
+			}
+			*p = value
+			setExtension(p, "nullable", true)
+		}
+
+		if len(p.Types) > 0 {
+			var types []string
+			for _, t := range p.Types {
+				if t == "null" {
+					setExtension(p, "nullable", true)
+				} else {
+					types = append(types, t)
+				}
+			}
+			p.Types = nil
+			if len(types) > 1 {
+				// type arrays are not supported
+				p.Type = ""
+				for _, t := range types {
+					p.AnyOf = append(p.AnyOf, &Property{Type: t})
+				}
+			}
+		}
+
+		booleanExclusiveBounds(p)
+
+		if p.Const != nil {
+			p.Enum = []interface{}{p.Const}
+			p.Const = nil
+		}
+	})
+}
+
+// emitKubernetesCRD emits the structural schema of a custom resource
+// definition, which accepts values of any type with x-kubernetes-preserve-unknown-fields.
+func emitKubernetesCRD(model *Property) ([]byte, error) {
+	toOpenAPI30(model)
+	walkProperties(model, func(p *Property) {
+		anyValue := p.Type == "" && len(p.AnyOf) == 0 && len(p.OneOf) == 0 && p.Not == nil
+		anyObject := p.Type == "object" && p.AdditionalProperties && len(p.Properties) == 0 && p.AdditionalPropertiesSchema == nil
+		if anyValue || anyObject {
+			setExtension(p, "x-kubernetes-preserve-unknown-fields", true)
+		}
+	})
+	return json.MarshalIndent(map[string]*Property{"openAPIV3Schema": model}, "", "  ")
+}
+
+// emitLLMTool emits the parameters of a tool called by a language model,
+// without the extensions, which such models don't understand.
+func emitLLMTool(model *Property) ([]byte, error) {
+	if model.Type != "object" {
+		return nil, fmt.Errorf("the parameters of tools must be objects, not %s", describeSchemaType(model))
+	}
+	walkProperties(model, func(p *Property) {
+		p.Extensions = nil
+	})
+	return json.MarshalIndent(model, "", "  ")
+}
+
+func setExtension(p *Property, key string, value interface{}) {
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[key] = value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
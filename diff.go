@@ -0,0 +1,503 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind identifies the kind of a Change between two schemas.
+type ChangeKind string
+
+const (
+	PropertyAdded        ChangeKind = "property-added"
+	PropertyRemoved      ChangeKind = "property-removed"
+	DefinitionAdded      ChangeKind = "definition-added"
+	DefinitionRemoved    ChangeKind = "definition-removed"
+	RequiredAdded        ChangeKind = "required-added"
+	RequiredRemoved      ChangeKind = "required-removed"
+	TypeChanged          ChangeKind = "type-changed"
+	RefChanged           ChangeKind = "ref-changed"
+	ConstraintTightened  ChangeKind = "constraint-tightened"
+	ConstraintLoosened   ChangeKind = "constraint-loosened"
+	ConstraintChanged    ChangeKind = "constraint-changed"
+	AnnotationChanged    ChangeKind = "annotation-changed"
+	ElementDeprecated    ChangeKind = "deprecated"
+	DeprecatedReferenced ChangeKind = "deprecated-referenced"
+)
+
+// Severity indicates how a Change affects the producers and consumers of documents.
+type Severity int
+
+const (
+	// SeverityInfo changes are compatible with existing documents.
+	SeverityInfo Severity = iota
+	// SeverityWarning changes are compatible, but need attention,
+	// such as references to deprecated elements.
+	SeverityWarning
+	// SeverityBreaking changes may break existing producers or consumers.
+	SeverityBreaking
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityBreaking:
+		return "breaking"
+	}
+	return "info"
+}
+
+// Change is a single difference between two schemas.
+type Change struct {
+	// Path is the JSON pointer of the changed element in the schema.
+	Path     string
+	Kind     ChangeKind
+	Severity Severity
+	// Keyword is the keyword which changed, for constraint and annotation changes.
+	Keyword string
+	Old     interface{}
+	New     interface{}
+}
+
+// Description returns a short human readable description of the change.
+func (c Change) Description() string {
+	switch c.Kind {
+	case PropertyAdded:
+		return "property added"
+	case PropertyRemoved:
+		return "property removed"
+	case DefinitionAdded:
+		return "definition added"
+	case DefinitionRemoved:
+		return "definition removed"
+	case RequiredAdded:
+		return fmt.Sprintf("%v is now required", c.New)
+	case RequiredRemoved:
+		return fmt.Sprintf("%v is no longer required", c.Old)
+	case ElementDeprecated:
+		if c.New != nil {
+			return fmt.Sprintf("deprecated, sunset on %v", c.New)
+		}
+		return "deprecated"
+	case DeprecatedReferenced:
+		return fmt.Sprintf("references deprecated definition %v", c.New)
+	}
+
+	change := fmt.Sprintf("%s changed from %s to %s", c.Keyword, describeValue(c.Old), describeValue(c.New))
+	switch {
+	case c.Old == nil:
+		change = fmt.Sprintf("%s %s added", c.Keyword, describeValue(c.New))
+	case c.New == nil:
+		change = fmt.Sprintf("%s %s removed", c.Keyword, describeValue(c.Old))
+	}
+	switch c.Kind {
+	case ConstraintTightened:
+		change += " (tightened)"
+	case ConstraintLoosened:
+		change += " (loosened)"
+	}
+	return change
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Path, c.Description())
+}
+
+func describeValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "none"
+	case string:
+		return fmt.Sprintf("%q", t)
+	case *float64:
+		return fmt.Sprint(*t)
+	case *int64:
+		return fmt.Sprint(*t)
+	case []string:
+		return "[" + strings.Join(t, ", ") + "]"
+	case []interface{}:
+		values := make([]string, len(t))
+		for i, v := range t {
+			values[i] = fmt.Sprint(v)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+// Diff returns the changes needed to go from the old schema to the new one,
+// sorted by path. References to deprecated definitions in the new schema
+// are reported as warnings.
+func Diff(old, new *JSONSchema) []Change {
+	d := &differ{}
+	d.definitions("/definitions", old.Definitions, new.Definitions)
+	d.property("", &old.Property, &new.Property)
+	d.deprecatedReferences(new)
+
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	return d.changes
+}
+
+// BreakingChanges returns the changes with SeverityBreaking.
+func BreakingChanges(changes []Change) []Change {
+	var breaking []Change
+	for _, c := range changes {
+		if c.Severity == SeverityBreaking {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+type differ struct {
+	changes []Change
+	hints   []MigrationHint
+}
+
+func (d *differ) add(c Change) {
+	d.changes = append(d.changes, c)
+	if c.Severity == SeverityBreaking && (c.Kind == ConstraintTightened || c.Kind == ConstraintChanged) {
+		d.hint(MigrationHint{Kind: HintRevalidate, Path: c.Path, Keyword: c.Keyword, Old: c.Old, New: c.New})
+	}
+}
+
+func (d *differ) definitions(path string, old, new map[string]Property) {
+	for _, name := range sortedDefinitionNames(old) {
+		o := old[name]
+		n, ok := new[name]
+		if !ok {
+			d.add(Change{Path: path + "/" + escapePointer(name), Kind: DefinitionRemoved, Severity: SeverityBreaking})
+			continue
+		}
+		d.property(path+"/"+escapePointer(name), &o, &n)
+	}
+	for _, name := range sortedDefinitionNames(new) {
+		if _, ok := old[name]; !ok {
+			d.add(Change{Path: path + "/" + escapePointer(name), Kind: DefinitionAdded, Severity: SeverityInfo})
+		}
+	}
+}
+
+func (d *differ) property(path string, old, new *Property) {
+	if old.Ref != new.Ref {
+		d.add(Change{Path: path, Kind: RefChanged, Severity: SeverityBreaking, Keyword: "$ref", Old: nilIfEmpty(old.Ref), New: nilIfEmpty(new.Ref)})
+	}
+	if old.Type != new.Type {
+		d.add(Change{Path: path, Kind: TypeChanged, Severity: SeverityBreaking, Keyword: "type", Old: nilIfEmpty(old.Type), New: nilIfEmpty(new.Type)})
+		d.typeHint(path, typeList(old), typeList(new))
+	} else if strings.Join(old.Types, ",") != strings.Join(new.Types, ",") {
+		d.add(Change{Path: path, Kind: TypeChanged, Severity: SeverityBreaking, Keyword: "type", Old: typeList(old), New: typeList(new)})
+		d.typeHint(path, typeList(old), typeList(new))
+	}
+	if old.Format != new.Format {
+		d.add(Change{Path: path, Kind: ConstraintChanged, Severity: SeverityBreaking, Keyword: "format", Old: nilIfEmpty(old.Format), New: nilIfEmpty(new.Format)})
+	}
+	if old.ContentEncoding != new.ContentEncoding {
+		d.add(Change{Path: path, Kind: ConstraintChanged, Severity: SeverityBreaking, Keyword: "contentEncoding", Old: nilIfEmpty(old.ContentEncoding), New: nilIfEmpty(new.ContentEncoding)})
+	}
+	if old.Title != new.Title {
+		d.add(Change{Path: path, Kind: AnnotationChanged, Severity: SeverityInfo, Keyword: "title", Old: nilIfEmpty(old.Title), New: nilIfEmpty(new.Title)})
+	}
+	if old.Description != new.Description {
+		d.add(Change{Path: path, Kind: AnnotationChanged, Severity: SeverityInfo, Keyword: "description", Old: nilIfEmpty(old.Description), New: nilIfEmpty(new.Description)})
+	}
+	if old.MarkdownDescription != new.MarkdownDescription {
+		d.add(Change{Path: path, Kind: AnnotationChanged, Severity: SeverityInfo, Keyword: "markdownDescription", Old: nilIfEmpty(old.MarkdownDescription), New: nilIfEmpty(new.MarkdownDescription)})
+	}
+	if !jsonEqual(old.Examples, new.Examples) {
+		d.add(Change{Path: path, Kind: AnnotationChanged, Severity: SeverityInfo, Keyword: "examples", Old: old.Examples, New: new.Examples})
+	}
+	if !jsonEqual(old.Default, new.Default) {
+		d.add(Change{Path: path, Kind: AnnotationChanged, Severity: SeverityInfo, Keyword: "default", Old: old.Default, New: new.Default})
+	}
+	if !old.Deprecated && new.Deprecated {
+		c := Change{Path: path, Kind: ElementDeprecated, Severity: SeverityWarning, Keyword: "deprecated"}
+		if sunset, ok := new.Sunset(); ok {
+			c.New = sunset.Format(sunsetLayout)
+		}
+		d.add(c)
+	}
+
+	d.lowerBound(path, "minimum", old.Minimum, new.Minimum)
+	d.lowerBound(path, "exclusiveMinimum", old.ExclusiveMinimum, new.ExclusiveMinimum)
+	d.upperBound(path, "maximum", old.Maximum, new.Maximum)
+	d.upperBound(path, "exclusiveMaximum", old.ExclusiveMaximum, new.ExclusiveMaximum)
+	d.lowerBound(path, "minLength", int64ToFloat(old.MinLength), int64ToFloat(new.MinLength))
+	d.upperBound(path, "maxLength", int64ToFloat(old.MaxLength), int64ToFloat(new.MaxLength))
+	d.lowerBound(path, "minItems", int64ToFloat(old.MinItems), int64ToFloat(new.MinItems))
+	d.upperBound(path, "maxItems", int64ToFloat(old.MaxItems), int64ToFloat(new.MaxItems))
+	d.exact(path, "multipleOf", floatOrNil(old.MultipleOf), floatOrNil(new.MultipleOf))
+	d.exact(path, "pattern", nilIfEmpty(old.Pattern), nilIfEmpty(new.Pattern))
+	d.exact(path, "const", old.Const, new.Const)
+	d.enum(path, old.Enum, new.Enum)
+
+	if !old.NoAdditionalProperties && new.NoAdditionalProperties {
+		d.add(Change{Path: path, Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: "additionalProperties", Old: nil, New: false})
+	} else if old.NoAdditionalProperties && !new.NoAdditionalProperties {
+		d.add(Change{Path: path, Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: "additionalProperties", Old: false, New: nil})
+	} else if old.AdditionalProperties && !new.AdditionalProperties {
+		d.add(Change{Path: path, Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: "additionalProperties", Old: true, New: false})
+	} else if !old.AdditionalProperties && new.AdditionalProperties {
+		d.add(Change{Path: path, Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: "additionalProperties", Old: false, New: true})
+	}
+
+	d.required(path, old.Required, new)
+	d.properties(path+"/properties", old.Properties, new.Properties)
+
+	switch {
+	case old.AdditionalPropertiesSchema != nil && new.AdditionalPropertiesSchema != nil:
+		d.property(path+"/additionalProperties", old.AdditionalPropertiesSchema, new.AdditionalPropertiesSchema)
+	case old.AdditionalPropertiesSchema != nil:
+		d.add(Change{Path: path + "/additionalProperties", Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: "additionalProperties"})
+	case new.AdditionalPropertiesSchema != nil:
+		d.add(Change{Path: path + "/additionalProperties", Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: "additionalProperties"})
+	}
+
+	switch {
+	case old.Items != nil && new.Items != nil:
+		d.property(path+"/items", old.Items, new.Items)
+	case old.Items != nil:
+		d.add(Change{Path: path + "/items", Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: "items"})
+	case new.Items != nil:
+		d.add(Change{Path: path + "/items", Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: "items"})
+	}
+
+	d.propertySlice(path+"/anyOf", "anyOf", old.AnyOf, new.AnyOf)
+	d.propertySlice(path+"/oneOf", "oneOf", old.OneOf, new.OneOf)
+}
+
+func (d *differ) properties(path string, old, new map[string]*Property) {
+	removed, added := map[string]*Property{}, map[string]*Property{}
+	for _, name := range sortedPropertyNames(old) {
+		n, ok := new[name]
+		if !ok {
+			d.add(Change{Path: path + "/" + escapePointer(name), Kind: PropertyRemoved, Severity: SeverityBreaking})
+			removed[name] = old[name]
+			continue
+		}
+		d.property(path+"/"+escapePointer(name), old[name], n)
+	}
+	for _, name := range sortedPropertyNames(new) {
+		if _, ok := old[name]; !ok {
+			d.add(Change{Path: path + "/" + escapePointer(name), Kind: PropertyAdded, Severity: SeverityInfo})
+			added[name] = new[name]
+		}
+	}
+	d.renameHints(path, removed, added)
+}
+
+func (d *differ) propertySlice(path, keyword string, old, new []*Property) {
+	for i := 0; i < len(old) && i < len(new); i++ {
+		d.property(fmt.Sprintf("%s/%d", path, i), old[i], new[i])
+	}
+	if len(old) != len(new) {
+		d.add(Change{Path: path, Kind: ConstraintChanged, Severity: SeverityBreaking, Keyword: keyword, Old: len(old), New: len(new)})
+	}
+}
+
+func (d *differ) required(path string, old []string, new *Property) {
+	for _, name := range new.Required {
+		if !containsString(old, name) {
+			d.add(Change{Path: path + "/required", Kind: RequiredAdded, Severity: SeverityBreaking, Keyword: "required", New: name})
+			h := MigrationHint{Kind: HintBackfill, Path: path + "/properties/" + escapePointer(name)}
+			if p, ok := new.Properties[name]; ok {
+				h.New = p.Default
+			}
+			d.hint(h)
+		}
+	}
+	for _, name := range old {
+		if !containsString(new.Required, name) {
+			d.add(Change{Path: path + "/required", Kind: RequiredRemoved, Severity: SeverityInfo, Keyword: "required", Old: name})
+		}
+	}
+}
+
+// lowerBound compares a keyword where a higher value rejects more documents.
+func (d *differ) lowerBound(path, keyword string, old, new *float64) {
+	d.bound(path, keyword, old, new, func(o, n float64) bool { return n > o })
+}
+
+// upperBound compares a keyword where a lower value rejects more documents.
+func (d *differ) upperBound(path, keyword string, old, new *float64) {
+	d.bound(path, keyword, old, new, func(o, n float64) bool { return n < o })
+}
+
+func (d *differ) bound(path, keyword string, old, new *float64, tighter func(o, n float64) bool) {
+	var tightened bool
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		tightened = true
+	case new == nil:
+		tightened = false
+	case *old == *new:
+		return
+	default:
+		tightened = tighter(*old, *new)
+	}
+	d.constraint(path, keyword, floatOrNil(old), floatOrNil(new), tightened)
+}
+
+// exact compares a keyword where any change in value may reject documents.
+func (d *differ) exact(path, keyword string, old, new interface{}) {
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+	if new == nil {
+		d.constraint(path, keyword, old, new, false)
+		return
+	}
+	if old == nil {
+		d.constraint(path, keyword, old, new, true)
+		return
+	}
+	d.add(Change{Path: path, Kind: ConstraintChanged, Severity: SeverityBreaking, Keyword: keyword, Old: old, New: new})
+}
+
+func (d *differ) enum(path string, old, new []interface{}) {
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+	var removed, added bool
+	for _, v := range old {
+		removed = removed || !inEnum(new, v)
+	}
+	for _, v := range new {
+		added = added || !inEnum(old, v)
+	}
+	var o, n interface{}
+	if old != nil {
+		o = old
+	}
+	if new != nil {
+		n = new
+	}
+	switch {
+	case len(new) == 0:
+		d.constraint(path, "enum", o, n, false)
+	case len(old) == 0:
+		d.constraint(path, "enum", o, n, true)
+	case removed && added:
+		d.add(Change{Path: path, Kind: ConstraintChanged, Severity: SeverityBreaking, Keyword: "enum", Old: o, New: n})
+	case removed:
+		d.constraint(path, "enum", o, n, true)
+	case added:
+		d.constraint(path, "enum", o, n, false)
+	}
+}
+
+func (d *differ) constraint(path, keyword string, old, new interface{}, tightened bool) {
+	if tightened {
+		d.add(Change{Path: path, Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: keyword, Old: old, New: new})
+	} else {
+		d.add(Change{Path: path, Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: keyword, Old: old, New: new})
+	}
+}
+
+// deprecatedReferences reports every reference to a deprecated definition.
+func (d *differ) deprecatedReferences(js *JSONSchema) {
+	var walk func(path string, p *Property)
+	walk = func(path string, p *Property) {
+		if p == nil {
+			return
+		}
+		if strings.HasPrefix(p.Ref, "#/definitions/") {
+			name := unescapePointer(strings.TrimPrefix(p.Ref, "#/definitions/"))
+			if def, ok := js.Definitions[name]; ok && def.Deprecated {
+				d.add(Change{Path: path, Kind: DeprecatedReferenced, Severity: SeverityWarning, Keyword: "$ref", New: name})
+			}
+		}
+		for _, name := range sortedPropertyNames(p.Properties) {
+			walk(path+"/properties/"+escapePointer(name), p.Properties[name])
+		}
+		walk(path+"/items", p.Items)
+		for i, s := range p.AnyOf {
+			walk(fmt.Sprintf("%s/anyOf/%d", path, i), s)
+		}
+		for i, s := range p.OneOf {
+			walk(fmt.Sprintf("%s/oneOf/%d", path, i), s)
+		}
+	}
+
+	for _, name := range sortedDefinitionNames(js.Definitions) {
+		def := js.Definitions[name]
+		if def.Deprecated {
+			// references between deprecated definitions are expected.
+			continue
+		}
+		walk("/definitions/"+escapePointer(name), &def)
+	}
+	walk("", &js.Property)
+}
+
+func sortedPropertyNames(m map[string]*Property) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedDefinitionNames(m map[string]Property) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func floatOrNil(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+func int64ToFloat(i *int64) *float64 {
+	if i == nil {
+		return nil
+	}
+	return float64ptr(*i)
+}
+
+// escapePointer escapes a reference token for use in a JSON pointer.
+func escapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
+
+func unescapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~1", "/", -1), "~0", "~", -1)
+}
+
+// typeList returns the types of p as a list, for type arrays.
+func typeList(p *Property) []string {
+	if len(p.Types) > 0 {
+		return p.Types
+	}
+	return []string{p.Type}
+}
@@ -0,0 +1,132 @@
+package jsonschema
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type diffSuite struct{}
+
+var _ = Suite(&diffSuite{})
+
+type ExampleJSONDiffV1 struct {
+	Name    string  `json:"name" maxLength:"10"`
+	Age     int     `json:"age" min:"0"`
+	Fruit   string  `json:"fruit" enum:"apple|banana"`
+	Removed string  `json:"removed"`
+	Score   float64 `json:"score" max:"10"`
+}
+
+type ExampleJSONDiffV2 struct {
+	Name  string  `json:"name" maxLength:"20" required:"true"`
+	Age   string  `json:"age"`
+	Fruit string  `json:"fruit" enum:"apple"`
+	Added string  `json:"added"`
+	Score float64 `json:"score" max:"5" description:"The score."`
+}
+
+func (self *diffSuite) TestDiff(c *C) {
+	old := NewGenerator().WithRoot(&ExampleJSONDiffV1{}).MustGenerate()
+	new := NewGenerator().WithRoot(&ExampleJSONDiffV2{}).MustGenerate()
+
+	changes := Diff(old, new)
+
+	c.Assert(changes, DeepEquals, []Change{
+		{Path: "/properties/added", Kind: PropertyAdded, Severity: SeverityInfo},
+		{Path: "/properties/age", Kind: TypeChanged, Severity: SeverityBreaking, Keyword: "type", Old: "integer", New: "string"},
+		{Path: "/properties/age", Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: "minimum", Old: float64(0)},
+		{Path: "/properties/fruit", Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: "enum", Old: []interface{}{"apple", "banana"}, New: []interface{}{"apple"}},
+		{Path: "/properties/name", Kind: ConstraintLoosened, Severity: SeverityInfo, Keyword: "maxLength", Old: float64(10), New: float64(20)},
+		{Path: "/properties/removed", Kind: PropertyRemoved, Severity: SeverityBreaking},
+		{Path: "/properties/score", Kind: AnnotationChanged, Severity: SeverityInfo, Keyword: "description", New: "The score."},
+		{Path: "/properties/score", Kind: ConstraintTightened, Severity: SeverityBreaking, Keyword: "maximum", Old: float64(10), New: float64(5)},
+		{Path: "/required", Kind: RequiredAdded, Severity: SeverityBreaking, Keyword: "required", New: "name"},
+	})
+
+	c.Assert(BreakingChanges(changes), HasLen, 5)
+	c.Assert(Diff(old, old), HasLen, 0)
+}
+
+func (self *diffSuite) TestDiffDeprecation(c *C) {
+	old := NewGenerator().WithRoot(&ExampleJSONNestedStructReferenceParent{}).
+		WithDefinition("child", ExampleJSONNestedStructReferenceChild{}).
+		MustGenerate()
+	new := NewGenerator().WithRoot(&ExampleJSONNestedStructReferenceParent{}).
+		WithDefinition("child", ExampleJSONNestedStructReferenceChild{}).
+		WithDeprecatedDefinition("child", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)).
+		MustGenerate()
+
+	c.Assert(Diff(old, new), DeepEquals, []Change{
+		{Path: "/definitions/child", Kind: ElementDeprecated, Severity: SeverityWarning, Keyword: "deprecated", New: "2025-06-01"},
+		{Path: "/properties/Child", Kind: DeprecatedReferenced, Severity: SeverityWarning, Keyword: "$ref", New: "child"},
+	})
+}
+
+func (self *diffSuite) TestChangelog(c *C) {
+	old := NewGenerator().WithRoot(&ExampleJSONDiffV1{}).MustGenerate()
+	new := NewGenerator().WithRoot(&ExampleJSONDiffV2{}).MustGenerate()
+
+	expected := "### Breaking changes\n\n" +
+		"- `/properties/age`: type changed from \"integer\" to \"string\"\n" +
+		"- `/properties/fruit`: enum changed from [apple, banana] to [apple] (tightened)\n" +
+		"- `/properties/removed`: property removed\n" +
+		"- `/properties/score`: maximum changed from 10 to 5 (tightened)\n" +
+		"- `/required`: name is now required\n" +
+		"\n### Other changes\n\n" +
+		"- `/properties/added`: property added\n" +
+		"- `/properties/age`: minimum 0 removed (loosened)\n" +
+		"- `/properties/name`: maxLength changed from 10 to 20 (loosened)\n" +
+		"- `/properties/score`: description \"The score.\" added\n"
+
+	c.Assert(Changelog(old, new), Equals, expected)
+
+	fromJSON, err := ChangelogJSON([]byte(old.String()), []byte(new.String()))
+	c.Assert(err, IsNil)
+	c.Assert(fromJSON, Equals, expected)
+
+	c.Assert(Changelog(old, old), Equals, "No changes.\n")
+}
+
+type ExampleJSONCustomerV1 struct {
+	PostalCode string  `json:"postal_code" maxLength:"10"`
+	Balance    float64 `json:"balance"`
+	Fax        string  `json:"fax"`
+}
+
+type ExampleJSONCustomerV2 struct {
+	PostalCode string `json:"postalCode" maxLength:"10"`
+	Balance    int    `json:"balance"`
+	Country    string `json:"country" required:"true"`
+}
+
+func (self *diffSuite) TestMigrationHints(c *C) {
+	old := NewGenerator().WithRoot(&ExampleJSONCustomerV1{}).MustGenerate()
+	new := NewGenerator().WithRoot(&ExampleJSONCustomerV2{}).MustGenerate()
+	new.Properties["country"].Default = "US"
+
+	hints := MigrationHints(old, new)
+	c.Assert(hints, DeepEquals, []MigrationHint{
+		{Kind: HintTypeNarrowed, Path: "/properties/balance", Old: "number", New: "integer"},
+		{Kind: HintBackfill, Path: "/properties/country", New: "US"},
+		{Kind: HintDropped, Path: "/properties/fax"},
+		{Kind: HintRenamed, Path: "/properties/postal_code", NewPath: "/properties/postalCode", Similarity: 1, Old: "postal_code", New: "postalCode"},
+	})
+	c.Assert(hints[3].String(), Equals, "/properties/postal_code: renamed? old postal_code new postalCode similarity 1.00")
+	c.Assert(hints[0].String(), Equals, `/properties/balance: type narrowed "number"→"integer"`)
+
+	v1 := NewGenerator().WithRoot(&ExampleJSONDiffV1{}).MustGenerate()
+	v2 := NewGenerator().WithRoot(&ExampleJSONDiffV2{}).MustGenerate()
+	hints = MigrationHints(v1, v2)
+	c.Assert(hints, HasLen, 5)
+	c.Assert(hints[3].Similarity > 0.64 && hints[3].Similarity < 0.65, Equals, true)
+	hints[3].Similarity = 0
+	c.Assert(hints, DeepEquals, []MigrationHint{
+		{Kind: HintTypeConverted, Path: "/properties/age", Old: "integer", New: "string"},
+		{Kind: HintRevalidate, Path: "/properties/fruit", Keyword: "enum", Old: []interface{}{"apple", "banana"}, New: []interface{}{"apple"}},
+		{Kind: HintBackfill, Path: "/properties/name"},
+		{Kind: HintRenamed, Path: "/properties/removed", NewPath: "/properties/added", Old: "removed", New: "added"},
+		{Kind: HintRevalidate, Path: "/properties/score", Keyword: "maximum", Old: float64(10), New: float64(5)},
+	})
+	c.Assert(MigrationHints(v1, v1), HasLen, 0)
+}
@@ -0,0 +1,91 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Draft is a version of the JSON Schema specification, which Options.Draft
+// sets the meta-schema and the keywords of the schemas generated for.
+type Draft string
+
+const (
+	Draft04     Draft = "draft-04"
+	Draft07     Draft = "draft-07"
+	Draft201909 Draft = "2019-09"
+	Draft202012 Draft = "2020-12"
+)
+
+// Meta-schemas of the drafts using definitions.
+const (
+	Draft04Schema = "http://json-schema.org/draft-04/schema#"
+	Draft07Schema = "http://json-schema.org/draft-07/schema#"
+)
+
+// Meta-schemas of the drafts using $defs rather than definitions.
+const (
+	Draft201909Schema = "https://json-schema.org/draft/2019-09/schema"
+	Draft202012Schema = "https://json-schema.org/draft/2020-12/schema"
+)
+
+var draftSchemas = map[Draft]string{
+	Draft04:     Draft04Schema,
+	Draft07:     Draft07Schema,
+	Draft201909: Draft201909Schema,
+	Draft202012: Draft202012Schema,
+}
+
+const (
+	definitionsPrefix = "#/definitions/"
+	defsPrefix        = "#/$defs/"
+)
+
+// usesDefs reports whether the meta-schema of d names definitions $defs,
+// as the drafts published under json-schema.org/draft/ since 2019-09 do.
+func (d *JSONSchema) usesDefs() bool {
+	return isModernDraft(d.Schema)
+}
+
+// isModernDraft reports whether the meta-schema is one of the drafts
+// published under json-schema.org/draft/, since 2019-09.
+func isModernDraft(schema string) bool {
+	return strings.Contains(schema, "json-schema.org/draft/")
+}
+
+// replaceRefPrefix rewrites the refs starting with old to start with new instead.
+func (d *JSONSchema) replaceRefPrefix(old, new string) {
+	RewriteRefs(d, func(ref string) string {
+		if strings.HasPrefix(ref, old) {
+			return new + strings.TrimPrefix(ref, old)
+		}
+		return ref
+	})
+}
+
+// checkDraft returns an error if the draft set by the options is unknown.
+func (o Options) checkDraft() error {
+	if _, ok := draftSchemas[o.Draft]; o.Draft != "" && !ok {
+		return fmt.Errorf("unknown draft %q", o.Draft)
+	}
+	return nil
+}
+
+// booleanExclusiveBounds rewrites the exclusive bounds of p as the booleans
+// qualifying its bounds, as draft-04 and OpenAPI 3.0 do, keeping the
+// narrowest of the bounds when p has both.
+func booleanExclusiveBounds(p *Property) {
+	if p.ExclusiveMinimum != nil {
+		if p.Minimum == nil || *p.ExclusiveMinimum >= *p.Minimum {
+			p.Minimum = p.ExclusiveMinimum
+			setExtension(p, "exclusiveMinimum", true)
+		}
+		p.ExclusiveMinimum = nil
+	}
+	if p.ExclusiveMaximum != nil {
+		if p.Maximum == nil || *p.ExclusiveMaximum <= *p.Maximum {
+			p.Maximum = p.ExclusiveMaximum
+			setExtension(p, "exclusiveMaximum", true)
+		}
+		p.ExclusiveMaximum = nil
+	}
+}
@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type draftsSuite struct{}
+
+var _ = Suite(&draftsSuite{})
+
+func (self *draftsSuite) TestDefsOutput(c *C) {
+	j := NewGenerator(Options{Schema: Draft202012Schema}).
+		WithRoot(&ExampleJSONMapOfDefinitions{}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		MustGenerate()
+
+	b, err := json.Marshal(j)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"$schema":"https://json-schema.org/draft/2020-12/schema",`+
+		`"$defs":{"item":{"type":"object","properties":{"name":{"type":"string"}}}},`+
+		`"type":"object","properties":{`+
+		`"inline":{"type":"object","additionalProperties":{"type":"object","properties":{"MapOfInterface":{"type":"object","additionalProperties":true},"Maps":{"type":"object","properties":{".*":{"type":"string"}}}}}},`+
+		`"items":{"type":"object","additionalProperties":{"$ref":"#/$defs/item"}},`+
+		`"pointers":{"type":"object","additionalProperties":{"$ref":"#/$defs/item"}}}}`)
+
+	// the schema itself still uses definitions
+	c.Assert(j.Properties["items"].AdditionalPropertiesSchema.Ref, Equals, "#/definitions/item")
+}
+
+func (self *draftsSuite) TestDefsInput(c *C) {
+	var j JSONSchema
+	err := json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2019-09/schema",
+		"$defs": {"item": {"type": "string"}},
+		"definitions": {"legacy": {"$ref": "#/$defs/item"}},
+		"type": "array",
+		"items": {"$ref": "#/$defs/item"}
+	}`), &j)
+	c.Assert(err, IsNil)
+
+	c.Assert(j.Definitions, DeepEquals, map[string]Property{
+		"item":   {Type: "string"},
+		"legacy": {Ref: "#/definitions/item"},
+	})
+	c.Assert(j.Items.Ref, Equals, "#/definitions/item")
+	c.Assert(j.resolve(j.Items).Type, Equals, "string")
+
+	b, err := json.Marshal(j)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"$schema":"https://json-schema.org/draft/2019-09/schema",`+
+		`"$defs":{"item":{"type":"string"},"legacy":{"$ref":"#/$defs/item"}},`+
+		`"type":"array","items":{"$ref":"#/$defs/item"}}`)
+}
+
+func (self *draftsSuite) TestDefinitionsInputForOlderDrafts(c *C) {
+	var j JSONSchema
+	err := json.Unmarshal([]byte(`{"definitions": {"item": {"type": "string"}}, "items": {"$ref": "#/definitions/item"}}`), &j)
+	c.Assert(err, IsNil)
+
+	b, err := json.Marshal(j)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"definitions":{"item":{"type":"string"}},"items":{"$ref":"#/definitions/item"}}`)
+}
+
+func (self *draftsSuite) TestOmitSchemaKeyword(c *C) {
+	j := NewGenerator(Options{Schema: Draft202012Schema, OmitSchemaKeyword: true}).
+		WithRoot(&ExampleJSONMapOfDefinitions{}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		MustGenerate()
+	c.Assert(j.Schema, Equals, Draft202012Schema)
+
+	b, err := json.Marshal(j)
+	c.Assert(err, IsNil)
+	var doc map[string]interface{}
+	c.Assert(json.Unmarshal(b, &doc), IsNil)
+	c.Assert(doc["$schema"], IsNil)
+	c.Assert(doc["$defs"], NotNil)
+	c.Assert(doc["definitions"], IsNil)
+
+	b, err = j.Emit(DialectDraft07)
+	c.Assert(err, IsNil)
+	doc = nil
+	c.Assert(json.Unmarshal(b, &doc), IsNil)
+	c.Assert(doc["$schema"], IsNil)
+	c.Assert(doc["definitions"], NotNil)
+
+	set, err := NewGenerator(Options{OmitSchemaKeyword: true}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		GenerateSet(map[string]interface{}{"map": &ExampleJSONMapOfDefinitions{}})
+	c.Assert(err, IsNil)
+	root, ok := set.Root("map")
+	c.Assert(ok, Equals, true)
+	c.Assert(root.String(), Not(Matches), `(?s).*"\$schema".*`)
+}
+
+type ExampleJSONTemperature struct {
+	Celsius float64 `json:"celsius" exclusiveMin:"-273.15" max:"100"`
+	Level   int     `json:"level" exclusiveMin:"0" exclusiveMax:"10"`
+	Mode    string  `json:"mode" requiredWith:"level"`
+}
+
+func (self *draftsSuite) TestDraftOption(c *C) {
+	j := NewGenerator(Options{Draft: Draft04}).WithRoot(&ExampleJSONTemperature{}).MustGenerate()
+	c.Assert(j.Schema, Equals, Draft04Schema)
+	c.Assert(j.String(), Matches, `(?s).*"celsius": \{\s*"exclusiveMinimum": true,\s*"maximum": 100,\s*"minimum": -273.15,.*`)
+	c.Assert(j.Properties["level"].Extensions, DeepEquals, map[string]interface{}{"exclusiveMinimum": true, "exclusiveMaximum": true})
+	c.Assert(j.Dependencies, HasLen, 1)
+
+	errs, err := j.Validate([]byte(`{"celsius": -273.15, "level": 10, "mode": "eco"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs[0].Keyword, Equals, "exclusiveMinimum")
+	c.Assert(errs[1].Keyword, Equals, "exclusiveMaximum")
+
+	// draft-04 documents are parsed back
+	var parsed JSONSchema
+	c.Assert(json.Unmarshal([]byte(j.String()), &parsed), IsNil)
+	c.Assert(parsed.Properties["celsius"], DeepEquals, j.Properties["celsius"])
+
+	j = NewGenerator(Options{Draft: Draft202012}).WithRoot(&ExampleJSONTemperature{}).MustGenerate()
+	c.Assert(j.Schema, Equals, Draft202012Schema)
+	c.Assert(*j.Properties["celsius"].ExclusiveMinimum, Equals, -273.15)
+	c.Assert(j.DependentRequired, HasLen, 1)
+
+	_, err = NewGenerator(Options{Draft: "draft-99"}).WithRoot(&ExampleJSONTemperature{}).Generate()
+	c.Assert(err, ErrorMatches, `unknown draft "draft-99"`)
+}
@@ -0,0 +1,27 @@
+package jsonschema
+
+// EditorSchema returns a copy of the schema tailored for editors validating
+// and completing config files, such as VS Code or the YAML language server:
+// it has the given $id and title, if not empty, uses draft-07, which editors
+// support best, and repeats descriptions as markdownDescription, which
+// editors render in hovers.
+func (d *JSONSchema) EditorSchema(id, title string) *JSONSchema {
+	e := d.Clone()
+	e.Schema = Draft07Schema
+	e.ID = id
+	if title != "" {
+		e.Title = title
+	}
+
+	addMarkdown := func(p *Property) {
+		if _, ok := p.Extensions["markdownDescription"]; !ok && p.MarkdownDescription == "" {
+			p.MarkdownDescription = p.Description
+		}
+	}
+	walkProperties(&e.Property, addMarkdown)
+	for name, def := range e.Definitions {
+		walkProperties(&def, addMarkdown)
+		e.Definitions[name] = def
+	}
+	return e
+}
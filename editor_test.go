@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type editorSuite struct{}
+
+var _ = Suite(&editorSuite{})
+
+type ExampleEditorConfig struct {
+	meta    string               `description:"The configuration of the service."`
+	Port    int                  `mapstructure:"port" description:"The port to listen on."`
+	Logging ExampleConfigLogging `mapstructure:"logging" description:"Logging settings." extensions:"{\"markdownDescription\": \"See **logging**.\"}"`
+}
+
+func (self *editorSuite) TestEditorSchema(c *C) {
+	js := NewGenerator(ConfigOptions()).WithRoot(&ExampleEditorConfig{}).MustGenerate()
+	e := js.EditorSchema("https://example.com/config.schema.json", "Service configuration")
+
+	c.Assert(e.Schema, Equals, "http://json-schema.org/draft-07/schema#")
+	c.Assert(e.ID, Equals, "https://example.com/config.schema.json")
+	c.Assert(e.Title, Equals, "Service configuration")
+	c.Assert(e.MarkdownDescription, Equals, "The configuration of the service.")
+	c.Assert(e.Properties["port"].MarkdownDescription, Equals, "The port to listen on.")
+	c.Assert(e.Properties["logging"].Extensions["markdownDescription"], Equals, "See **logging**.")
+
+	// the schema is left as is
+	c.Assert(js.ID, Equals, "")
+	c.Assert(js.Properties["port"].MarkdownDescription, Equals, "")
+
+	js = &JSONSchema{Definitions: map[string]Property{"logging": {Type: "object", Description: "Logging settings."}}}
+	e = js.EditorSchema("https://example.com/config.schema.json", "")
+	c.Assert(e.Definitions["logging"].MarkdownDescription, Equals, "Logging settings.")
+}
+
+type ExampleJSONMarkdown struct {
+	Port    int    `json:"port" description:"The port." markdownDescription:"The **port**."`
+	Host    string `json:"host" description:"The host."`
+	Comment string `json:"comment"`
+}
+
+func (self *editorSuite) TestMarkdownDescription(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONMarkdown{}).MustGenerate()
+	c.Assert(j.Properties["port"].MarkdownDescription, Equals, "The **port**.")
+	c.Assert(j.Properties["port"].Description, Equals, "The port.")
+	c.Assert(j.Properties["host"].MarkdownDescription, Equals, "")
+
+	j = NewGenerator(Options{MarkdownDescriptions: true}).WithRoot(&ExampleJSONMarkdown{}).MustGenerate()
+	c.Assert(j.Properties["port"].MarkdownDescription, Equals, "The **port**.")
+	c.Assert(j.Properties["host"].MarkdownDescription, Equals, "The host.")
+	c.Assert(j.Properties["comment"].MarkdownDescription, Equals, "")
+	c.Assert(j.String(), Matches, `(?s).*"markdownDescription": "The host.".*`)
+}
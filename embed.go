@@ -0,0 +1,130 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var rTypeJSONSchema = reflect.TypeOf(JSONSchema{})
+
+// MarshalEmbedded returns the JSON encoding of v, a struct embedding
+// JSONSchema, with the keywords of the schema followed by the other fields of
+// v. Structs embedding JSONSchema inherit its MarshalJSON, which only knows
+// of the schema, so they marshal with MarshalEmbedded rather:
+//
+//	type Document struct {
+//		jsonschema.JSONSchema
+//		Owner string `json:"x-owner"`
+//	}
+//
+//	func (d Document) MarshalJSON() ([]byte, error) {
+//		return jsonschema.MarshalEmbedded(d)
+//	}
+func MarshalEmbedded(v interface{}) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	schema, fields, err := embeddingFields(rv)
+	if err != nil {
+		return nil, err
+	}
+	root, err := schema.Interface().(JSONSchema).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	others, err := json.Marshal(fields.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return mergeObjects(root, others), nil
+}
+
+// UnmarshalEmbedded reads b into v, a pointer to a struct embedding
+// JSONSchema, as MarshalEmbedded wrote it. The keywords of the other fields
+// of v aren't held in the Extensions of the schema.
+func UnmarshalEmbedded(b []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("%T is not a pointer to a struct", v)
+	}
+	rv = rv.Elem()
+	schema, fields, err := embeddingFields(rv)
+	if err != nil {
+		return err
+	}
+	var js JSONSchema
+	if err := js.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	others := reflect.New(fields.Type())
+	if err := json.Unmarshal(b, others.Interface()); err != nil {
+		return err
+	}
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Type().Field(i)
+		rv.FieldByName(field.Name).Set(others.Elem().Field(i))
+		delete(js.Extensions, jsonFieldName(field))
+	}
+	if len(js.Extensions) == 0 {
+		js.Extensions = nil
+	}
+	schema.Set(reflect.ValueOf(js))
+	return nil
+}
+
+// embeddingFields returns the JSONSchema embedded by the struct rv, and a
+// struct holding the values of its other fields, whose type has no methods
+// so that it marshals without calling MarshalEmbedded again.
+func embeddingFields(rv reflect.Value) (reflect.Value, reflect.Value, error) {
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("%s is not a struct", rv.Type())
+	}
+	var schema reflect.Value
+	var fields []reflect.StructField
+	var values []reflect.Value
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		switch {
+		case field.Anonymous && field.Type == rTypeJSONSchema:
+			schema = rv.Field(i)
+		case field.PkgPath != "" || jsonFieldName(field) == "-":
+			// not marshaled
+		case field.Anonymous:
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("%s embeds %s besides JSONSchema", rv.Type(), field.Type)
+		default:
+			fields = append(fields, reflect.StructField{Name: field.Name, Type: field.Type, Tag: field.Tag})
+			values = append(values, rv.Field(i))
+		}
+	}
+	if !schema.IsValid() {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("%s doesn't embed JSONSchema", rv.Type())
+	}
+	others := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, value := range values {
+		others.Field(i).Set(value)
+	}
+	return schema, others, nil
+}
+
+// jsonFieldName returns the name of the JSON property of the field, or "-"
+// if it isn't marshaled.
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// mergeObjects returns the JSON object holding the properties of the objects
+// a and b, those of a first.
+func mergeObjects(a, b []byte) []byte {
+	switch {
+	case len(a) == 2:
+		return b
+	case len(b) == 2:
+		return a
+	}
+	merged := append(a[:len(a)-1:len(a)-1], ',')
+	return append(merged, b[1:]...)
+}
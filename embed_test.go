@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type embedSuite struct{}
+
+var _ = Suite(&embedSuite{})
+
+type ExampleJSONEmbeddingDocument struct {
+	JSONSchema
+	Owner   string `json:"x-owner"`
+	Version int    `json:"x-version,omitempty"`
+	Ignored string `json:"-"`
+	hidden  string
+}
+
+func (d ExampleJSONEmbeddingDocument) MarshalJSON() ([]byte, error) {
+	return MarshalEmbedded(d)
+}
+
+func (d *ExampleJSONEmbeddingDocument) UnmarshalJSON(b []byte) error {
+	return UnmarshalEmbedded(b, d)
+}
+
+func (self *embedSuite) TestMarshalEmbedded(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONMapItem{}).MustGenerate()
+	j.ID = "https://example.com/item.json"
+	j.Extensions = map[string]interface{}{"x-tag": "item"}
+	doc := ExampleJSONEmbeddingDocument{JSONSchema: *j, Owner: "team", Version: 2, Ignored: "a", hidden: "b"}
+
+	expected := `{"$schema":"http://json-schema.org/schema#","$id":"https://example.com/item.json",` +
+		`"properties":{"name":{"type":"string"}},"type":"object","x-tag":"item","x-owner":"team","x-version":2}`
+	b, err := json.Marshal(doc)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, expected)
+	b, err = json.Marshal(&doc)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, expected)
+	b, err = json.Marshal(map[string]interface{}{"doc": doc})
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"doc":`+expected+`}`)
+
+	var read ExampleJSONEmbeddingDocument
+	c.Assert(json.Unmarshal([]byte(expected), &read), IsNil)
+	c.Assert(read.Owner, Equals, "team")
+	c.Assert(read.Version, Equals, 2)
+	c.Assert(read.ID, Equals, j.ID)
+	c.Assert(read.Properties["name"].Type, Equals, "string")
+	c.Assert(read.Extensions, DeepEquals, map[string]interface{}{"x-tag": "item"})
+
+	_, err = MarshalEmbedded(ExampleJSONMapItem{})
+	c.Assert(err, ErrorMatches, ".*ExampleJSONMapItem doesn't embed JSONSchema")
+	c.Assert(UnmarshalEmbedded(b, read), ErrorMatches, ".* is not a pointer to a struct")
+}
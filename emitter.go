@@ -0,0 +1,190 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Emitter serializes the model of a schema in an output dialect, e.g. a draft
+// of JSON Schema, OpenAPI or TypeScript. Emitters registered with
+// RegisterEmitter are used by JSONSchema.Emit. The model passed to Emit has
+// its references to definitions inlined; emitters which handle definitions
+// themselves implement DocumentEmitter. Emitters of formats other than JSON
+// Schema may work on the representation returned by JSONSchema.Model.
+type Emitter interface {
+	Emit(model *Property) ([]byte, error)
+}
+
+// DocumentEmitter is an Emitter which emits the definitions of a schema along
+// with its root, and is given the whole schema by JSONSchema.Emit.
+type DocumentEmitter interface {
+	Emitter
+	EmitDocument(schema *JSONSchema) ([]byte, error)
+}
+
+// EmitterFunc adapts a function to the Emitter interface.
+type EmitterFunc func(model *Property) ([]byte, error)
+
+// Emit calls f(model).
+func (f EmitterFunc) Emit(model *Property) ([]byte, error) {
+	return f(model)
+}
+
+// Dialects registered by default.
+const (
+	// DialectJSONSchema emits the schema as generated.
+	DialectJSONSchema = "json-schema"
+	DialectDraft07    = "draft-07"
+	// DialectDraft202012 emits definitions as $defs.
+	DialectDraft202012 = "draft-2020-12"
+	// DialectOpenAPI30 emits {"schema": root, "components": {"schemas": definitions}}
+	// with the nullable keyword of OpenAPI 3.0.
+	DialectOpenAPI30 = "openapi-3.0"
+	// DialectKubernetesCRD emits {"openAPIV3Schema": root} as a structural schema.
+	DialectKubernetesCRD = "kubernetes-crd"
+	// DialectLLMTool emits the parameters of tools called by language models.
+	DialectLLMTool = "llm-tool"
+	DialectAvro    = "avro"
+	// DialectTypeScript emits a TypeScript declaration per definition and for the root.
+	DialectTypeScript = "typescript"
+	// DialectJSONAPI emits a JSON:API document whose primary data is the
+	// root, for schemas generated with Options.JSONAPI.
+	DialectJSONAPI = "jsonapi"
+	// DialectHAL emits the HAL representations of the objects described by
+	// the root and the definitions, with _links and _embedded resources.
+	DialectHAL = "hal"
+)
+
+var emitters = struct {
+	sync.RWMutex
+	m map[string]Emitter
+}{m: map[string]Emitter{
+	DialectJSONSchema:    draftEmitter{},
+	DialectDraft07:       draftEmitter{schema: Draft07Schema},
+	DialectDraft202012:   draftEmitter{schema: Draft202012Schema},
+	DialectOpenAPI30:     openAPIEmitter{},
+	DialectKubernetesCRD: EmitterFunc(emitKubernetesCRD),
+	DialectLLMTool:       EmitterFunc(emitLLMTool),
+	DialectAvro:          avroEmitter{},
+	DialectTypeScript:    typeScriptEmitter{},
+	DialectJSONAPI:       jsonAPIEmitter{},
+	DialectHAL:           halEmitter{},
+}}
+
+// RegisterEmitter registers the emitter of a dialect, replacing the one
+// registered under the same name, if any.
+func RegisterEmitter(dialect string, e Emitter) {
+	emitters.Lock()
+	defer emitters.Unlock()
+	emitters.m[dialect] = e
+}
+
+// Dialects returns the names of the registered dialects, sorted.
+func Dialects() []string {
+	emitters.RLock()
+	defer emitters.RUnlock()
+	names := make([]string, 0, len(emitters.m))
+	for name := range emitters.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Emit serializes the schema in the dialect registered under the given name.
+func (d *JSONSchema) Emit(dialect string) ([]byte, error) {
+	emitters.RLock()
+	e, ok := emitters.m[dialect]
+	emitters.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown dialect %s", dialect)
+	}
+	if de, ok := e.(DocumentEmitter); ok {
+		return de.EmitDocument(d.Clone())
+	}
+	model, err := d.Inline()
+	if err != nil {
+		return nil, fmt.Errorf("dialect %s: %s", dialect, err)
+	}
+	return e.Emit(model)
+}
+
+// Inline returns a copy of the root of the schema with the references to
+// definitions replaced by the definitions, for consumers which don't support
+// $ref. Recursive definitions can't be inlined.
+func (d *JSONSchema) Inline() (*Property, error) {
+	return d.inline(&d.Property, map[string]bool{})
+}
+
+func (d *JSONSchema) inline(p *Property, visiting map[string]bool) (*Property, error) {
+	c := p.Clone()
+	if p.Ref != "" {
+		if !strings.HasPrefix(p.Ref, definitionsPrefix) {
+			return nil, fmt.Errorf("reference %s can't be inlined", p.Ref)
+		}
+		name := unescapePointer(strings.TrimPrefix(p.Ref, definitionsPrefix))
+		def, ok := d.Definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference %s", p.Ref)
+		}
+		if visiting[name] {
+			return nil, fmt.Errorf("recursive definition %s can't be inlined", name)
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+
+		inlined, err := d.inline(&def, visiting)
+		if err != nil {
+			return nil, err
+		}
+		// annotations next to the reference take precedence
+		if c.Title != "" {
+			inlined.Title = c.Title
+		}
+		if c.Description != "" {
+			inlined.Description = c.Description
+		}
+		inlined.Deprecated = inlined.Deprecated || c.Deprecated
+		for k, v := range c.Extensions {
+			if inlined.Extensions == nil {
+				inlined.Extensions = map[string]interface{}{}
+			}
+			inlined.Extensions[k] = v
+		}
+		return inlined, nil
+	}
+
+	err := replaceSubschemas(c, func(s *Property) (*Property, error) {
+		return d.inline(s, visiting)
+	})
+	return c, err
+}
+
+// replaceSubschemas replaces the direct subschemas of p by the result of fn.
+func replaceSubschemas(p *Property, fn func(s *Property) (*Property, error)) error {
+	var err error
+	replace := func(s *Property) *Property {
+		if s == nil || err != nil {
+			return s
+		}
+		var r *Property
+		r, err = fn(s)
+		return r
+	}
+	p.Items = replace(p.Items)
+	p.AdditionalPropertiesSchema = replace(p.AdditionalPropertiesSchema)
+	p.Not = replace(p.Not)
+	for _, m := range []map[string]*Property{p.Properties, p.Dependencies, p.DependentSchemas} {
+		for _, name := range sortedPropertyNames(m) {
+			m[name] = replace(m[name])
+		}
+	}
+	for _, s := range [][]*Property{p.AnyOf, p.OneOf} {
+		for i := range s {
+			s[i] = replace(s[i])
+		}
+	}
+	return err
+}
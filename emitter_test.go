@@ -0,0 +1,367 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type emitterSuite struct{}
+
+var _ = Suite(&emitterSuite{})
+
+type emitterLine struct {
+	SKU      string   `json:"sku" required:"true" description:"The stock keeping unit."`
+	Quantity int32    `json:"quantity" min:"1"`
+	Note     *string  `json:"note"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+type emitterOrder struct {
+	meta   string            `title:"Order"`
+	Status string            `json:"status" required:"true" enum:"new|shipped"`
+	Lines  []emitterLine     `json:"lines"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type emitterNode struct {
+	Children []emitterNode `json:"children"`
+}
+
+type emitterMetadata struct {
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func (self *emitterSuite) emitterSchema() *JSONSchema {
+	return NewGenerator().WithRoot(emitterOrder{}).WithDefinition("line", emitterLine{}).MustGenerate()
+}
+
+func (self *emitterSuite) TestDialects(c *C) {
+	c.Assert(Dialects(), DeepEquals, []string{
+		DialectAvro,
+		DialectDraft07,
+		DialectDraft202012,
+		DialectHAL,
+		DialectJSONSchema,
+		DialectJSONAPI,
+		DialectKubernetesCRD,
+		DialectLLMTool,
+		DialectOpenAPI30,
+		DialectTypeScript,
+	})
+
+	_, err := self.emitterSchema().Emit("protobuf")
+	c.Assert(err, ErrorMatches, "unknown dialect protobuf")
+}
+
+func (self *emitterSuite) TestInline(c *C) {
+	schema := self.emitterSchema()
+	model, err := schema.Inline()
+	c.Assert(err, IsNil)
+	c.Assert(model.Properties["lines"].Items.Ref, Equals, "")
+	c.Assert(model.Properties["lines"].Items.Required, DeepEquals, []string{"sku"})
+	// the schema is left as is
+	c.Assert(schema.Properties["lines"].Items.Ref, Equals, "#/definitions/line")
+
+	recursive := NewGenerator().WithRoot(emitterNode{}).WithDefinition("node", emitterNode{}).MustGenerate()
+	_, err = recursive.Inline()
+	c.Assert(err, ErrorMatches, "recursive definition node can't be inlined")
+	_, err = recursive.Emit(DialectKubernetesCRD)
+	c.Assert(err, ErrorMatches, "dialect kubernetes-crd: recursive definition node can't be inlined")
+}
+
+func (self *emitterSuite) TestRegisterEmitter(c *C) {
+	RegisterEmitter("titles", EmitterFunc(func(model *Property) ([]byte, error) {
+		return []byte(model.Title + " " + model.Properties["lines"].Items.Properties["sku"].Description), nil
+	}))
+	defer func() {
+		emitters.Lock()
+		delete(emitters.m, "titles")
+		emitters.Unlock()
+	}()
+
+	b, err := self.emitterSchema().Emit("titles")
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "Order The stock keeping unit.")
+}
+
+func (self *emitterSuite) TestDraft(c *C) {
+	b, err := self.emitterSchema().Emit(DialectDraft202012)
+	c.Assert(err, IsNil)
+
+	var schema JSONSchema
+	c.Assert(json.Unmarshal(b, &schema), IsNil)
+	c.Assert(schema.Schema, Equals, Draft202012Schema)
+	c.Assert(schema.Properties["lines"].Items.Ref, Equals, "#/definitions/line")
+	c.Assert(schema.Definitions["line"].Required, DeepEquals, []string{"sku"})
+}
+
+func (self *emitterSuite) TestOpenAPI(c *C) {
+	b, err := self.emitterSchema().Emit(DialectOpenAPI30)
+	c.Assert(err, IsNil)
+
+	expected := `{
+  "schema": {
+    "type": "object",
+    "properties": {
+      "labels": {
+        "type": "object",
+        "additionalProperties": {
+          "type": "string"
+        }
+      },
+      "lines": {
+        "type": "array",
+        "items": {
+          "$ref": "#/components/schemas/line"
+        }
+      },
+      "status": {
+        "type": "string",
+        "enum": [
+          "new",
+          "shipped"
+        ]
+      }
+    },
+    "required": [
+      "status"
+    ],
+    "title": "Order"
+  },
+  "components": {
+    "schemas": {
+      "line": {
+        "type": "object",
+        "properties": {
+          "note": {
+            "nullable": true,
+            "type": "string"
+          },
+          "quantity": {
+            "type": "integer",
+            "minimum": 1
+          },
+          "sku": {
+            "type": "string",
+            "description": "The stock keeping unit."
+          },
+          "tags": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          }
+        },
+        "required": [
+          "sku"
+        ]
+      }
+    }
+  }
+}`
+	c.Assert(findDiff(string(b), expected), Equals, "")
+}
+
+func (self *emitterSuite) TestOpenAPIKeywords(c *C) {
+	min, exclusiveMax := 1.0, 10.0
+	schema := &JSONSchema{Property: Property{
+		Type: "object",
+		Properties: map[string]*Property{
+			"count":   {Type: "integer", Minimum: &min, ExclusiveMaximum: &exclusiveMax},
+			"kind":    {Type: "string", Const: "order"},
+			"version": {Type: "integer", Const: 2},
+			"value":   {Type: "string", Types: []string{"string", "integer", "null"}},
+		},
+	}}
+	b, err := schema.Emit(DialectOpenAPI30)
+	c.Assert(err, IsNil)
+
+	expected := `{
+  "schema": {
+    "type": "object",
+    "properties": {
+      "count": {
+        "exclusiveMaximum": true,
+        "maximum": 10,
+        "minimum": 1,
+        "type": "integer"
+      },
+      "kind": {
+        "type": "string",
+        "enum": [
+          "order"
+        ]
+      },
+      "value": {
+        "anyOf": [
+          {
+            "type": "string"
+          },
+          {
+            "type": "integer"
+          }
+        ],
+        "nullable": true
+      },
+      "version": {
+        "type": "integer",
+        "enum": [
+          2
+        ]
+      }
+    }
+  }
+}`
+	c.Assert(findDiff(string(b), expected), Equals, "")
+}
+
+func (self *emitterSuite) TestKubernetesCRD(c *C) {
+	b, err := NewGenerator().WithRoot(emitterMetadata{}).MustGenerate().Emit(DialectKubernetesCRD)
+	c.Assert(err, IsNil)
+
+	expected := `{
+  "openAPIV3Schema": {
+    "type": "object",
+    "properties": {
+      "metadata": {
+        "additionalProperties": true,
+        "type": "object",
+        "x-kubernetes-preserve-unknown-fields": true
+      }
+    }
+  }
+}`
+	c.Assert(findDiff(string(b), expected), Equals, "")
+}
+
+func (self *emitterSuite) TestLLMTool(c *C) {
+	schema := self.emitterSchema()
+	schema.Extensions = map[string]interface{}{"x-internal": true}
+	b, err := schema.Emit(DialectLLMTool)
+	c.Assert(err, IsNil)
+
+	var model Property
+	c.Assert(json.Unmarshal(b, &model), IsNil)
+	c.Assert(model.Extensions, HasLen, 0)
+	c.Assert(model.Properties["lines"].Items.Properties["sku"].Type, Equals, "string")
+
+	_, err = (&JSONSchema{Property: Property{Type: "array"}}).Emit(DialectLLMTool)
+	c.Assert(err, ErrorMatches, "the parameters of tools must be objects, not array values")
+}
+
+func (self *emitterSuite) TestAvro(c *C) {
+	b, err := self.emitterSchema().Emit(DialectAvro)
+	c.Assert(err, IsNil)
+
+	expected := `{
+  "fields": [
+    {
+      "default": null,
+      "name": "labels",
+      "type": [
+        "null",
+        {
+          "type": "map",
+          "values": "string"
+        }
+      ]
+    },
+    {
+      "default": null,
+      "name": "lines",
+      "type": [
+        "null",
+        {
+          "items": {
+            "fields": [
+              {
+                "default": null,
+                "name": "note",
+                "type": [
+                  "null",
+                  "string"
+                ]
+              },
+              {
+                "default": null,
+                "name": "quantity",
+                "type": [
+                  "null",
+                  "long"
+                ]
+              },
+              {
+                "doc": "The stock keeping unit.",
+                "name": "sku",
+                "type": "string"
+              },
+              {
+                "default": null,
+                "name": "tags",
+                "type": [
+                  "null",
+                  {
+                    "items": "string",
+                    "type": "array"
+                  }
+                ]
+              }
+            ],
+            "name": "Line",
+            "type": "record"
+          },
+          "type": "array"
+        }
+      ]
+    },
+    {
+      "name": "status",
+      "type": {
+        "name": "Status",
+        "symbols": [
+          "new",
+          "shipped"
+        ],
+        "type": "enum"
+      }
+    }
+  ],
+  "name": "Order",
+  "type": "record"
+}`
+	c.Assert(findDiff(string(b), expected), Equals, "")
+
+	// recursive definitions are referenced by name
+	b, err = NewGenerator().WithRoot(emitterNode{}).WithDefinition("node", emitterNode{}).MustGenerate().Emit(DialectAvro)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `(?s).*"items": "Node".*`)
+
+	_, err = NewGenerator().WithRoot(emitterMetadata{}).MustGenerate().Emit(DialectAvro)
+	c.Assert(err, ErrorMatches, "metadata: values of any type can't be described in Avro")
+}
+
+func (self *emitterSuite) TestTypeScript(c *C) {
+	b, err := self.emitterSchema().Emit(DialectTypeScript)
+	c.Assert(err, IsNil)
+
+	expected := `export interface Line {
+  note?: string | null;
+  quantity?: number;
+  /** The stock keeping unit. */
+  sku: string;
+  tags?: string[];
+}
+
+export interface Order {
+  labels?: Record<string, string>;
+  lines?: Line[];
+  status: "new" | "shipped";
+}
+`
+	c.Assert(string(b), Equals, expected)
+
+	b, err = NewGenerator().WithRoot(emitterNode{}).WithDefinition("node", emitterNode{}).MustGenerate().Emit(DialectTypeScript)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "export interface Node {\n  children?: Node[];\n}\n")
+}
@@ -0,0 +1,176 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LargeEnumStyle is the representation of enums of more than
+// Options.MaxEnumSize values.
+type LargeEnumStyle int
+
+const (
+	// EnumPattern replaces the enum by a pattern matching its values, e.g.
+	// "^[A-Z]{2}$" for country codes, which also matches other values.
+	// Enums of strings which already have a pattern are kept.
+	EnumPattern LargeEnumStyle = iota
+	// EnumDefinition moves the enum to a definition referenced wherever it
+	// is used, named after the first property holding it, or countryCode,
+	// currencyCode and languageCode for the codes of CountryCode,
+	// CurrencyCode and LanguageCode.
+	EnumDefinition
+)
+
+// minifyEnums minifies the enums of the roots and definitions larger than
+// set by the options, and returns the definitions with those added.
+func minifyEnums(options Options, definitions map[string]Property, roots ...*Property) map[string]Property {
+	if options.MaxEnumSize <= 0 {
+		return definitions
+	}
+	m := &enumMinifier{options: options, definitions: definitions, names: map[string]string{}}
+	for _, root := range roots {
+		m.minify(root, "", false)
+	}
+	for _, name := range sortedDefinitionNames(definitions) {
+		def := definitions[name]
+		// a definition is already shared
+		m.minify(&def, name, true)
+		m.definitions[name] = def
+	}
+	return m.definitions
+}
+
+type enumMinifier struct {
+	options     Options
+	definitions map[string]Property
+	// names holds the names of the definitions added, by values
+	names map[string]string
+}
+
+// minify minifies the enums of p, the property name, and of its subschemas.
+func (m *enumMinifier) minify(p *Property, name string, shared bool) {
+	if p == nil {
+		return
+	}
+	if values, ok := enumStrings(p.Enum); ok && len(values) > m.options.MaxEnumSize {
+		switch {
+		case m.options.LargeEnums == EnumDefinition && !shared && p.Type == "string":
+			p.Ref = definitionReference(m.define(values, name))
+			p.Type, p.Enum = "", nil
+		case m.options.LargeEnums == EnumPattern && p.Pattern == "":
+			p.Pattern = enumPattern(values)
+			p.Enum = nil
+		}
+	}
+	m.minify(p.Items, name, false)
+	m.minify(p.AdditionalPropertiesSchema, name, false)
+	m.minify(p.Not, name, false)
+	for _, property := range sortedPropertyNames(p.Properties) {
+		m.minify(p.Properties[property], property, false)
+	}
+	for _, property := range sortedPropertyNames(p.Dependencies) {
+		m.minify(p.Dependencies[property], property, false)
+	}
+	for _, property := range sortedPropertyNames(p.DependentSchemas) {
+		m.minify(p.DependentSchemas[property], property, false)
+	}
+	for _, branch := range p.AnyOf {
+		m.minify(branch, name, false)
+	}
+	for _, branch := range p.OneOf {
+		m.minify(branch, name, false)
+	}
+}
+
+// define returns the name of the definition of the string values, adding it
+// when missing, named after the property name.
+func (m *enumMinifier) define(values []string, name string) string {
+	key := strings.Join(values, "\x00")
+	if defined, ok := m.names[key]; ok {
+		return defined
+	}
+	for _, iso := range isoDefinitions {
+		if equalStrings(values, iso.values) {
+			name = iso.name
+		}
+	}
+	if name == "" {
+		name = "enum"
+	}
+	defined := name
+	for i := 2; ; i++ {
+		def, exists := m.definitions[defined]
+		if !exists {
+			break
+		}
+		if enum, ok := enumStrings(def.Enum); ok && def.Type == "string" && equalStrings(enum, values) && def.Ref == "" {
+			m.names[key] = defined
+			return defined
+		}
+		defined = fmt.Sprintf("%s%d", name, i)
+	}
+	if m.definitions == nil {
+		m.definitions = map[string]Property{}
+	}
+	m.definitions[defined] = Property{Type: "string", Enum: enumValues(values)}
+	m.names[key] = defined
+	return defined
+}
+
+// enumPattern returns a pattern matching the values, from the classes of
+// their characters and the range of their lengths.
+func enumPattern(values []string) string {
+	minLength, maxLength := -1, 0
+	classes := map[string]bool{}
+	for _, v := range values {
+		length := utf8.RuneCountInString(v)
+		if minLength < 0 || length < minLength {
+			minLength = length
+		}
+		if length > maxLength {
+			maxLength = length
+		}
+		for _, c := range v {
+			switch {
+			case c >= 'A' && c <= 'Z':
+				classes["A-Z"] = true
+			case c >= 'a' && c <= 'z':
+				classes["a-z"] = true
+			case c >= '0' && c <= '9':
+				classes["0-9"] = true
+			case strings.ContainsRune(`\]^-[`, c):
+				classes[`\`+string(c)] = true
+			case unicode.IsSpace(c):
+				classes[`\s`] = true
+			default:
+				classes[string(c)] = true
+			}
+		}
+	}
+	class := make([]string, 0, len(classes))
+	for c := range classes {
+		class = append(class, c)
+	}
+	sort.Strings(class)
+
+	length := fmt.Sprintf("{%d,%d}", minLength, maxLength)
+	if minLength == maxLength {
+		length = fmt.Sprintf("{%d}", minLength)
+	}
+	return "^[" + strings.Join(class, "") + "]" + length + "$"
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type enumsSuite struct{}
+
+var _ = Suite(&enumsSuite{})
+
+type ExampleJSONLocale struct {
+	Country  CountryCode   `json:"country"`
+	Billing  CountryCode   `json:"billing"`
+	Currency *CurrencyCode `json:"currency"`
+	Language LanguageCode  `json:"language"`
+	Size     string        `json:"size" enum:"s|m|l|xl"`
+	Region   string        `json:"region" enum:"eu-west-1|us-east-1|ap-south_1"`
+}
+
+func (self *enumsSuite) TestISOCodes(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONLocale{}).MustGenerate()
+
+	c.Assert(j.Properties["country"].Enum, HasLen, 249)
+	c.Assert(j.Properties["country"].Enum[0], Equals, "AD")
+	c.Assert(j.Properties["language"].Enum, HasLen, 183)
+	c.Assert(j.Properties["size"].Enum, HasLen, 4)
+}
+
+func (self *enumsSuite) TestEnumPattern(c *C) {
+	j := NewGenerator(Options{MaxEnumSize: 2}).WithRoot(&ExampleJSONLocale{}).MustGenerate()
+
+	c.Assert(j.Properties["country"], DeepEquals, &Property{Type: "string", Pattern: "^[A-Z]{2}$"})
+	c.Assert(j.Properties["currency"].AnyOf[0].Pattern, Equals, "^[A-Z]{3}$")
+	c.Assert(j.Properties["language"].Pattern, Equals, "^[a-z]{2}$")
+	c.Assert(j.Properties["size"].Pattern, Equals, "^[a-z]{1,2}$")
+	c.Assert(j.Properties["region"].Pattern, Equals, "^[0-9\\-_a-z]{9,10}$")
+	c.Assert(j.Properties["region"].Enum, IsNil)
+
+	j = NewGenerator(Options{MaxEnumSize: 10}).WithRoot(&ExampleJSONLocale{}).MustGenerate()
+	c.Assert(j.Properties["size"].Enum, HasLen, 4)
+}
+
+func (self *enumsSuite) TestEnumDefinition(c *C) {
+	options := Options{MaxEnumSize: 3, LargeEnums: EnumDefinition}
+	j := NewGenerator(options).
+		WithRoot(&ExampleJSONLocale{}).
+		WithDefinition("size", "").
+		MustGenerate()
+
+	c.Assert(j.Properties["country"], DeepEquals, &Property{Ref: "#/definitions/countryCode"})
+	c.Assert(j.Properties["billing"], DeepEquals, &Property{Ref: "#/definitions/countryCode"})
+	c.Assert(j.Properties["language"], DeepEquals, &Property{Ref: "#/definitions/languageCode"})
+	c.Assert(j.Definitions["countryCode"].Enum, HasLen, 249)
+	// nullable values reference it from their branch which isn't null
+	c.Assert(j.Properties["currency"].AnyOf[0], DeepEquals, &Property{Ref: "#/definitions/currencyCode"})
+	// named after the property, without conflicting with other definitions
+	c.Assert(j.Properties["size"], DeepEquals, &Property{Ref: "#/definitions/size2"})
+	c.Assert(j.Definitions["size2"], DeepEquals, Property{Type: "string", Enum: []interface{}{"s", "m", "l", "xl"}})
+	c.Assert(j.Properties["region"].Enum, HasLen, 3)
+
+	set, err := NewGenerator(options).GenerateSet(map[string]interface{}{
+		"a": &ExampleJSONLocale{},
+		"b": &ExampleJSONLocale{},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(set.Roots["a"].Properties["country"].Ref, Equals, "#/definitions/countryCode")
+	c.Assert(set.Roots["b"].Properties["size"].Ref, Equals, "#/definitions/size")
+	c.Assert(sortedDefinitionNames(set.Definitions), DeepEquals, []string{"countryCode", "currencyCode", "languageCode", "size"})
+}
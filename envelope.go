@@ -0,0 +1,96 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Envelope builds the schema of a message envelope, as commonly used by
+// WebSocket and other streaming protocols:
+//
+//	{"type": "<message name>", "payload": {...}}
+//
+// Each message payload type is emitted as a definition named after the
+// message, and the envelope only accepts the payload matching its type. The
+// payload property lists the payloads of every message with anyOf, as
+// payloads of different messages may be alike.
+type Envelope struct {
+	options      []Options
+	definitions  map[string]interface{}
+	messages     map[string]interface{}
+	typeField    string
+	payloadField string
+}
+
+// NewEnvelope returns an Envelope using "type" and "payload" as field names.
+func NewEnvelope(options ...Options) *Envelope {
+	return &Envelope{
+		options:      options,
+		definitions:  map[string]interface{}{},
+		messages:     map[string]interface{}{},
+		typeField:    "type",
+		payloadField: "payload",
+	}
+}
+
+// WithFields sets the names of the discriminator and payload fields.
+func (e *Envelope) WithFields(typeField, payloadField string) *Envelope {
+	e.typeField = typeField
+	e.payloadField = payloadField
+	return e
+}
+
+// WithMessage registers the payload type of the message with the given name.
+// payload may be an instance of the type or its reflect.Type.
+func (e *Envelope) WithMessage(name string, payload interface{}) *Envelope {
+	e.messages[name] = payload
+	return e
+}
+
+// WithDefinition registers an additional definition which the message payloads may reference.
+func (e *Envelope) WithDefinition(name string, d interface{}) *Envelope {
+	e.definitions[name] = d
+	return e
+}
+
+// Generate generates the envelope schema.
+func (e *Envelope) Generate() (*JSONSchema, error) {
+	if len(e.messages) == 0 {
+		return nil, fmt.Errorf("envelope has no messages")
+	}
+
+	g := NewGenerator(e.options...).WithDefinitions(e.definitions)
+	names := make([]string, 0, len(e.messages))
+	for name, payload := range e.messages {
+		if _, ok := e.definitions[name]; ok {
+			return nil, fmt.Errorf("message %s conflicts with a definition of the same name", name)
+		}
+		names = append(names, name)
+		g.WithDefinition(name, payload)
+	}
+	sort.Strings(names)
+
+	js, err := g.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	payloads := make([]*Property, 0, len(names))
+	for _, name := range names {
+		payloads = append(payloads, &Property{Ref: definitionReference(name)})
+		js.OneOf = append(js.OneOf, &Property{
+			Properties: map[string]*Property{
+				e.typeField:    {Const: name},
+				e.payloadField: {Ref: definitionReference(name)},
+			},
+		})
+	}
+
+	js.Type = "object"
+	js.Properties = map[string]*Property{
+		e.typeField:    {Type: "string", Enum: enumValues(names)},
+		e.payloadField: {AnyOf: payloads},
+	}
+	js.Required = []string{e.typeField, e.payloadField}
+	return js, nil
+}
@@ -0,0 +1,113 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type envelopeSuite struct{}
+
+var _ = Suite(&envelopeSuite{})
+
+type ExampleJSONChatMessage struct {
+	Text string `json:"text" required:"true"`
+}
+
+type ExampleJSONJoinMessage struct {
+	Room string `json:"room" required:"true"`
+}
+
+func (self *envelopeSuite) TestEnvelope(c *C) {
+	j, err := NewEnvelope().
+		WithMessage("join", &ExampleJSONJoinMessage{}).
+		WithMessage("chat", ExampleJSONChatMessage{}).
+		Generate()
+	c.Assert(err, IsNil)
+
+	k := JSONSchema{
+		Schema: DEFAULT_SCHEMA,
+		Definitions: map[string]Property{
+			"chat": Property{
+				Type:       "object",
+				Properties: map[string]*Property{"text": &Property{Type: "string"}},
+				Required:   []string{"text"},
+			},
+			"join": Property{
+				Type:       "object",
+				Properties: map[string]*Property{"room": &Property{Type: "string"}},
+				Required:   []string{"room"},
+			},
+		},
+		Property: Property{
+			Type: "object",
+			Properties: map[string]*Property{
+				"type": &Property{Type: "string", Enum: []interface{}{"chat", "join"}},
+				"payload": &Property{AnyOf: []*Property{
+					{Ref: "#/definitions/chat"},
+					{Ref: "#/definitions/join"},
+				}},
+			},
+			Required: []string{"type", "payload"},
+			OneOf: []*Property{
+				{Properties: map[string]*Property{
+					"type":    {Const: "chat"},
+					"payload": {Ref: "#/definitions/chat"},
+				}},
+				{Properties: map[string]*Property{
+					"type":    {Const: "join"},
+					"payload": {Ref: "#/definitions/join"},
+				}},
+			},
+		},
+	}
+
+	c.Assert(findDiff(j.String(), k.String()), Equals, "")
+}
+
+func (self *envelopeSuite) TestEnvelopeFields(c *C) {
+	e := NewEnvelope().
+		WithFields("kind", "data").
+		WithMessage("chat", ExampleJSONChatMessage{})
+
+	j, err := e.Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.Required, DeepEquals, []string{"kind", "data"})
+	c.Assert(j.Properties["data"].AnyOf, DeepEquals, []*Property{{Ref: "#/definitions/chat"}})
+
+	_, err = e.Generate()
+	c.Assert(err, IsNil)
+
+	_, err = NewEnvelope().Generate()
+	c.Assert(err, ErrorMatches, "envelope has no messages")
+}
+
+type ExampleJSONEmptyMessage struct{}
+
+type ExampleJSONOptionalMessage struct {
+	Text string `json:"text,omitempty"`
+}
+
+func (self *envelopeSuite) TestEnvelopeOverlappingPayloads(c *C) {
+	j, err := NewEnvelope().
+		WithMessage("ping", ExampleJSONEmptyMessage{}).
+		WithMessage("pong", ExampleJSONEmptyMessage{}).
+		WithMessage("note", ExampleJSONOptionalMessage{}).
+		Generate()
+	c.Assert(err, IsNil)
+
+	v, err := NewValidator(j)
+	c.Assert(err, IsNil)
+	for _, doc := range []string{
+		`{"type": "ping", "payload": {}}`,
+		`{"type": "pong", "payload": {}}`,
+		`{"type": "note", "payload": {}}`,
+		`{"type": "note", "payload": {"text": "hi"}}`,
+	} {
+		errs, err := v.Validate([]byte(doc))
+		c.Assert(err, IsNil)
+		c.Assert(errs, HasLen, 0, Commentf("%s: %v", doc, errs))
+	}
+
+	errs, err := v.Validate([]byte(`{"type": "ping", "payload": []}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, Not(HasLen), 0)
+}
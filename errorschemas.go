@@ -0,0 +1,132 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ErrorOptions configures the types of error responses.
+type ErrorOptions struct {
+	// Details is the type of the details of the errors, an instance of the type
+	// or its reflect.Type: its fields are the extension members of problem
+	// details, and it describes the meta of JSON:API errors and the items of
+	// the details of the Google error model. By default the details are any
+	// object.
+	Details interface{}
+}
+
+// googleStatuses are the canonical codes of the Google error model.
+const googleStatuses = "OK|CANCELLED|UNKNOWN|INVALID_ARGUMENT|DEADLINE_EXCEEDED|NOT_FOUND|" +
+	"ALREADY_EXISTS|PERMISSION_DENIED|RESOURCE_EXHAUSTED|FAILED_PRECONDITION|ABORTED|" +
+	"OUT_OF_RANGE|UNIMPLEMENTED|INTERNAL|UNAVAILABLE|DATA_LOSS|UNAUTHENTICATED"
+
+var rTypeAnyObject = reflect.TypeOf(map[string]interface{}{})
+
+// ProblemDetailsError returns the type of RFC 7807 problem details, to register
+// as a definition which error responses reference:
+//
+//	{"type": "...", "title": "...", "status": 400, "detail": "...", "instance": "..."}
+//
+// The exported fields of the details are extension members. It fails if the
+// details aren't a struct, or if their fields are named after the members of
+// problem details. Like Page, the types of error responses are built with
+// reflect.StructOf.
+func ProblemDetailsError(options ...ErrorOptions) (reflect.Type, error) {
+	fields := []reflect.StructField{
+		errorField("Type", "", `json:"type,omitempty" description:"A URI reference identifying the type of the problem."`),
+		errorField("Title", "", `json:"title,omitempty" description:"A short summary of the type of the problem."`),
+		errorField("Status", 0, `json:"status,omitempty" min:"100" max:"599" description:"The HTTP status code of the response."`),
+		errorField("Detail", "", `json:"detail,omitempty" description:"An explanation of this occurrence of the problem."`),
+		errorField("Instance", "", `json:"instance,omitempty" description:"A URI reference identifying this occurrence of the problem."`),
+	}
+	members := len(fields)
+	if details := errorDetails(options); details != nil {
+		if details.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("the details of problems must be structs, not %s", details)
+		}
+		for i := 0; i < details.NumField(); i++ {
+			f := details.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			for _, member := range fields[:members] {
+				if f.Name == member.Name || jsonFieldName(f) == jsonFieldName(member) {
+					return nil, fmt.Errorf("the field %s of %s conflicts with the %s member of problem details", f.Name, details, jsonFieldName(member))
+				}
+			}
+			fields = append(fields, f)
+		}
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// JSONAPIErrors returns the type of JSON:API error documents, to register as a
+// definition which error responses reference:
+//
+//	{"errors": [{"status": "422", "code": "...", "title": "...", "source": {"pointer": "/data"}}]}
+//
+// The details describe the meta of the errors.
+func JSONAPIErrors(options ...ErrorOptions) reflect.Type {
+	meta := errorDetails(options)
+	if meta == nil {
+		meta = rTypeAnyObject
+	}
+	links := reflect.StructOf([]reflect.StructField{
+		errorField("About", "", `json:"about,omitempty" description:"A link to further details about this occurrence of the problem."`),
+		errorField("Type", "", `json:"type,omitempty" description:"A link identifying the type of the problem."`),
+	})
+	source := reflect.StructOf([]reflect.StructField{
+		errorField("Pointer", "", `json:"pointer,omitempty" description:"A JSON pointer to the value of the request document causing the error."`),
+		errorField("Parameter", "", `json:"parameter,omitempty" description:"The query parameter causing the error."`),
+		errorField("Header", "", `json:"header,omitempty" description:"The header causing the error."`),
+	})
+	e := reflect.StructOf([]reflect.StructField{
+		errorField("ID", "", `json:"id,omitempty" description:"A unique identifier of this occurrence of the problem."`),
+		{Name: "Links", Type: reflect.PtrTo(links), Tag: `json:"links,omitempty"`},
+		errorField("Status", "", `json:"status,omitempty" pattern:"^[1-5][0-9][0-9]$" description:"The HTTP status code of the problem, as a string."`),
+		errorField("Code", "", `json:"code,omitempty" description:"An application-specific error code."`),
+		errorField("Title", "", `json:"title,omitempty" description:"A short summary of the problem."`),
+		errorField("Detail", "", `json:"detail,omitempty" description:"An explanation of this occurrence of the problem."`),
+		{Name: "Source", Type: reflect.PtrTo(source), Tag: `json:"source,omitempty"`},
+		{Name: "Meta", Type: meta, Tag: `json:"meta,omitempty" description:"Non-standard meta-information about the error."`},
+	})
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "Errors", Type: reflect.SliceOf(e), Tag: `json:"errors" required:"true"`},
+	})
+}
+
+// GoogleError returns the type of error responses of the Google error model,
+// as returned by Google APIs and gRPC gateways, to register as a definition
+// which error responses reference:
+//
+//	{"error": {"code": 404, "message": "...", "status": "NOT_FOUND", "details": [...]}}
+//
+// The details describe the items of the details of the error.
+func GoogleError(options ...ErrorOptions) reflect.Type {
+	details := errorDetails(options)
+	if details == nil {
+		details = rTypeAnyObject
+	}
+	status := reflect.StructOf([]reflect.StructField{
+		errorField("Code", 0, `json:"code" required:"true" description:"The HTTP status code of the response."`),
+		errorField("Message", "", `json:"message" required:"true" description:"A message describing the error to developers."`),
+		errorField("Status", "", `json:"status,omitempty" enum:"`+googleStatuses+`" description:"The canonical code of the error."`),
+		{Name: "Details", Type: reflect.SliceOf(details), Tag: `json:"details,omitempty" description:"Additional details about the error."`},
+	})
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "Error", Type: status, Tag: `json:"error" required:"true"`},
+	})
+}
+
+func errorField(name string, of interface{}, tag reflect.StructTag) reflect.StructField {
+	return reflect.StructField{Name: name, Type: reflect.TypeOf(of), Tag: tag}
+}
+
+func errorDetails(options []ErrorOptions) reflect.Type {
+	if len(options) == 0 || options[0].Details == nil {
+		return nil
+	}
+	return linkType(options[0].Details)
+}
@@ -0,0 +1,68 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type errorSchemasSuite struct{}
+
+var _ = Suite(&errorSchemasSuite{})
+
+type ExampleErrorDetails struct {
+	TraceID string `json:"traceId" required:"true"`
+	hidden  string
+}
+
+type ExampleConflictingDetails struct {
+	Code   string `json:"code"`
+	Reason string `json:"status"`
+}
+
+func (self *errorSchemasSuite) TestProblemDetailsError(c *C) {
+	problemType, err := ProblemDetailsError(ErrorOptions{Details: ExampleErrorDetails{}})
+	c.Assert(err, IsNil)
+	j := NewGenerator().
+		WithDefinition("problem", problemType).
+		MustGenerate()
+
+	problem := j.Definitions["problem"]
+	c.Assert(sortedPropertyNames(problem.Properties), DeepEquals, []string{"detail", "instance", "status", "title", "traceId", "type"})
+	c.Assert(problem.Required, DeepEquals, []string{"traceId"})
+	c.Assert(*problem.Properties["status"].Maximum, Equals, 599.0)
+
+	_, err = ProblemDetailsError(ErrorOptions{Details: ""})
+	c.Assert(err, ErrorMatches, "the details of problems must be structs, not string")
+	_, err = ProblemDetailsError(ErrorOptions{Details: struct{ Title string }{}})
+	c.Assert(err, ErrorMatches, "the field Title of struct { Title string } conflicts with the title member of problem details")
+	_, err = ProblemDetailsError(ErrorOptions{Details: ExampleConflictingDetails{}})
+	c.Assert(err, ErrorMatches, "the field Reason of jsonschema.ExampleConflictingDetails conflicts with the status member of problem details")
+}
+
+func (self *errorSchemasSuite) TestJSONAPIErrors(c *C) {
+	j := NewGenerator().
+		WithDefinition("details", ExampleErrorDetails{}).
+		WithDefinition("errors", JSONAPIErrors(ErrorOptions{Details: &ExampleErrorDetails{}})).
+		MustGenerate()
+
+	errors := j.Definitions["errors"]
+	c.Assert(errors.Required, DeepEquals, []string{"errors"})
+	e := errors.Properties["errors"].Items
+	c.Assert(sortedPropertyNames(e.Properties), DeepEquals, []string{"code", "detail", "id", "links", "meta", "source", "status", "title"})
+	c.Assert(e.Properties["meta"].Ref, Equals, "#/definitions/details")
+	c.Assert(sortedPropertyNames(e.Properties["source"].Properties), DeepEquals, []string{"header", "parameter", "pointer"})
+
+	j = NewGenerator().WithRoot(JSONAPIErrors()).MustGenerate()
+	c.Assert(j.Properties["errors"].Items.Properties["meta"].AdditionalProperties, Equals, true)
+}
+
+func (self *errorSchemasSuite) TestGoogleError(c *C) {
+	j := NewGenerator().WithRoot(GoogleError()).MustGenerate()
+
+	c.Assert(j.Required, DeepEquals, []string{"error"})
+	status := j.Properties["error"]
+	c.Assert(status.Required, DeepEquals, []string{"code", "message"})
+	c.Assert(status.Properties["status"].Enum, HasLen, 17)
+	c.Assert(status.Properties["details"].Items.Type, Equals, "object")
+}
@@ -0,0 +1,135 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// addExampleFromTags adds the value of the example tag to the examples of p,
+// parsed according to t, the type of the values of the property: the tag
+// holds the text of strings and the JSON encoding of other values.
+func (p *Property) addExampleFromTags(tag *reflect.StructTag, t string) error {
+	raw, ok := tag.Lookup("example")
+	if !ok {
+		return nil
+	}
+
+	var v interface{}
+	var err error
+	switch t {
+	case "string":
+		v = raw
+	case "integer":
+		v, err = strconv.ParseInt(raw, 10, 64)
+	case "number":
+		v, err = strconv.ParseFloat(raw, 64)
+	case "boolean":
+		v, err = strconv.ParseBool(raw)
+	default:
+		err = json.Unmarshal([]byte(raw), &v)
+		if err != nil && t == "" {
+			// values of any type may be given as text
+			v, err = raw, nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf(`invalid "example" tag value %q: %s`, raw, err)
+	}
+	p.Examples = append(p.Examples, v)
+	return nil
+}
+
+// AssembleExample builds an example document from the examples, defaults,
+// consts and enums of the properties of the schema, e.g. to embed in OpenAPI
+// documents. Values without any of them get a placeholder of their type.
+func (d *JSONSchema) AssembleExample() interface{} {
+	v, _ := d.example(&d.Property, map[string]bool{})
+	return v
+}
+
+// example returns an example value of p. It returns false for references
+// looping back to a schema whose example is being built.
+func (d *JSONSchema) example(p *Property, visiting map[string]bool) (interface{}, bool) {
+	if p.Ref != "" {
+		if visiting[p.Ref] {
+			return nil, false
+		}
+		visiting[p.Ref] = true
+		defer delete(visiting, p.Ref)
+		p = d.resolve(p)
+		if p.Ref != "" {
+			// an external reference
+			return nil, false
+		}
+	}
+
+	switch {
+	case len(p.Examples) > 0:
+		return p.Examples[0], true
+	case p.Default != nil:
+		return p.Default, true
+	case p.Const != nil:
+		return p.Const, true
+	case len(p.Enum) > 0:
+		return p.Enum[0], true
+	}
+
+	for _, branches := range [][]*Property{p.AnyOf, p.OneOf} {
+		for _, branch := range branches {
+			if branch.Type != "null" {
+				if v, ok := d.example(branch, visiting); ok {
+					return v, true
+				}
+			}
+		}
+	}
+
+	switch p.Type {
+	case "object":
+		object := map[string]interface{}{}
+		for name, s := range p.Properties {
+			if name == ".*" {
+				name = "key"
+			}
+			if v, ok := d.example(s, visiting); ok {
+				object[name] = v
+			}
+		}
+		if p.AdditionalPropertiesSchema != nil && len(p.Properties) == 0 {
+			if v, ok := d.example(p.AdditionalPropertiesSchema, visiting); ok {
+				object["key"] = v
+			}
+		}
+		return object, true
+	case "array":
+		array := []interface{}{}
+		if p.Items != nil {
+			if v, ok := d.example(p.Items, visiting); ok {
+				array = append(array, v)
+			}
+		}
+		return array, true
+	case "string":
+		switch p.Format {
+		case "date-time":
+			return "2006-01-02T15:04:05Z", true
+		case "binary":
+			return "", true
+		}
+		if p.MinLength != nil {
+			return strings.Repeat("x", int(*p.MinLength)), true
+		}
+		return "string", true
+	case "integer", "number":
+		if p.Minimum != nil {
+			return *p.Minimum, true
+		}
+		return 0, true
+	case "boolean":
+		return false, true
+	}
+	return nil, true
+}
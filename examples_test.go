@@ -0,0 +1,91 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type examplesSuite struct{}
+
+var _ = Suite(&examplesSuite{})
+
+type ExampleJSONExampleAddress struct {
+	Street string `json:"street" example:"1 Main St"`
+	Zip    string `json:"zip" pattern:"^[0-9]{5}$"`
+}
+
+type ExampleJSONExampleCustomer struct {
+	Name     string                      `json:"name" example:"Ann"`
+	Age      int                         `json:"age" example:"42"`
+	Score    float64                     `json:"score" example:"9.5" min:"1"`
+	Active   bool                        `json:"active" example:"true"`
+	Nickname *string                     `json:"nickname" example:"Annie"`
+	Tags     []string                    `json:"tags" example:"[\"a\",\"b\"]"`
+	Status   string                      `json:"status" enum:"new|active"`
+	Created  time.Time                   `json:"created"`
+	Address  ExampleJSONExampleAddress   `json:"address"`
+	Referrer *ExampleJSONExampleCustomer `json:"referrer"`
+	Attrs    map[string]int              `json:"attrs"`
+	Anything interface{}                 `json:"anything" example:"{\"a\":1}"`
+	Freeform interface{}                 `json:"freeform" example:"text"`
+}
+
+func (self *examplesSuite) TestExampleTag(c *C) {
+	js := NewGenerator().WithRoot(&ExampleJSONExampleCustomer{}).MustGenerate()
+	c.Assert(js.Ref, Equals, "#/definitions/exampleJSONExampleCustomer")
+	customer := js.Definitions["exampleJSONExampleCustomer"]
+
+	c.Assert(customer.Properties["name"].Examples, DeepEquals, []interface{}{"Ann"})
+	c.Assert(customer.Properties["age"].Examples, DeepEquals, []interface{}{int64(42)})
+	c.Assert(customer.Properties["score"].Examples, DeepEquals, []interface{}{9.5})
+	c.Assert(customer.Properties["active"].Examples, DeepEquals, []interface{}{true})
+	c.Assert(customer.Properties["nickname"].Examples, DeepEquals, []interface{}{"Annie"})
+	c.Assert(customer.Properties["tags"].Examples, DeepEquals, []interface{}{[]interface{}{"a", "b"}})
+	c.Assert(customer.Properties["anything"].Examples, DeepEquals, []interface{}{map[string]interface{}{"a": 1.0}})
+	c.Assert(customer.Properties["freeform"].Examples, DeepEquals, []interface{}{"text"})
+
+	b, err := json.Marshal(customer.Properties["age"])
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"type":"integer","examples":[42]}`)
+
+	type invalid struct {
+		Age int `example:"forty"`
+	}
+	_, err = NewGenerator().WithRoot(&invalid{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Age:invalid "example" tag value "forty": .*`)
+}
+
+func (self *examplesSuite) TestAssembleExample(c *C) {
+	js := NewGenerator().
+		WithDefinition("address", ExampleJSONExampleAddress{}).
+		WithDefinition("customer", ExampleJSONExampleCustomer{}).
+		WithRoot(&ExampleJSONExampleCustomer{}).
+		MustGenerate()
+
+	b, err := json.Marshal(js.AssembleExample())
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"active":true,"address":{"street":"1 Main St","zip":"string"},"age":42,`+
+		`"anything":{"a":1},"attrs":{"key":0},"created":"2006-01-02T15:04:05Z","freeform":"text",`+
+		`"name":"Ann","nickname":"Annie","score":9.5,"status":"new","tags":["a","b"]}`)
+}
+
+func (self *examplesSuite) TestAssembleExampleDefaults(c *C) {
+	js := &JSONSchema{}
+	c.Assert(json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"size": {"type": "integer", "default": 10, "minimum": 1},
+			"limit": {"type": "integer", "minimum": 1},
+			"code": {"type": "string", "minLength": 3},
+			"kind": {"const": "order"},
+			"items": {"type": "array", "items": {"type": "boolean"}},
+			"extra": {"type": "object", "additionalProperties": {"type": "string"}}
+		}
+	}`), js), IsNil)
+
+	b, err := json.Marshal(js.AssembleExample())
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"code":"xxx","extra":{"key":"string"},"items":[false],"kind":"order","limit":1,"size":10}`)
+}
@@ -0,0 +1,128 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FlagsExtension is the extension keyword holding the values of the flags
+// combined by an integer bitmask, by name.
+const FlagsExtension = "x-flags"
+
+// FlagsStyle is the representation of the bitmasks described by the flags tag.
+type FlagsStyle int
+
+const (
+	// FlagsInteger describes bitmasks as integers combining the flags,
+	// listed in the x-flags extension.
+	FlagsInteger FlagsStyle = iota
+	// FlagsArray describes bitmasks as arrays of the names of their flags,
+	// for types marshaling them so.
+	FlagsArray
+)
+
+// Flags returns a copy of the values of the flags combined by the values of
+// the property, by name, if it is a bitmask.
+func (p *Property) Flags() (map[string]int64, bool) {
+	switch flags := p.Extensions[FlagsExtension].(type) {
+	case map[string]int64:
+		return cloneValue(flags).(map[string]int64), true
+	case map[string]interface{}:
+		values := make(map[string]int64, len(flags))
+		for name, v := range flags {
+			switch v := v.(type) {
+			case float64:
+				values[name] = int64(v)
+			case json.Number:
+				n, err := v.Int64()
+				if err != nil {
+					return nil, false
+				}
+				values[name] = n
+			default:
+				return nil, false
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// flagNames returns the names of the flags in the order of their values.
+func flagNames(flags map[string]int64) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if flags[names[i]] != flags[names[j]] {
+			return flags[names[i]] < flags[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// addFlagsFromTags describes the values of an integer property as the
+// bitmasks of the flags tag, naming the flags and their values separated by
+// vertical bars, e.g. `flags:"read=1|write=2|admin=4"`, in the style set by
+// Options.Flags.
+func (r *reader) addFlagsFromTags(n *Node, tag *reflect.StructTag) error {
+	return n.setFlagsFromTags(tag, r.options.Flags)
+}
+
+func (n *Node) setFlagsFromTags(tag *reflect.StructTag, style FlagsStyle) error {
+	flags, ok, err := parseFlags(tag)
+	if !ok || err != nil {
+		return err
+	}
+	if n.Kind != KindInteger {
+		return fmt.Errorf(`"flags" tag on a %s property`, n.schemaType())
+	}
+
+	if style == FlagsArray {
+		*n = Node{
+			Kind:   KindArray,
+			Elem:   &Node{Kind: KindString, Schema: &Property{Enum: enumValues(flagNames(flags))}},
+			Schema: &Property{},
+		}
+		return nil
+	}
+	p := n.Schema
+	var mask int64
+	for _, value := range flags {
+		mask |= value
+	}
+	p.Minimum = float64ptr(0)
+	p.Maximum = float64ptr(mask)
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[FlagsExtension] = flags
+	return nil
+}
+
+// parseFlags returns the values of the flags of the flags tag by name.
+func parseFlags(tag *reflect.StructTag) (map[string]int64, bool, error) {
+	raw, ok := tag.Lookup("flags")
+	if !ok {
+		return nil, false, nil
+	}
+	flags := map[string]int64{}
+	for _, flag := range strings.Split(raw, "|") {
+		i := strings.Index(flag, "=")
+		if i <= 0 {
+			return nil, true, fmt.Errorf(`invalid flag %q in "flags" tag`, flag)
+		}
+		value, err := strconv.ParseInt(flag[i+1:], 0, 64)
+		if err != nil || value <= 0 {
+			return nil, true, fmt.Errorf(`invalid flag %q in "flags" tag`, flag)
+		}
+		flags[flag[:i]] = value
+	}
+	return flags, true, nil
+}
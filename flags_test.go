@@ -0,0 +1,53 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type flagsSuite struct{}
+
+var _ = Suite(&flagsSuite{})
+
+type ExampleJSONPermissions struct {
+	Mode uint8 `json:"mode" flags:"read=1|write=2|admin=4"`
+}
+
+type ExampleJSONInvalidFlags struct {
+	Mode string `json:"mode" flags:"read=1"`
+}
+
+func (self *flagsSuite) TestFlagsTag(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONPermissions{}).MustGenerate()
+	mode := j.Properties["mode"]
+	c.Assert(mode.Type, Equals, "integer")
+	c.Assert(*mode.Minimum, Equals, float64(0))
+	c.Assert(*mode.Maximum, Equals, float64(7))
+	flags, ok := mode.Flags()
+	c.Assert(ok, Equals, true)
+	c.Assert(flags, DeepEquals, map[string]int64{"read": 1, "write": 2, "admin": 4})
+	c.Assert(j.String(), Matches, `(?s).*"x-flags": \{\s*"admin": 4,\s*"read": 1,\s*"write": 2\s*\}.*`)
+
+	j = NewGenerator(Options{Flags: FlagsArray}).WithRoot(&ExampleJSONPermissions{}).MustGenerate()
+	c.Assert(j.Properties["mode"], DeepEquals, &Property{
+		Type:  "array",
+		Items: &Property{Type: "string", Enum: []interface{}{"read", "write", "admin"}},
+	})
+
+	_, err := NewGenerator().WithRoot(&ExampleJSONInvalidFlags{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Mode:"flags" tag on a string property`)
+}
+
+func (self *flagsSuite) TestValidateFlags(c *C) {
+	j := &JSONSchema{Property: Property{
+		Type:       "integer",
+		Extensions: map[string]interface{}{FlagsExtension: map[string]interface{}{"read": float64(1), "write": float64(4)}},
+	}}
+	errs, err := j.Validate([]byte(`5`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	errs, err = j.Validate([]byte(`2`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{
+		{SchemaPath: "/x-flags", Keyword: "x-flags", Message: "must only combine the flags read, write"},
+	})
+}
@@ -0,0 +1,166 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// AllowNaNExtension is the extension keyword marking number properties whose
+// values may be NaN or infinite, written as the NaN, Infinity and -Infinity
+// tokens some encoders emit.
+const AllowNaNExtension = "x-allow-nan"
+
+// floatBounds maps float kinds to the largest finite value they hold.
+var floatBounds = map[reflect.Kind]float64{
+	reflect.Float32: math.MaxFloat32,
+	reflect.Float64: math.MaxFloat64,
+}
+
+// AllowsNaN reports whether the values of the property may be NaN or infinite.
+func (p *Property) AllowsNaN() bool {
+	allow, _ := p.Extensions[AllowNaNExtension].(bool)
+	if i, ok := nullableBranch(p.AnyOf); ok && !allow && p.Type == "" {
+		allow, _ = p.AnyOf[i].Extensions[AllowNaNExtension].(bool)
+	}
+	return allow
+}
+
+// nonFiniteTokens are the tokens some encoders emit for NaN and infinite
+// values, which aren't JSON.
+var nonFiniteTokens = []string{"-Infinity", "Infinity", "NaN"}
+
+// decodeNonFinite decodes a JSON document which may hold the NaN, Infinity
+// and -Infinity tokens, as json.Numbers of their text. The tokens are
+// replaced by number literals found nowhere in data before decoding, so
+// that the numbers decoded with their text are theirs.
+func decodeNonFinite(data []byte) (interface{}, error) {
+	zeros := "0"
+	literal := func(i int) string { return "0E-" + zeros + strconv.Itoa(i+1) }
+	// literals of more zeros contain those of less
+	for bytes.Contains(data, []byte("0E-"+zeros)) {
+		zeros += "0"
+	}
+
+	var b bytes.Buffer
+	replaced := false
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		default:
+			// outside strings, no other JSON token holds these letters
+			if j, ok := nonFiniteTokenAt(data[i:]); ok {
+				b.WriteString(literal(j))
+				i += len(nonFiniteTokens[j]) - 1
+				replaced = true
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	if !replaced {
+		return decodeDocument(data)
+	}
+
+	doc, err := decodeDocument(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(map[json.Number]json.Number, len(nonFiniteTokens))
+	for i, token := range nonFiniteTokens {
+		tokens[json.Number(literal(i))] = json.Number(token)
+	}
+	return restoreNonFinite(doc, tokens), nil
+}
+
+// nonFiniteTokenAt returns the index of the token data starts with.
+func nonFiniteTokenAt(data []byte) (int, bool) {
+	for i, token := range nonFiniteTokens {
+		if bytes.HasPrefix(data, []byte(token)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// restoreNonFinite replaces the number literals of value standing for the
+// tokens by the tokens.
+func restoreNonFinite(value interface{}, tokens map[json.Number]json.Number) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if token, ok := tokens[v]; ok {
+			return token
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = restoreNonFinite(v[i], tokens)
+		}
+	case map[string]interface{}:
+		for name := range v {
+			v[name] = restoreNonFinite(v[name], tokens)
+		}
+	}
+	return value
+}
+
+// isNonFinite reports whether the number n is NaN or infinite.
+func isNonFinite(n json.Number) bool {
+	f, err := n.Float64()
+	return err == nil && (math.IsNaN(f) || math.IsInf(f, 0))
+}
+
+func (r *reader) readFloat(p *Property, kind reflect.Kind) {
+	if !r.options.FiniteFloats {
+		return
+	}
+	p.Minimum = float64ptr(-floatBounds[kind])
+	p.Maximum = float64ptr(floatBounds[kind])
+}
+
+// addNaNFromTags allows NaN and infinite values of p, describing values of
+// the type t, by the allowNaN tag.
+func (p *Property) addNaNFromTags(tag *reflect.StructTag, t string) error {
+	raw, ok := tag.Lookup("allowNaN")
+	if !ok {
+		return nil
+	}
+	allow, err := strconv.ParseBool(raw)
+	if err != nil {
+		return fmt.Errorf(`invalid "allowNaN" tag value %q: %s`, raw, err)
+	}
+	if !allow {
+		return nil
+	}
+	if t != "number" {
+		return fmt.Errorf(`"allowNaN" tag on a %s property`, t)
+	}
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[AllowNaNExtension] = true
+
+	// the bounds of FiniteFloats exclude infinite values, those set by tags remain
+	for _, bound := range floatBounds {
+		if p.Minimum != nil && *p.Minimum == -bound {
+			p.Minimum = nil
+		}
+		if p.Maximum != nil && *p.Maximum == bound {
+			p.Maximum = nil
+		}
+	}
+	return nil
+}
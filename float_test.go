@@ -0,0 +1,92 @@
+package jsonschema
+
+import (
+	"math"
+
+	. "gopkg.in/check.v1"
+)
+
+type floatSuite struct{}
+
+var _ = Suite(&floatSuite{})
+
+type ExampleJSONMeasurement struct {
+	Value    float64  `json:"value"`
+	Ratio    float32  `json:"ratio" min:"0"`
+	Raw      float64  `json:"raw" allowNaN:"true"`
+	Bounded  float64  `json:"bounded" allowNaN:"true" max:"10"`
+	Optional *float64 `json:"optional"`
+}
+
+func (self *floatSuite) TestFiniteFloats(c *C) {
+	j := NewGenerator(Options{FiniteFloats: true}).WithRoot(&ExampleJSONMeasurement{}).MustGenerate()
+
+	c.Assert(j.Properties["value"], DeepEquals, &Property{Type: "number",
+		Minimum: float64ptr(-math.MaxFloat64), Maximum: float64ptr(math.MaxFloat64)})
+	c.Assert(j.Properties["ratio"], DeepEquals, &Property{Type: "number",
+		Minimum: float64ptr(0), Maximum: float64ptr(math.MaxFloat32)})
+	c.Assert(j.Properties["raw"], DeepEquals, &Property{Type: "number",
+		Extensions: map[string]interface{}{AllowNaNExtension: true}})
+	c.Assert(j.Properties["bounded"], DeepEquals, &Property{Type: "number", Maximum: float64ptr(10),
+		Extensions: map[string]interface{}{AllowNaNExtension: true}})
+	c.Assert(j.Properties["optional"].Maximum, DeepEquals, float64ptr(math.MaxFloat64))
+
+	c.Assert(j.Properties["raw"].AllowsNaN(), Equals, true)
+	c.Assert(j.Properties["value"].AllowsNaN(), Equals, false)
+}
+
+func (self *floatSuite) TestFiniteFloatsDisabled(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONMeasurement{}).MustGenerate()
+
+	c.Assert(j.Properties["value"], DeepEquals, &Property{Type: "number"})
+	c.Assert(j.Properties["raw"].AllowsNaN(), Equals, true)
+}
+
+type ExampleJSONInvalidNaN struct {
+	Name string `json:"name" allowNaN:"true"`
+}
+
+func (self *floatSuite) TestAllowNaNOnString(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONInvalidNaN{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Name:"allowNaN" tag on a string property`)
+}
+
+func (self *floatSuite) TestValidateNaN(c *C) {
+	j := NewGenerator(Options{FiniteFloats: true}).WithRoot(&ExampleJSONMeasurement{}).MustGenerate()
+	v, err := NewValidator(j)
+	c.Assert(err, IsNil)
+
+	errs, err := v.Validate([]byte(`{"value": 1, "raw": NaN, "bounded": -Infinity, "optional": 1, "text": "NaN Infinity"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	errs, err = v.Validate([]byte(`{"value": NaN, "ratio": Infinity, "bounded": Infinity, "optional": -Infinity}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{
+		{InstancePath: "/bounded", SchemaPath: "/properties/bounded/maximum", Keyword: "maximum", Message: "must be less than or equal to 10"},
+		{InstancePath: "/optional", SchemaPath: "/properties/optional/x-allow-nan", Keyword: "x-allow-nan", Message: "must be a finite number, not -Infinity"},
+		{InstancePath: "/ratio", SchemaPath: "/properties/ratio/x-allow-nan", Keyword: "x-allow-nan", Message: "must be a finite number, not Infinity"},
+		{InstancePath: "/value", SchemaPath: "/properties/value/x-allow-nan", Keyword: "x-allow-nan", Message: "must be a finite number, not NaN"},
+	})
+
+	// the literals standing for the tokens while decoding are not mistaken for them
+	errs, err = v.Validate([]byte(`{"value": 0E-01, "ratio": 0E-02}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	_, err = v.Validate([]byte(`{"value": Nope}`))
+	c.Assert(err, ErrorMatches, "invalid JSON document: .*")
+}
+
+type ExampleJSONNullableNaN struct {
+	Reading *float64 `json:"reading" allowNaN:"true"`
+}
+
+func (self *floatSuite) TestValidateNullableNaN(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONNullableNaN{}).MustGenerate()
+	c.Assert(j.Properties["reading"].AllowsNaN(), Equals, true)
+
+	errs, err := j.Validate([]byte(`{"reading": NaN}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+}
@@ -0,0 +1,171 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CoercionError is a form value which couldn't be coerced to the type of its property.
+type CoercionError struct {
+	// Field is the key of the value in the form.
+	Field   string
+	Value   string
+	Message string
+}
+
+func (e CoercionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// CoerceForm converts an HTML form submission to a JSON document typed per
+// the schema, to be validated and unmarshaled like the bodies of JSON requests:
+// booleans accept true/false, on/off, yes/no and 1/0, repeated keys make
+// arrays and dotted keys, e.g. "address.street", nested objects. Empty values
+// of properties which aren't strings are left out, or null if the property
+// is nullable. Keys the schema doesn't describe are kept as strings. The values
+// which couldn't be coerced are left out of the document and reported.
+func CoerceForm(schema *JSONSchema, form url.Values) ([]byte, []CoercionError) {
+	c := &formCoercer{schema: schema}
+	doc := map[string]interface{}{}
+
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.set(doc, key, form[key])
+	}
+
+	b, _ := json.Marshal(doc)
+	return b, c.errors
+}
+
+// CoerceFormMap is CoerceForm for forms holding a single value per key.
+func CoerceFormMap(schema *JSONSchema, form map[string]string) ([]byte, []CoercionError) {
+	values := make(url.Values, len(form))
+	for key, value := range form {
+		values.Set(key, value)
+	}
+	return CoerceForm(schema, values)
+}
+
+type formCoercer struct {
+	schema *JSONSchema
+	errors []CoercionError
+}
+
+func (c *formCoercer) fail(key, value, format string, args ...interface{}) {
+	c.errors = append(c.errors, CoercionError{Field: key, Value: value, Message: fmt.Sprintf(format, args...)})
+}
+
+// set coerces the values of the form key and sets them in the document.
+func (c *formCoercer) set(doc map[string]interface{}, key string, values []string) {
+	names := strings.Split(key, ".")
+	object := doc
+	p, _ := c.valueSchema(&c.schema.Property)
+	for i, name := range names {
+		var child *Property
+		nullable := false
+		if p != nil {
+			if s, ok := propertySchema(p, name); ok {
+				child, nullable = c.valueSchema(s)
+			}
+		}
+		if i == len(names)-1 {
+			if v, ok := c.coerce(key, child, nullable, values); ok {
+				object[name] = v
+			}
+			return
+		}
+
+		nested, ok := object[name].(map[string]interface{})
+		if !ok {
+			if _, exists := object[name]; exists {
+				c.fail(key, strings.Join(values, ","), "%s is not an object", strings.Join(names[:i+1], "."))
+				return
+			}
+			nested = map[string]interface{}{}
+			object[name] = nested
+		}
+		object, p = nested, child
+	}
+}
+
+// coerce returns the value of the property described by p with the form values.
+func (c *formCoercer) coerce(key string, p *Property, nullable bool, values []string) (interface{}, bool) {
+	if p != nil && p.Type == "array" {
+		items, itemsNullable := c.valueSchema(p.Items)
+		array := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			if v, ok := c.coerceValue(key, items, itemsNullable, value); ok {
+				array = append(array, v)
+			}
+		}
+		return array, true
+	}
+
+	switch {
+	case len(values) == 0:
+		return nil, false
+	case len(values) > 1:
+		c.fail(key, values[0], "only one value is accepted, got %d", len(values))
+		return nil, false
+	}
+	return c.coerceValue(key, p, nullable, values[0])
+}
+
+func (c *formCoercer) coerceValue(key string, p *Property, nullable bool, value string) (interface{}, bool) {
+	if p == nil || p.Type == "" || p.Type == "string" {
+		return value, true
+	}
+	if value == "" {
+		if nullable {
+			return nil, true
+		}
+		return nil, false
+	}
+
+	switch p.Type {
+	case "boolean":
+		switch strings.ToLower(value) {
+		case "true", "on", "yes", "1":
+			return true, true
+		case "false", "off", "no", "0":
+			return false, true
+		}
+		c.fail(key, value, "%q is not a boolean", value)
+	case "integer":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return i, true
+		}
+		c.fail(key, value, "%q is not an integer", value)
+	case "number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return f, true
+		}
+		c.fail(key, value, "%q is not a number", value)
+	default:
+		c.fail(key, value, "%s values can't be submitted as form values", p.Type)
+	}
+	return nil, false
+}
+
+// valueSchema follows the $refs of p, and the value branch of the anyOf
+// emitted for pointers, and reports whether the value may be null.
+func (c *formCoercer) valueSchema(p *Property) (*Property, bool) {
+	if p == nil {
+		return nil, false
+	}
+	p = c.schema.resolve(p)
+	if branch, ok := nullableBranch(p.AnyOf); ok && p.Type == "" {
+		return c.schema.resolve(p.AnyOf[branch]), true
+	}
+	return p, containsString(p.Types, "null")
+}
@@ -0,0 +1,83 @@
+package jsonschema
+
+import (
+	"net/url"
+
+	. "gopkg.in/check.v1"
+)
+
+type formSuite struct{}
+
+var _ = Suite(&formSuite{})
+
+type ExampleJSONFormAddress struct {
+	Street string `json:"street"`
+	Floor  int    `json:"floor"`
+}
+
+type ExampleJSONForm struct {
+	Name     string                  `json:"name"`
+	Age      int                     `json:"age"`
+	Score    float64                 `json:"score"`
+	Optin    bool                    `json:"optin"`
+	Nickname *string                 `json:"nickname"`
+	Tags     []string                `json:"tags"`
+	Ratings  []int                   `json:"ratings"`
+	Address  ExampleJSONFormAddress  `json:"address"`
+	Previous *ExampleJSONFormAddress `json:"previous"`
+}
+
+func (self *formSuite) TestCoerceForm(c *C) {
+	js := NewGenerator().WithDefinition("address", ExampleJSONFormAddress{}).WithRoot(&ExampleJSONForm{}).MustGenerate()
+
+	doc, errs := CoerceForm(js, url.Values{
+		"name":           {"Ann"},
+		"age":            {"42"},
+		"score":          {"9.5"},
+		"optin":          {"on"},
+		"nickname":       {""},
+		"tags":           {"a", "b"},
+		"ratings":        {"1", "2"},
+		"address.street": {"Main"},
+		"address.floor":  {"3"},
+		"previous.floor": {""},
+		"extra":          {"x"},
+	})
+	c.Assert(errs, HasLen, 0)
+	c.Assert(string(doc), Equals, `{"address":{"floor":3,"street":"Main"},"age":42,"extra":"x",`+
+		`"name":"Ann","nickname":"","optin":true,"previous":{},"ratings":[1,2],"score":9.5,"tags":["a","b"]}`)
+
+	errs2, err := js.Validate(doc)
+	c.Assert(err, IsNil)
+	c.Assert(errs2, HasLen, 0)
+}
+
+func (self *formSuite) TestCoerceFormErrors(c *C) {
+	js := NewGenerator().WithRoot(&ExampleJSONForm{}).MustGenerate()
+
+	doc, errs := CoerceForm(js, url.Values{
+		"age":        {"forty"},
+		"score":      {"1", "2"},
+		"optin":      {"maybe"},
+		"ratings":    {"1", "x"},
+		"address":    {"Main street"},
+		"name.first": {"Ann"},
+		"nickname":   {"Annie"},
+	})
+	c.Assert(string(doc), Equals, `{"name":{"first":"Ann"},"nickname":"Annie","ratings":[1]}`)
+	c.Assert(errs, DeepEquals, []CoercionError{
+		{Field: "address", Value: "Main street", Message: "object values can't be submitted as form values"},
+		{Field: "age", Value: "forty", Message: `"forty" is not an integer`},
+		{Field: "optin", Value: "maybe", Message: `"maybe" is not a boolean`},
+		{Field: "ratings", Value: "x", Message: `"x" is not an integer`},
+		{Field: "score", Value: "1", Message: "only one value is accepted, got 2"},
+	})
+	c.Assert(errs[1].Error(), Equals, `age: "forty" is not an integer`)
+}
+
+func (self *formSuite) TestCoerceFormMap(c *C) {
+	js := NewGenerator().WithRoot(&ExampleJSONForm{}).MustGenerate()
+	doc, errs := CoerceFormMap(js, map[string]string{"age": "7", "optin": "0", "score": ""})
+	c.Assert(errs, HasLen, 0)
+	c.Assert(string(doc), Equals, `{"age":7,"optin":false}`)
+}
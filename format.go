@@ -0,0 +1,76 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// FormatOptions controls the serialization of schemas by Format.
+type FormatOptions struct {
+	// Prefix and Indent indent the output as json.MarshalIndent does. The
+	// output is on a single line when both are empty.
+	Prefix string
+	Indent string
+	// SortKeys emits the keys of every object in lexical order, rather than
+	// the keywords of schemas in their conventional order.
+	SortKeys bool
+}
+
+// Format serializes the schema as set by the options.
+func (d *JSONSchema) Format(options FormatOptions) ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	if options.SortKeys {
+		doc, err := decodeInstance(b)
+		if err != nil {
+			return nil, err
+		}
+		// maps are marshaled with sorted keys
+		if b, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+	if options.Prefix == "" && options.Indent == "" {
+		return b, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, options.Prefix, options.Indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Indent serializes the schema indented as json.MarshalIndent does, with its
+// keywords in their conventional order.
+func (d *JSONSchema) Indent(prefix, indent string) ([]byte, error) {
+	return d.Format(FormatOptions{Prefix: prefix, Indent: indent})
+}
+
+// Canonical serializes the schema in its canonical form, so that equal
+// schemas are byte-identical, e.g. to hash them: on a single line, with the
+// keys of objects in lexical order, without escaping HTML characters and with
+// numbers in their shortest form, as CanonicalizeInstance does for documents.
+func (d *JSONSchema) Canonical() ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalJSON(b)
+}
+
+// canonicalJSON returns the canonical form of the JSON document b, with the
+// keys of all its objects sorted.
+func canonicalJSON(b []byte) ([]byte, error) {
+	doc, err := decodeInstance(b)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	// without schema, the keys of all the objects are sorted
+	if err := (&JSONSchema{}).writeCanonical(&buf, nil, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
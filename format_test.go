@@ -0,0 +1,113 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type formatSuite struct{}
+
+var _ = Suite(&formatSuite{})
+
+type ExampleFormatItem struct {
+	Name  string  `json:"name" description:"A <b>name</b>." required:"true"`
+	Price float64 `json:"price" max:"1e21"`
+}
+
+func (self *formatSuite) TestFormat(c *C) {
+	j := NewGenerator(Options{Schema: Draft07Schema}).WithRoot(&ExampleFormatItem{}).MustGenerate()
+
+	compact, err := j.Format(FormatOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(string(compact), Equals, `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object",`+
+		`"properties":{"name":{"type":"string","description":"A \u003cb\u003ename\u003c/b\u003e."},"price":{"type":"number","maximum":1e+21}},`+
+		`"required":["name"]}`)
+
+	sorted, err := j.Format(FormatOptions{Indent: "\t", SortKeys: true})
+	c.Assert(err, IsNil)
+	c.Assert(string(sorted), Equals, `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"properties": {
+		"name": {
+			"description": "A \u003cb\u003ename\u003c/b\u003e.",
+			"type": "string"
+		},
+		"price": {
+			"maximum": 1e+21,
+			"type": "number"
+		}
+	},
+	"required": [
+		"name"
+	],
+	"type": "object"
+}`)
+
+	indented, err := j.Indent("", "  ")
+	c.Assert(err, IsNil)
+	c.Assert(string(indented), Equals, j.String())
+	expected, _ := json.MarshalIndent(j, "", "  ")
+	c.Assert(string(indented), Equals, string(expected))
+}
+
+func (self *formatSuite) TestCanonical(c *C) {
+	j := NewGenerator(Options{Schema: Draft07Schema}).WithRoot(&ExampleFormatItem{}).MustGenerate()
+
+	canonical, err := j.Canonical()
+	c.Assert(err, IsNil)
+	c.Assert(string(canonical), Equals, `{"$schema":"http://json-schema.org/draft-07/schema#",`+
+		`"properties":{"name":{"description":"A <b>name</b>.","type":"string"},"price":{"maximum":1e+21,"type":"number"}},`+
+		`"required":["name"],"type":"object"}`)
+
+	var parsed JSONSchema
+	c.Assert(json.Unmarshal(canonical, &parsed), IsNil)
+	again, err := parsed.Canonical()
+	c.Assert(err, IsNil)
+	c.Assert(string(again), Equals, string(canonical))
+}
+
+type ExampleFormatCatalog struct {
+	Items  map[string]ExampleFormatItem `json:"items"`
+	Ratio  float64                      `json:"ratio" min:"0.1" max:"100.0"`
+	Colors []string                     `json:"colors" enum:"red|green|blue"`
+}
+
+func (self *formatSuite) TestReproducible(c *C) {
+	generate := func() *JSONSchema {
+		return NewGenerator(Options{
+			Schema:       Draft07Schema,
+			Reproducible: true,
+			Metadata:     &BuildMetadata{GeneratedBy: "catalog", Version: "v1.0.0", Timestamp: true},
+		}).
+			WithDefinition("item", ExampleFormatItem{}).
+			WithRoot(&ExampleFormatCatalog{}).
+			MustGenerate()
+	}
+	j := generate()
+
+	b, err := j.MarshalJSON()
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"$schema":"http://json-schema.org/draft-07/schema#",`+
+		`"definitions":{"item":{"properties":{"name":{"description":"A <b>name</b>.","type":"string"},`+
+		`"price":{"maximum":1e+21,"type":"number"}},"required":["name"],"type":"object"}},`+
+		`"properties":{"colors":{"items":{"type":"string"},"type":"array"},`+
+		`"items":{"additionalProperties":{"$ref":"#/definitions/item"},"type":"object"},`+
+		`"ratio":{"maximum":100,"minimum":0.1,"type":"number"}},`+
+		`"type":"object","x-generated-by":"catalog","x-generator-version":"v1.0.0"}`)
+
+	for i := 0; i < 10; i++ {
+		c.Assert(generate().String(), Equals, j.String())
+	}
+
+	set, err := NewGenerator(Options{Reproducible: true}).
+		WithDefinition("item", ExampleFormatItem{}).
+		GenerateSet(map[string]interface{}{"catalog": &ExampleFormatCatalog{}})
+	c.Assert(err, IsNil)
+	root, _ := set.Root("catalog")
+	canonical, err := root.Canonical()
+	c.Assert(err, IsNil)
+	b, err = root.MarshalJSON()
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, string(canonical))
+}
@@ -0,0 +1,45 @@
+package jsonschema
+
+import (
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	uuidRegex  = regexp.MustCompile(PatternUUID)
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// formatCheckers check the strings of the formats asserted by validators
+// created with WithFormatAssertion. Other formats are annotations.
+var formatCheckers = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(s))
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05.999999999Z07:00", strings.ToUpper(s))
+		return err == nil
+	},
+	"email": emailRegex.MatchString,
+	"uuid":  uuidRegex.MatchString,
+	"ipv4": func(s string) bool {
+		addr, err := netip.ParseAddr(s)
+		return err == nil && addr.Is4()
+	},
+	"ipv6": func(s string) bool {
+		addr, err := netip.ParseAddr(s)
+		return err == nil && addr.Is6()
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+}
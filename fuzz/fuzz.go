@@ -0,0 +1,390 @@
+// Package fuzz helps writing structure-aware fuzz tests of JSON APIs described
+// by generated schemas, with Go's native fuzzing:
+//
+//	func FuzzCreateOrder(f *testing.F) {
+//		js := jsonschema.NewGenerator().WithRoot(&Order{}).MustGenerate()
+//		fuzz.AddSeeds(f, js)
+//		f.Fuzz(func(t *testing.T, data []byte, seed int64) {
+//			body := fuzz.Mutate(js, data, seed)
+//			...
+//		})
+//	}
+package fuzz
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveego/go-json-schema"
+)
+
+// maxDepth bounds the nesting of generated documents, for recursive schemas.
+// Beyond it only required properties and empty arrays are generated.
+const maxDepth = 8
+
+// Seeds returns a seed corpus of documents valid under the schema: one with
+// only the required properties, one with every property, and a few random ones.
+func Seeds(js *jsonschema.JSONSchema) [][]byte {
+	seeds := [][]byte{
+		encode(newGenerator(js, 0, minimal).value(&js.Property, 0)),
+		encode(newGenerator(js, 0, full).value(&js.Property, 0)),
+	}
+	for seed := int64(1); seed <= 3; seed++ {
+		seeds = append(seeds, encode(newGenerator(js, seed, random).value(&js.Property, 0)))
+	}
+	return seeds
+}
+
+// AddSeeds adds the seeds of the schema to the corpus of f, as (data []byte, seed int64)
+// arguments of the fuzz target.
+func AddSeeds(f *testing.F, js *jsonschema.JSONSchema) {
+	for i, seed := range Seeds(js) {
+		f.Add(seed, int64(i))
+	}
+}
+
+// Mutate returns a document derived from data: one value of data, chosen by
+// seed, is replaced with a new value valid under its schema, so documents
+// valid under the schema stay valid. When data is not a JSON document, a new
+// document is generated from seed instead.
+func Mutate(js *jsonschema.JSONSchema, data []byte, seed int64) []byte {
+	g := newGenerator(js, seed, random)
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return encode(g.value(&js.Property, 0))
+	}
+
+	var candidates []candidate
+	g.collect(&js.Property, doc, 0, func(v interface{}) { doc = v }, &candidates)
+	c := candidates[g.rand.Intn(len(candidates))]
+	c.set(g.value(c.schema, c.depth))
+	return encode(doc)
+}
+
+func encode(v interface{}) []byte {
+	b, _ := json.Marshal(v)
+	return b
+}
+
+type mode int
+
+const (
+	random mode = iota
+	minimal
+	full
+)
+
+type generator struct {
+	js   *jsonschema.JSONSchema
+	rand *rand.Rand
+	mode mode
+}
+
+func newGenerator(js *jsonschema.JSONSchema, seed int64, m mode) *generator {
+	return &generator{js: js, rand: rand.New(rand.NewSource(seed)), mode: m}
+}
+
+// resolve follows the reference of p to a definition of the schema.
+func (g *generator) resolve(p *jsonschema.Property) *jsonschema.Property {
+	for p != nil && p.Ref != "" {
+		d, ok := g.js.Definitions[strings.TrimPrefix(p.Ref, "#/definitions/")]
+		if !ok {
+			return p
+		}
+		p = &d
+	}
+	return p
+}
+
+// value returns a value valid under p.
+func (g *generator) value(p *jsonschema.Property, depth int) interface{} {
+	p = g.resolve(p)
+	if p == nil {
+		return nil
+	}
+	if p.Const != nil {
+		return p.Const
+	}
+	if branches := append(append([]*jsonschema.Property{}, p.AnyOf...), p.OneOf...); p.Type == "" && len(branches) > 0 {
+		return g.value(branches[g.intn(len(branches))], depth)
+	}
+
+	switch p.Type {
+	case "null":
+		return nil
+	case "boolean":
+		return g.intn(2) == 1
+	case "integer":
+		return g.integer(p)
+	case "number":
+		return g.number(p)
+	case "string":
+		return g.string(p)
+	case "array":
+		n := 0
+		if depth < maxDepth && p.Items != nil {
+			switch g.mode {
+			case full:
+				n = 1
+			case random:
+				n = g.rand.Intn(4)
+			}
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			items[i] = g.value(p.Items, depth+1)
+		}
+		return items
+	case "object":
+		return g.object(p, depth)
+	}
+	// any value
+	return nil
+}
+
+// intn returns 0 for the seeds generated deterministically.
+func (g *generator) intn(n int) int {
+	if g.mode != random {
+		return 0
+	}
+	return g.rand.Intn(n)
+}
+
+func (g *generator) object(p *jsonschema.Property, depth int) interface{} {
+	obj := map[string]interface{}{}
+	names := make([]string, 0, len(p.Properties))
+	for name := range p.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		required := false
+		for _, r := range p.Required {
+			required = required || r == name
+		}
+		include := required
+		if !required && depth < maxDepth {
+			switch g.mode {
+			case full:
+				include = true
+			case random:
+				include = g.rand.Intn(2) == 1
+			}
+		}
+		if !include {
+			continue
+		}
+		if name == ".*" {
+			// properties of a map
+			obj["key"+string(rune('a'+g.intn(26)))] = g.value(p.Properties[name], depth+1)
+			continue
+		}
+		obj[name] = g.value(p.Properties[name], depth+1)
+	}
+	if p.AdditionalPropertiesSchema != nil && depth < maxDepth && (g.mode == full || g.mode == random && g.rand.Intn(2) == 1) {
+		obj["key"+string(rune('a'+g.intn(26)))] = g.value(p.AdditionalPropertiesSchema, depth+1)
+	}
+	return obj
+}
+
+// bounds returns the inclusive range of values allowed by p, within [-limit, limit].
+func bounds(p *jsonschema.Property, limit, step float64) (float64, float64) {
+	lower, upper := -limit, limit
+	if p.Minimum != nil {
+		lower = math.Max(lower, *p.Minimum)
+	}
+	if p.ExclusiveMinimum != nil {
+		lower = math.Max(lower, *p.ExclusiveMinimum+step)
+	}
+	if p.Maximum != nil {
+		upper = math.Min(upper, *p.Maximum)
+	}
+	if p.ExclusiveMaximum != nil {
+		upper = math.Min(upper, *p.ExclusiveMaximum-step)
+	}
+	if upper < lower {
+		upper = lower
+	}
+	return lower, upper
+}
+
+func (g *generator) integer(p *jsonschema.Property) interface{} {
+	lower, upper := bounds(p, 1<<31, 1)
+	lower, upper = math.Ceil(lower), math.Floor(upper)
+	step := 1.0
+	if p.MultipleOf != nil && *p.MultipleOf >= 1 {
+		step = *p.MultipleOf
+		lower = math.Ceil(lower/step) * step
+	}
+	n := lower
+	if g.mode == random && upper > lower {
+		n = lower + math.Floor(g.rand.Float64()*((upper-lower)/step+1))*step
+		if n > upper {
+			n = lower
+		}
+	}
+	return int64(n)
+}
+
+func (g *generator) number(p *jsonschema.Property) interface{} {
+	lower, upper := bounds(p, 1e9, 1e-6)
+	if p.MultipleOf != nil {
+		step := *p.MultipleOf
+		n := math.Ceil(lower/step) * step
+		if g.mode == random {
+			n += math.Floor(g.rand.Float64()*((upper-n)/step+1)) * step
+		}
+		return n
+	}
+	if g.mode != random {
+		return lower
+	}
+	return lower + g.rand.Float64()*(upper-lower)
+}
+
+const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_"
+
+func (g *generator) string(p *jsonschema.Property) interface{} {
+	if len(p.Enum) > 0 {
+		return p.Enum[g.intn(len(p.Enum))]
+	}
+	if p.Pattern != "" {
+		if re, err := syntax.Parse(p.Pattern, syntax.Perl); err == nil {
+			b := &strings.Builder{}
+			g.match(b, re.Simplify())
+			return b.String()
+		}
+	}
+	if p.Format == "date-time" {
+		t := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		if g.mode == random {
+			t = t.Add(time.Duration(g.rand.Int63n(int64(50 * 365 * 24 * time.Hour))))
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	min, max := int64(0), int64(16)
+	if p.MinLength != nil {
+		min = *p.MinLength
+	}
+	if p.MaxLength != nil {
+		max = *p.MaxLength
+	} else if max < min {
+		max = min + 16
+	}
+	n := min
+	switch {
+	case g.mode == full && max > min:
+		n = min + 1
+	case g.mode == random && max > min:
+		n = min + g.rand.Int63n(max-min+1)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[g.intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// match writes a string matched by the regular expression re to b.
+func (g *generator) match(b *strings.Builder, re *syntax.Regexp) {
+	repeat := func(min, max int) {
+		if max < 0 {
+			max = min + 3
+		}
+		n := min
+		if max > min {
+			n += g.intn(max - min + 1)
+		}
+		for i := 0; i < n; i++ {
+			g.match(b, re.Sub[0])
+		}
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) >= 2 {
+			i := g.intn(len(re.Rune)/2) * 2
+			lo, hi := re.Rune[i], re.Rune[i+1]
+			b.WriteRune(lo + rune(g.intn(int(hi-lo)+1)))
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(alphabet[g.intn(len(alphabet))])
+	case syntax.OpCapture:
+		g.match(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.match(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.match(b, re.Sub[g.intn(len(re.Sub))])
+	case syntax.OpStar:
+		repeat(0, -1)
+	case syntax.OpPlus:
+		repeat(1, -1)
+	case syntax.OpQuest:
+		repeat(0, 1)
+	case syntax.OpRepeat:
+		repeat(re.Min, re.Max)
+	}
+}
+
+// candidate is a value of a document which Mutate may replace.
+type candidate struct {
+	schema *jsonschema.Property
+	depth  int
+	set    func(interface{})
+}
+
+// collect lists the values of v described by p which can be replaced.
+func (g *generator) collect(p *jsonschema.Property, v interface{}, depth int, set func(interface{}), candidates *[]candidate) {
+	p = g.resolve(p)
+	if p == nil {
+		return
+	}
+	*candidates = append(*candidates, candidate{schema: p, depth: depth, set: set})
+	if p.Type == "" {
+		// the branch v matches is unknown, so only v as a whole is replaced
+		return
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			s, ok := p.Properties[k]
+			if !ok {
+				s, ok = p.Properties[".*"]
+			}
+			if !ok {
+				s, ok = p.AdditionalPropertiesSchema, p.AdditionalPropertiesSchema != nil
+			}
+			if !ok {
+				continue
+			}
+			k := k
+			g.collect(s, t[k], depth+1, func(v interface{}) { t[k] = v }, candidates)
+		}
+	case []interface{}:
+		if p.Items == nil {
+			return
+		}
+		for i := range t {
+			i := i
+			g.collect(p.Items, t[i], depth+1, func(v interface{}) { t[i] = v }, candidates)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/naveego/go-json-schema"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type fuzzSuite struct{}
+
+var _ = Suite(&fuzzSuite{})
+
+type ExampleFuzzLine struct {
+	SKU      string `json:"sku" pattern:"^[A-Z]{3}-\\d{4}$" required:"true"`
+	Quantity int    `json:"quantity" min:"1" max:"10" required:"true"`
+}
+
+type ExampleFuzzOrder struct {
+	ID       string            `json:"id" minLength:"8" maxLength:"8" required:"true"`
+	Status   string            `json:"status" enum:"open|closed" required:"true"`
+	Version  string            `json:"version" const:"v1"`
+	Total    float64           `json:"total" exclusiveMin:"0" max:"100"`
+	Created  time.Time         `json:"created"`
+	Note     *string           `json:"note"`
+	Lines    []ExampleFuzzLine `json:"lines"`
+	Labels   map[string]string `json:"labels"`
+	Previous *ExampleFuzzOrder `json:"previous"`
+}
+
+var orderSchema = jsonschema.NewGenerator().
+	WithRoot(&ExampleFuzzOrder{}).
+	WithDefinition("order", ExampleFuzzOrder{}).
+	MustGenerate()
+
+var sku = regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+
+// checkOrder verifies the constraints of the schema of ExampleFuzzOrder.
+func checkOrder(c *C, data []byte) {
+	var raw map[string]interface{}
+	c.Assert(json.Unmarshal(data, &raw), IsNil, Commentf("%s", data))
+	c.Assert(raw["id"], NotNil)
+	c.Assert(raw["status"], NotNil)
+
+	var order ExampleFuzzOrder
+	c.Assert(json.Unmarshal(data, &order), IsNil, Commentf("%s", data))
+	for o := &order; o != nil; o = o.Previous {
+		c.Check(o.ID, HasLen, 8)
+		c.Check(o.Status == "open" || o.Status == "closed", Equals, true, Commentf("%s", data))
+		c.Check(o.Version == "" || o.Version == "v1", Equals, true)
+		c.Check(o.Total >= 0 && o.Total <= 100, Equals, true, Commentf("%s", data))
+		for _, l := range o.Lines {
+			c.Check(sku.MatchString(l.SKU), Equals, true, Commentf("%s", data))
+			c.Check(l.Quantity >= 1 && l.Quantity <= 10, Equals, true, Commentf("%s", data))
+		}
+	}
+}
+
+func (self *fuzzSuite) TestSeeds(c *C) {
+	seeds := Seeds(orderSchema)
+	c.Assert(seeds, HasLen, 5)
+	for _, seed := range seeds {
+		checkOrder(c, seed)
+	}
+
+	c.Check(string(seeds[0]), Equals, `{"id":"aaaaaaaa","status":"open"}`)
+
+	var full map[string]interface{}
+	c.Assert(json.Unmarshal(seeds[1], &full), IsNil)
+	for _, name := range []string{"id", "status", "version", "total", "created", "note", "lines", "labels", "previous"} {
+		c.Check(full[name], NotNil, Commentf("%s", name))
+	}
+}
+
+func (self *fuzzSuite) TestMutate(c *C) {
+	data := Seeds(orderSchema)[1]
+	for seed := int64(0); seed < 200; seed++ {
+		data = Mutate(orderSchema, data, seed)
+		checkOrder(c, data)
+	}
+}
+
+func (self *fuzzSuite) TestMutateInvalidData(c *C) {
+	data := Mutate(orderSchema, []byte("not json"), 42)
+	checkOrder(c, data)
+	c.Check(string(Mutate(orderSchema, []byte("not json"), 42)), Equals, string(data))
+}
+
+func FuzzMutate(f *testing.F) {
+	AddSeeds(f, orderSchema)
+	f.Fuzz(func(t *testing.T, data []byte, seed int64) {
+		var order ExampleFuzzOrder
+		if json.Unmarshal(data, &order) != nil {
+			return
+		}
+		if err := json.Unmarshal(Mutate(orderSchema, data, seed), &order); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -5,9 +5,14 @@ package jsonschema
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const DEFAULT_SCHEMA = "http://json-schema.org/schema#"
@@ -16,8 +21,15 @@ var rTypeInt64, rTypeFloat64 = reflect.TypeOf(int64(0)), reflect.TypeOf(float64(
 
 type JSONSchema struct {
 	Schema      string              `json:"$schema,omitempty"`
+	ID          string              `json:"$id,omitempty"`
 	Definitions map[string]Property `json:"definitions,omitempty"`
 	Property
+	// knownTypes maps the types registered as definitions to their names
+	knownTypes knownTypes
+	// omitSchema leaves $schema out of the output, Schema still setting the draft
+	omitSchema bool
+	// reproducible serializes the schema in its canonical form
+	reproducible bool
 }
 
 type knownTypes map[reflect.Type]string
@@ -25,22 +37,180 @@ type knownTypes map[reflect.Type]string
 func (k knownTypes) getReference(t reflect.Type) (string, bool) {
 	if k != nil {
 		if name, ok := k[t]; ok {
-			return fmt.Sprintf("#/definitions/%s", name), true
+			return definitionReference(name), true
 		}
 	}
 	return "", false
 }
 
+// DefinitionFor returns the name of the definition generated for the type t.
+func (d *JSONSchema) DefinitionFor(t reflect.Type) (name string, ok bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name, ok = d.knownTypes[t]
+	if _, defined := d.Definitions[name]; !ok || !defined {
+		return "", false
+	}
+	return name, true
+}
+
+// DefinitionTypes returns the types the definitions were generated for, by
+// name. Aliases and definitions not generated from a type are not included.
+func (d *JSONSchema) DefinitionTypes() map[string]reflect.Type {
+	types := make(map[string]reflect.Type, len(d.knownTypes))
+	for t, name := range d.knownTypes {
+		if _, ok := d.Definitions[name]; ok {
+			types[name] = t
+		}
+	}
+	return types
+}
+
+func definitionReference(name string) string {
+	return fmt.Sprintf("#/definitions/%s", name)
+}
+
 type Generator struct {
-	root        interface{}
-	definitions map[string]interface{}
-	options     Options
+	root         interface{}
+	rootUnion    []interface{}
+	definitions  map[string]interface{}
+	aliases      map[string]string
+	deprecations map[string]time.Time
+	snippets     map[string]string
+	presets      map[string]map[string]string
+	typeTags     map[string]map[string]string
+	policies     []Policy
+	options      Options
+	logger       *slog.Logger
+	warnings     []Inconsistency
+	// naming names the definitions of the types hoisted by
+	// WithAutoDefinitions, if set
+	naming DefinitionNaming
 }
 
 type Options struct {
 	Schema string
+	// Draft sets Schema to the meta-schema of a draft, so that the schemas
+	// generated use the keywords of the draft: $defs rather than definitions
+	// since 2019-09, and booleans qualifying minimum and maximum rather than
+	// exclusiveMinimum and exclusiveMaximum bounds in draft-04.
+	Draft Draft
+	// NullableStyle is the representation of the values of pointers to strings.
+	NullableStyle NullableStyle
+	// IntegerFormats emits the int32 or int64 format of integers, as in OpenAPI,
+	// and a minimum of 0 for unsigned integers.
+	IntegerFormats bool
+	// IntegerBounds emits the minimum and maximum of integers holding less
+	// than 64 bits, and a minimum of 0 for unsigned integers, so that values
+	// which would fail to unmarshal are rejected.
+	IntegerBounds bool
+	// FiniteFloats emits the minimum and maximum finite values of floats,
+	// unless the allowNaN tag is set.
+	FiniteFloats bool
+	// BytesFormat is the encoding of byte slices described by the schema, one
+	// of BytesBase64, BytesHex or BytesBinary. By default it is not described.
+	BytesFormat string
+	// HumanizeTitles sets the title of properties without title tag to their
+	// humanized name, e.g. "First Name" for firstName.
+	HumanizeTitles bool
+	// OnlyReferencedDefinitions emits only the definitions transitively
+	// referenced from the root, when there is one, even if more were registered.
+	OnlyReferencedDefinitions bool
+	// TagAliases maps alternative tag names to the names of the tags read by
+	// the generator, e.g. "schema-title" to "title". An alias ending with "*"
+	// maps every tag starting with it, and a "*" in the tag name it maps to
+	// stands for the rest of the alias: {"schema-*": "*"} reads schema-minLength
+	// as minLength. Tags take precedence over their aliases.
+	TagAliases map[string]string
+	// JSONTagName is the name of the tag holding the names and options of
+	// properties, "json" by default, for types marshaled by other libraries,
+	// e.g. "yaml" or "msgpack".
+	JSONTagName string
+	// FallbackTagNames are read in order for fields without the JSONTagName tag.
+	FallbackTagNames []string
+	// LowercaseNames names the properties of fields without name in lower
+	// case rather than after the field.
+	LowercaseNames bool
+	// MarkdownDescriptions sets the markdownDescription of properties without
+	// markdownDescription tag to their description.
+	MarkdownDescriptions bool
+	// OmitSchemaKeyword leaves the $schema keyword out of the schemas
+	// generated, e.g. to embed them in OpenAPI documents or CRDs. The draft
+	// set by Schema still applies.
+	OmitSchemaKeyword bool
+	// MaxEnumSize minifies the enums of more values, e.g. of country codes,
+	// in the style set by LargeEnums. Zero keeps every enum as it is.
+	MaxEnumSize int
+	// LargeEnums is the style in which enums of more than MaxEnumSize
+	// values are minified.
+	LargeEnums LargeEnumStyle
+	// PatternDialect is the dialect of the patterns of the schemas
+	// generated. Patterns which can't be translated to it are reported in
+	// Generator.Warnings, and fail the generation in Strict mode.
+	PatternDialect RegexDialect
+	// JSONAPI describes the structs with a field tagged `jsonapi:"primary,type"`
+	// as JSON:API resource objects, whose attributes and relationships are
+	// the fields tagged `jsonapi:"attr,name"` and `jsonapi:"relation,name"`.
+	JSONAPI bool
+	// UnrollRecursion describes recursive types inline, unrolled to this
+	// depth and ended by a schema of any object, for consumers which don't
+	// support recursive references. By default a recursive type recurses
+	// with a $ref to its definition, and those which aren't definitions are
+	// promoted to definitions named after them, e.g. "node" for Node.
+	UnrollRecursion int
+	// DefineRecursiveTypes promotes the recursive types unrolled to the depth
+	// of UnrollRecursion to definitions, so that they recurse with a $ref
+	// rather than ending with any object.
+	DefineRecursiveTypes bool
+	// TimeEncoding is the representation of times, by default RFC 3339
+	// date-time strings, for types marshaling them as Unix epochs. The
+	// timeEncoding tag sets the representation of the times of a field.
+	TimeEncoding TimeEncoding
+	// RequireUTC requires date-times to be in UTC, suffixed by Z, by a
+	// pattern along with their format, as validators may not assert formats.
+	// The utc tag requires it, or not, for the date-times of a field.
+	RequireUTC bool
+	// Flags is the representation of the bitmasks described by the flags
+	// tag, by default integers listing their flags in the x-flags extension.
+	Flags FlagsStyle
+	// ReferencePrimitives references the definitions of named primitive
+	// types, e.g. `type UserID string`, where they are used, so that shared
+	// scalar types and their patterns or formats are defined once. By
+	// default they are described inline, like unregistered types.
+	ReferencePrimitives bool
+	// Trace receives a line for each decision made while reading the Go
+	// types: the schema chosen for each field, whether mapped from its type
+	// or referenced, the tags applied and those skipped, and the recursive
+	// types cut or unrolled, to explain why a field came out as it did.
+	Trace io.Writer
+	// Metadata stamps the build producing the schemas into their root, as
+	// the x-generated-by, x-generator-version, x-git-commit and, unless
+	// turned off for reproducible builds, x-generated-at extensions.
+	Metadata *BuildMetadata
+	// Reproducible guarantees byte-identical output for the same types
+	// across runs and Go versions, so that schema artifacts can be content
+	// addressed: the schemas are serialized in their canonical form, with
+	// the keys of every object sorted and numbers in their shortest form,
+	// and the time of the generation is never stamped.
+	Reproducible bool
+	// Strict fails the generation of schemas whose tags are inconsistent,
+	// e.g. a minimum greater than the maximum, rather than reporting them
+	// in Generator.Warnings.
+	Strict bool
 }
 
+// NullableStyle is the representation of values which may be null.
+type NullableStyle int
+
+const (
+	// AnyOf emits "anyOf": [{"type": "string"}, {"type": "null"}].
+	AnyOf NullableStyle = iota
+	// TypeArray emits "type": ["string", "null"], for consumers which
+	// don't handle anyOf well.
+	TypeArray
+)
+
 func Generate(root interface{}) string {
 	js, _ := NewGenerator().WithRoot(root).Generate()
 	return js.String()
@@ -51,14 +221,23 @@ func NewGenerator(options ...Options) *Generator {
 	if len(options) > 0 {
 		g.options = options[0]
 	}
+	if schema, ok := draftSchemas[g.options.Draft]; ok {
+		g.options.Schema = schema
+	}
 	if g.options.Schema == "" {
 		g.options.Schema = DEFAULT_SCHEMA
 	}
+	if g.options.JSONTagName == "" {
+		g.options.JSONTagName = "json"
+	}
 	return g
 }
 
+// WithRoot describes the type of r at the root of the schema. r may be an
+// instance of the type or its reflect.Type.
 func (g *Generator) WithRoot(r interface{}) *Generator {
 	g.root = r
+	g.rootUnion = nil
 	return g
 }
 
@@ -69,6 +248,8 @@ func (g *Generator) WithDefinitions(d map[string]interface{}) *Generator {
 	return g
 }
 
+// WithDefinition registers d under name in the definitions of the schema.
+// d may be an instance of the type or its reflect.Type.
 func (g *Generator) WithDefinition(name string, d interface{}) *Generator {
 	if g.definitions == nil {
 		g.definitions = map[string]interface{}{}
@@ -77,6 +258,23 @@ func (g *Generator) WithDefinition(name string, d interface{}) *Generator {
 	return g
 }
 
+// WithDefinitionAlias registers alias as an additional name for the canonical definition.
+// The alias is emitted as a definition containing only a $ref to the canonical one,
+// so consumers of the old name keep working after a rename.
+func (g *Generator) WithDefinitionAlias(alias, canonical string) *Generator {
+	if g.aliases == nil {
+		g.aliases = map[string]string{}
+	}
+	g.aliases[alias] = canonical
+	return g
+}
+
+// WithDefinitionType registers the type t under name in the definitions of the schema,
+// without requiring an instance of the type.
+func (g *Generator) WithDefinitionType(name string, t reflect.Type) *Generator {
+	return g.WithDefinition(name, t)
+}
+
 func (g *Generator) MustGenerate() *JSONSchema {
 	js, err := g.Generate()
 	if err != nil {
@@ -85,51 +283,259 @@ func (g *Generator) MustGenerate() *JSONSchema {
 	return js
 }
 
-// Generate generates a schema for the provided interface.
+// Generate generates a schema for the provided interface. The schema is the
+// caller's to modify, and may share the values of examples, defaults and
+// extensions with the types described; GenerateSchema returns an immutable
+// copy.
 func (g *Generator) Generate() (*JSONSchema, error) {
-	var err error
-	d := &JSONSchema{
-		Schema: g.options.Schema,
+	d, err := g.generate()
+	if err != nil {
+		return nil, err
+	}
+	logWarnings(g.logger, g.warnings)
+	return d, nil
+}
+
+// GenerateModel reads the Go types of the generator into a Model, as Generate
+// does before serializing it as the keywords of the draft and the
+// NullableStyle of the options. The policies, patterns and enums of the
+// options are only applied to the schema generated.
+func (g *Generator) GenerateModel() (*Model, error) {
+	m, _, err := g.read()
+	return m, err
+}
+
+// generate generates the schema, without logging its warnings.
+func (g *Generator) generate() (*JSONSchema, error) {
+	m, r, err := g.read()
+	if err != nil {
+		return nil, err
+	}
+	d, warnings, err := buildDocument(m, r, g.policies, g.root != nil || len(g.rootUnion) > 0)
+	g.warnings = warnings
+	return d, err
+}
+
+// read reads the Go types of the generator into a Model, returning the
+// reader holding the types registered as definitions.
+func (g *Generator) read() (*Model, *reader, error) {
+	if err := g.options.checkDraft(); err != nil {
+		return nil, nil, err
+	}
+	r := &reader{options: g.options, snippets: g.snippets, presets: g.presets, typeTags: g.typeTags, logger: g.logger, visiting: map[interface{}]int{}, definitionNames: map[string]bool{}}
+	if g.naming != nil {
+		r.naming = func(t goType) string { return g.naming(t.(reflectType).Type) }
 	}
 
+	ts := typeSet{aliases: g.aliases, deprecations: g.deprecations}
 	if g.definitions != nil {
-		d.knownTypes = make(map[reflect.Type]string)
-		d.Definitions = make(map[string]Property)
-
-		for name, instance := range g.definitions {
-			value := reflect.ValueOf(instance)
-			defType := value.Type()
-			if defType.Kind() == reflect.Ptr {
-				defType = defType.Elem()
+		ts.definitions = make(map[string]goType, len(g.definitions))
+		for name, d := range g.definitions {
+			ts.definitions[name] = reflectType{instanceType(d)}
+		}
+	}
+	if g.root != nil {
+		ts.root = reflectType{instanceType(g.root)}
+	}
+	for _, root := range g.rootUnion {
+		t := linkType(root)
+		if t == nil {
+			return nil, nil, fmt.Errorf("nil in the root union")
+		}
+		ts.rootUnion = append(ts.rootUnion, reflectType{t})
+	}
+	m, err := r.readModel(ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, r, nil
+}
+
+// instanceType returns the type of v, an instance of the type or its
+// reflect.Type.
+func instanceType(v interface{}) reflect.Type {
+	if t, ok := v.(reflect.Type); ok {
+		return t
+	}
+	return reflect.ValueOf(v).Type()
+}
+
+// linkType returns the type of the values v stands for, an instance of the
+// type, a pointer to one, or its reflect.Type, nil for a nil v.
+func linkType(v interface{}) reflect.Type {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// typeSet holds the types a generator describes, read by reflection or from
+// source.
+type typeSet struct {
+	root        goType
+	rootUnion   []goType
+	definitions map[string]goType
+	// aliases maps the aliases of definitions to their canonical names
+	aliases map[string]string
+	// deprecations maps the deprecated definitions to their sunset dates
+	deprecations map[string]time.Time
+}
+
+// readModel reads the types of ts into a Model.
+func (r *reader) readModel(ts typeSet) (*Model, error) {
+	m := &Model{Root: newNode()}
+	definitions, err := unionDefinitions(ts.definitions, ts.rootUnion)
+	if err != nil {
+		return nil, err
+	}
+	for name := range definitions {
+		r.definitionNames[name] = true
+	}
+	for alias := range ts.aliases {
+		r.definitionNames[alias] = true
+	}
+
+	aliases := map[string]string{}
+	for alias, canonical := range ts.aliases {
+		if _, ok := definitions[canonical]; !ok {
+			return nil, fmt.Errorf("alias %s refers to unknown definition %s", alias, canonical)
+		}
+		if _, ok := definitions[alias]; ok {
+			return nil, fmt.Errorf("alias %s conflicts with a definition of the same name", alias)
+		}
+		aliases[alias] = canonical
+	}
+
+	// definitions are read in sorted order, so that traces are stable
+	var defNames []string
+	defTypes := map[string]goType{}
+	if definitions != nil {
+		if r.knownTypes == nil {
+			r.knownTypes = make(map[interface{}]string)
+		}
+		m.Definitions = make(map[string]*Node)
+
+		// names are visited in sorted order so that when a type is registered
+		// more than once, the lexically first name is the canonical one and
+		// the others become aliases of it.
+		names := make([]string, 0, len(definitions))
+		for name := range definitions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			defType := pointedType(definitions[name])
+			if canonical, ok := r.knownTypes[defType.Key()]; ok {
+				aliases[name] = canonical
+				continue
 			}
-			d.knownTypes[defType] = name
+			r.knownTypes[defType.Key()] = name
+			defTypes[name] = defType
+			defNames = append(defNames, name)
 		}
 	}
 
-	for defType, name := range d.knownTypes {
-		p := d.child()
-		p.isDefinition = true
-		err = p.read(defType)
-		if err != nil {
+	for _, name := range defNames {
+		defType := defTypes[name]
+		r.tracef("definition %s: %s", name, defType)
+		n := newNode()
+		if err := r.readDefinition(n, defType); err != nil {
 			return nil, fmt.Errorf("error on type %s (%s): %s", defType, name, err)
 		}
-		d.Definitions[name] = *p
+		m.Definitions[name] = n
 	}
 
-	if g.root != nil {
-		value := reflect.ValueOf(g.root)
-		err = d.read(value.Type())
-		if err != nil {
-			return nil, fmt.Errorf("error on root type %T: %s", g.root, err)
+	for alias, canonical := range aliases {
+		n := newNode()
+		n.setRef(definitionReference(canonical))
+		m.Definitions[alias] = n
+	}
+
+	for name, sunset := range ts.deprecations {
+		n, ok := m.Definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot deprecate unknown definition %s", name)
 		}
+		n.Schema.Deprecate(sunset)
 	}
 
-	return d, nil
+	if ts.root != nil {
+		r.tracef("root: %s", ts.root)
+		if err := r.readRoot(m.Root, ts.root); err != nil {
+			return nil, fmt.Errorf("error on root type %s: %s", ts.root, err)
+		}
+	}
+	if len(ts.rootUnion) > 0 {
+		if err := r.readRootUnion(m.Root, ts.rootUnion); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.definePromoted(m); err != nil {
+		return nil, err
+	}
+
+	m.Root.annotate()
+	for _, def := range m.Definitions {
+		def.annotate()
+	}
+	return m, nil
+}
+
+// buildDocument serializes the Model read by r as the keywords of the draft
+// of its options, and applies the policies and the options to the schema. The
+// definitions the root doesn't reference are pruned if it has one and the
+// options say so. It returns the inconsistencies found, which fail it with
+// Options.Strict.
+func buildDocument(m *Model, r *reader, policies []Policy, hasRoot bool) (*JSONSchema, []Inconsistency, error) {
+	options := r.options
+	s := newSerializer(options)
+	d := s.document(m)
+	d.Schema = options.Schema
+	d.omitSchema = options.OmitSchemaKeyword
+	d.reproducible = options.Reproducible
+	d.knownTypes = r.valueTypes()
+	if options.OnlyReferencedDefinitions && hasRoot {
+		d.PruneUnusedDefinitions()
+	}
+
+	d.Definitions = minifyEnums(options, d.Definitions, &d.Property)
+	if err := applyPolicies(policies, d); err != nil {
+		return nil, nil, err
+	}
+	if err := d.compilePatterns(); err != nil {
+		return nil, nil, err
+	}
+	untranslatable := translatePatterns(options.PatternDialect, d)
+	if options.Metadata != nil {
+		options.Metadata.stamp(&d.Property, options.Reproducible)
+	}
+
+	warnings := append(d.CheckConsistency(), untranslatable...)
+	if options.Strict && len(warnings) > 0 {
+		return nil, warnings, inconsistencyError(warnings)
+	}
+	s.rewriteKeywords(d)
+	return d, warnings, nil
 }
 
-// String return the JSON encoding of the JSONSchema as a string
+// Warnings returns the inconsistencies found in the schema last generated,
+// such as a minimum greater than the maximum, and the patterns which can't be
+// translated to Options.PatternDialect. They fail the generation with
+// Options.Strict. After GenerateSet, the paths of the inconsistencies of the
+// roots start with /roots/name.
+func (g *Generator) Warnings() []Inconsistency {
+	return g.warnings
+}
+
+// String return the JSON encoding of the JSONSchema as a string, indented
+// with two spaces. Indent, Format and Canonical serialize it otherwise.
 func (d JSONSchema) String() string {
-	json, _ := json.MarshalIndent(d, "", "  ")
+	json, _ := d.Indent("", "  ")
 	return string(json)
 }
 
@@ -140,16 +546,31 @@ func (d *JSONSchema) setDefaultSchema() {
 }
 
 type Property struct {
-	Type                 string               `json:"type,omitempty"`
+	Type string `json:"type,omitempty"`
+	// Types holds the types of values which may be of several types, emitted
+	// as a type array. Type then holds the first of them which isn't null.
+	Types                []string             `json:"-"`
 	Format               string               `json:"format,omitempty"`
 	Items                *Property            `json:"items,omitempty"`
 	Properties           map[string]*Property `json:"properties,omitempty"`
 	Required             []string             `json:"required,omitempty"`
 	AdditionalProperties bool                 `json:"additionalProperties,omitempty"`
-	Description          string               `json:"description,omitempty"`
-	AnyOf                []*Property          `json:"anyOf,omitempty"`
-	OneOf                []*Property          `json:"oneOf,omitempty"`
-	Dependencies         map[string]*Property `json:"dependencies,omitempty"`
+	// AdditionalPropertiesSchema describes the properties of an object not
+	// listed in Properties, emitted as additionalProperties when set.
+	AdditionalPropertiesSchema *Property `json:"-"`
+	// NoAdditionalProperties forbids the properties of an object not listed
+	// in Properties, emitted as additionalProperties false. As false is the
+	// zero value of AdditionalProperties, it can't tell it apart from absent.
+	NoAdditionalProperties bool                 `json:"-"`
+	Description            string               `json:"description,omitempty"`
+	AnyOf                  []*Property          `json:"anyOf,omitempty"`
+	OneOf                  []*Property          `json:"oneOf,omitempty"`
+	Dependencies           map[string]*Property `json:"dependencies,omitempty"`
+	// DependentRequired and DependentSchemas replace Dependencies since draft 2019-09.
+	DependentRequired map[string][]string  `json:"dependentRequired,omitempty"`
+	DependentSchemas  map[string]*Property `json:"dependentSchemas,omitempty"`
+	Not               *Property            `json:"not,omitempty"`
+	Deprecated        bool                 `json:"deprecated,omitempty"`
 
 	Extensions map[string]interface{} `json:"-"`
 
@@ -167,24 +588,57 @@ type Property struct {
 	MaxLength *int64 `json:"maxLength,omitempty"`
 	MinLength *int64 `json:"minLength,omitempty"`
 	Pattern   string `json:"pattern,omitempty"`
-	// Enum is defined for arbitrary types, but I'm currently just implementing it for strings.
-	Enum  []string `json:"enum,omitempty"`
-	Title string   `json:"title,omitempty"`
+	// array validators
+	MinItems *int64 `json:"minItems,omitempty"`
+	MaxItems *int64 `json:"maxItems,omitempty"`
+	// ContentEncoding is the encoding of binary data in a string, e.g. base64.
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	// Enum holds the values allowed. The enum tag only describes strings, but
+	// schemas read may allow values of any type.
+	Enum  []interface{} `json:"enum,omitempty"`
+	Title string        `json:"title,omitempty"`
 	// Implemented for strings and numbers
-	Const        interface{} `json:"const,omitempty"`
-	Ref          string      `json:"$ref,omitempty"`
-	knownTypes   knownTypes
-	isDefinition bool
+	Const interface{} `json:"const,omitempty"`
+	// MarkdownDescription is the description rendered by editors such as VS Code.
+	MarkdownDescription string `json:"markdownDescription,omitempty"`
+	// Examples holds example values, e.g. from the example tag.
+	Examples []interface{} `json:"examples,omitempty"`
+	// Default is the value assumed when the property is absent.
+	Default interface{} `json:"default,omitempty"`
+	Ref     string      `json:"$ref,omitempty"`
 }
 
 type marshallingProperty Property
 
-func (p *Property) MarshalJSON() ([]byte, error) {
-	if p == nil {
-		return nil, nil
+// MarshalJSON has a value receiver so that the definitions, which are not
+// addressable, are marshaled with it as well.
+func (p Property) MarshalJSON() ([]byte, error) {
+	var v interface{} = marshallingProperty(p)
+	if len(p.Types) > 0 || p.AdditionalPropertiesSchema != nil || p.NoAdditionalProperties {
+		// keywords which may not be represented by the fields of Property
+		mixed := struct {
+			Type                 interface{} `json:"type,omitempty"`
+			AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+			marshallingProperty
+		}{marshallingProperty: marshallingProperty(p)}
+		if p.Type != "" {
+			mixed.Type = p.Type
+		}
+		if len(p.Types) > 0 {
+			mixed.Type = p.Types
+		}
+		if p.AdditionalProperties {
+			mixed.AdditionalProperties = true
+		}
+		if p.NoAdditionalProperties {
+			mixed.AdditionalProperties = false
+		}
+		if p.AdditionalPropertiesSchema != nil {
+			mixed.AdditionalProperties = p.AdditionalPropertiesSchema
+		}
+		v = mixed
 	}
-	mp := marshallingProperty(*p)
-	b, err := json.Marshal(mp)
+	b, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
@@ -208,143 +662,698 @@ func (p *Property) MarshalJSON() ([]byte, error) {
 	return b, err
 }
 
-func (p *Property) child() *Property {
-	return &Property{knownTypes: p.knownTypes}
+// propertyKeywords holds the keywords of the fields of Property.
+var propertyKeywords = func() map[string]bool {
+	keywords := map[string]bool{}
+	t := reflect.TypeOf(Property{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			keywords[name] = true
+		}
+	}
+	return keywords
+}()
+
+// UnmarshalJSON accepts both a single type and a type array, both a
+// boolean and a schema for additionalProperties, and both the bounds and the
+// booleans of draft-04 for exclusiveMinimum and exclusiveMaximum. The other
+// keywords, such as x- extensions, are held in Extensions, so that schemas
+// marshal back as they were read.
+func (p *Property) UnmarshalJSON(b []byte) error {
+	v := struct {
+		Type                 json.RawMessage `json:"type"`
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+		ExclusiveMinimum     json.RawMessage `json:"exclusiveMinimum"`
+		ExclusiveMaximum     json.RawMessage `json:"exclusiveMaximum"`
+		*marshallingProperty
+	}{marshallingProperty: (*marshallingProperty)(p)}
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+	if err := p.unmarshalExclusiveBound("exclusiveMinimum", v.ExclusiveMinimum, &p.ExclusiveMinimum); err != nil {
+		return err
+	}
+	if err := p.unmarshalExclusiveBound("exclusiveMaximum", v.ExclusiveMaximum, &p.ExclusiveMaximum); err != nil {
+		return err
+	}
+	if err := p.unmarshalExtensions(b); err != nil {
+		return err
+	}
+	if len(v.AdditionalProperties) > 0 && v.AdditionalProperties[0] == '{' {
+		err = json.Unmarshal(v.AdditionalProperties, &p.AdditionalPropertiesSchema)
+	} else if len(v.AdditionalProperties) > 0 {
+		err = json.Unmarshal(v.AdditionalProperties, &p.AdditionalProperties)
+		p.NoAdditionalProperties = err == nil && !p.AdditionalProperties
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(v.Type) == 0:
+		return nil
+	case v.Type[0] != '[':
+		return json.Unmarshal(v.Type, &p.Type)
+	}
+	err = json.Unmarshal(v.Type, &p.Types)
+	if err != nil {
+		return err
+	}
+	for _, t := range p.Types {
+		if t != "null" {
+			p.Type = t
+			break
+		}
+	}
+	return nil
 }
 
-func (p *Property) read(t reflect.Type) error {
+// unmarshalExtensions reads the keywords which aren't fields of Property
+// into Extensions.
+func (p *Property) unmarshalExtensions(b []byte) error {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(b, &keywords); err != nil {
+		return err
+	}
+	for keyword, raw := range keywords {
+		if propertyKeywords[keyword] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		setExtension(p, keyword, value)
+	}
+	return nil
+}
+
+// unmarshalExclusiveBound reads the exclusive bound raw into bound, or the
+// boolean of draft-04 qualifying the bound into the extension keyword.
+func (p *Property) unmarshalExclusiveBound(keyword string, raw json.RawMessage, bound **float64) error {
+	switch {
+	case len(raw) == 0:
+		return nil
+	case raw[0] == 't' || raw[0] == 'f':
+		var exclusive bool
+		if err := json.Unmarshal(raw, &exclusive); err != nil {
+			return err
+		}
+		if exclusive {
+			setExtension(p, keyword, true)
+		}
+		return nil
+	}
+	return json.Unmarshal(raw, bound)
+}
+
+// MarshalJSON is needed as the one of the embedded Property would ignore
+// the keywords of the root. The keywords of the root come first, followed by
+// those of the Property, including its extensions, unless the schema was
+// generated with Options.Reproducible, which serializes it in its canonical
+// form.
+//
+// It has a value receiver so that schemas held by value, by pointer or in
+// maps marshal the same way. Structs embedding JSONSchema inherit it, and
+// should marshal with MarshalEmbedded rather.
+func (d JSONSchema) MarshalJSON() ([]byte, error) {
+	if d.reproducible {
+		d.reproducible = false
+		b, err := d.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return canonicalJSON(b)
+	}
+	var root []byte
+	var err error
+	schema := d.Schema
+	if d.omitSchema {
+		schema = ""
+	}
+	if d.usesDefs() {
+		// definitions are held under the definitions keyword, whatever the draft
+		d = *d.Clone()
+		d.replaceRefPrefix(definitionsPrefix, defsPrefix)
+		root, err = json.Marshal(struct {
+			Schema string              `json:"$schema,omitempty"`
+			ID     string              `json:"$id,omitempty"`
+			Defs   map[string]Property `json:"$defs,omitempty"`
+		}{schema, d.ID, d.Definitions})
+	} else {
+		root, err = json.Marshal(struct {
+			Schema      string              `json:"$schema,omitempty"`
+			ID          string              `json:"$id,omitempty"`
+			Definitions map[string]Property `json:"definitions,omitempty"`
+		}{schema, d.ID, d.Definitions})
+	}
+	if err != nil {
+		return nil, err
+	}
+	property, err := d.Property.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// keywords of the root first
+	return mergeObjects(root, property), nil
+}
+
+// UnmarshalJSON is needed as the one of the embedded Property would ignore
+// the keywords of the root. Definitions may be named definitions or $defs,
+// whatever the draft, and are held under Definitions.
+func (d *JSONSchema) UnmarshalJSON(b []byte) error {
+	var root struct {
+		Schema      string              `json:"$schema"`
+		ID          string              `json:"$id"`
+		Definitions map[string]Property `json:"definitions"`
+		Defs        map[string]Property `json:"$defs"`
+	}
+	err := json.Unmarshal(b, &root)
+	if err != nil {
+		return err
+	}
+	d.Schema, d.ID, d.Definitions = root.Schema, root.ID, root.Definitions
+	for name, def := range root.Defs {
+		if d.Definitions == nil {
+			d.Definitions = map[string]Property{}
+		}
+		d.Definitions[name] = def
+	}
+	err = d.Property.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+	for _, keyword := range []string{"$schema", "$id", "definitions", "$defs"} {
+		delete(d.Extensions, keyword)
+	}
+	if len(d.Extensions) == 0 {
+		d.Extensions = nil
+	}
+	d.replaceRefPrefix(defsPrefix, definitionsPrefix)
+	return nil
+}
+
+// reader reads the schemas of Go types into properties, whether read by
+// reflection or from source.
+type reader struct {
+	// knownTypes maps the keys of the types registered as definitions to
+	// the names of the definitions
+	knownTypes map[interface{}]string
+	options    Options
+	snippets   map[string]string
+	// presets holds the tags of the presets by name
+	presets map[string]map[string]string
+	// typeTags holds the tags set on the fields of types, by qualified name
+	typeTags map[string]map[string]string
+	// logger receives the trace at the debug level, if set
+	logger *slog.Logger
+	// visiting counts the reads of the structs being read, by key, to stop
+	// at recursive types
+	visiting map[interface{}]int
+	// definitionNames holds the names of the definitions, which the
+	// recursive types promoted to definitions don't take
+	definitionNames map[string]bool
+	// promoted holds the types promoted to definitions, which are yet to
+	// be read
+	promoted []goType
+	// naming names the definitions of the types hoisted by
+	// WithAutoDefinitions, if set
+	naming func(t goType) string
+}
+
+// reference returns the reference to the definition of t, if registered.
+func (r *reader) reference(t goType) (string, bool) {
+	name, ok := r.knownTypes[t.Key()]
+	if !ok {
+		return "", false
+	}
+	return definitionReference(name), true
+}
+
+// valueTypes returns the types of values registered as definitions, by
+// name, or nil if there are none.
+func (r *reader) valueTypes() knownTypes {
+	var k knownTypes
+	for key, name := range r.knownTypes {
+		if t, ok := key.(reflect.Type); ok {
+			if k == nil {
+				k = knownTypes{}
+			}
+			k[t] = name
+		}
+	}
+	return k
+}
+
+// enter marks the struct t as being read, unless it is recursive beyond the
+// depth unrolled.
+func (r *reader) enter(t goType) bool {
+	key := t.Key()
+	if r.visiting[key] > 0 && r.visiting[key] > r.options.UnrollRecursion {
+		return false
+	}
+	r.visiting[key]++
+	return true
+}
+
+func (r *reader) leave(t goType) {
+	key := t.Key()
+	r.visiting[key]--
+	if r.visiting[key] == 0 {
+		delete(r.visiting, key)
+	}
+}
+
+// unrolling reports whether the struct t, being read, is described inline
+// rather than referenced where it recurses.
+func (r *reader) unrolling(t goType) bool {
+	return r.options.UnrollRecursion > 0 && r.visiting[t.Key()] > 0
+}
+
+// schema returns the schema of n in the draft of the options, as the types
+// modifying their schema and the trace see it.
+func (r *reader) schema(n *Node) *Property {
+	return newSerializer(r.options).schema(n)
+}
+
+// describe sets the description of p to doc, the doc comment of its type or
+// field, unless set by tags.
+func (r *reader) describe(p *Property, doc string) {
+	if doc == "" || p.Description != "" {
+		return
+	}
+	p.Description = doc
+	if p.MarkdownDescription == "" && r.options.MarkdownDescriptions {
+		p.MarkdownDescription = doc
+	}
+}
+
+// readDefinition reads the node of t into n, a definition of the schema, so a
+// struct is described in place rather than referenced.
+func (r *reader) readDefinition(n *Node, t goType) error {
+	if t.Kind() == reflect.Struct {
+		err := r.readFromStruct(n, t)
+		if err != nil {
+			return err
+		}
+		r.addMetadata(n.Schema, t)
+		r.modifySchema(n, t)
+	} else {
+		// a primitive type describes its definition rather than referencing it
+		if err := r.readType(n, t); err != nil {
+			return err
+		}
+		tag := r.addTypeTags("", t.Name())
+		n.addValidatorsFromTags(&tag)
+	}
+	r.describe(n.Schema, t.Doc())
+	return nil
+}
+
+func (r *reader) read(n *Node, t goType) error {
+	if ref, ok := r.reference(t); ok && r.options.ReferencePrimitives && isPrimitive(t.Kind()) {
+		n.setRef(ref)
+		return nil
+	}
+	return r.readType(n, t)
+}
+
+// readType reads the node of the type t into n.
+func (r *reader) readType(n *Node, t goType) error {
 	jsType, format, kind := getTypeFromMapping(t)
 	if jsType != "" {
-		p.Type = jsType
+		n.Kind = kindOfType(jsType)
 	}
 	if format != "" {
-		p.Format = format
+		n.Schema.Format = format
+	}
+	if err := r.readTime(n); err != nil {
+		return err
 	}
 
 	var err error
 
 	switch kind {
-	case reflect.Slice:
-		err = p.readFromSlice(t)
+	case reflect.Slice, reflect.Array:
+		err = r.readFromSlice(n, t)
 	case reflect.Map:
-		err = p.readFromMap(t)
+		err = r.readFromMap(n, t)
 	case reflect.Struct:
-		err = p.readFromStruct(t)
+		if ref, ok := r.reference(t); ok && !r.unrolling(t) {
+			n.setRef(ref)
+			return nil
+		} else if ok {
+			r.tracef("%s: unrolled to depth %d rather than referenced", t, r.visiting[t.Key()]+1)
+		} else if r.hoisting(t) {
+			n.setRef(r.promote(t))
+			r.tracef("%s: hoisted into %s", t, n.Ref)
+			return nil
+		}
+		err = r.readFromStruct(n, t)
 	case reflect.Ptr:
-		err = p.read(t.Elem())
+		err = r.read(n, t.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		r.readInteger(n.Schema, kind)
+	case reflect.Float32, reflect.Float64:
+		r.readFloat(n.Schema, kind)
 	}
 
 	if err != nil {
 		return err
 	}
 
-	// say we have *int
-	if kind == reflect.Ptr && isPrimitive(t.Elem().Kind()) {
-		p.AnyOf = []*Property{
-			{Type: p.Type},
-			{Type: "null"},
-		}
-		p.Type = ""
+	if kind != reflect.Ptr {
+		r.addMetadata(n.Schema, t)
+		r.modifySchema(n, t)
+	}
+
+	// say we have *string. Pointers to numbers and booleans are described
+	// by the node of their values.
+	if kind == reflect.Ptr && t.Elem().Kind() == reflect.String {
+		n.setNullable()
 	}
 
 	return nil
 }
 
-func (p *Property) readFromSlice(t reflect.Type) error {
+// integerFormats maps integer kinds to the smallest format holding their values.
+var integerFormats = map[reflect.Kind]string{
+	reflect.Int:    "int64",
+	reflect.Int8:   "int32",
+	reflect.Int16:  "int32",
+	reflect.Int32:  "int32",
+	reflect.Int64:  "int64",
+	reflect.Uint:   "int64",
+	reflect.Uint8:  "int32",
+	reflect.Uint16: "int32",
+	reflect.Uint32: "int64",
+	reflect.Uint64: "int64",
+}
+
+// integerBounds maps integer kinds to the range of their values, for those
+// holding less than 64 bits.
+var integerBounds = map[reflect.Kind][2]float64{
+	reflect.Int8:   {math.MinInt8, math.MaxInt8},
+	reflect.Int16:  {math.MinInt16, math.MaxInt16},
+	reflect.Int32:  {math.MinInt32, math.MaxInt32},
+	reflect.Uint8:  {0, math.MaxUint8},
+	reflect.Uint16: {0, math.MaxUint16},
+	reflect.Uint32: {0, math.MaxUint32},
+}
+
+func (r *reader) readInteger(p *Property, kind reflect.Kind) {
+	unsigned := false
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		unsigned = true
+	}
+
+	if r.options.IntegerFormats {
+		p.Format = integerFormats[kind]
+	}
+	if unsigned && (r.options.IntegerFormats || r.options.IntegerBounds) {
+		p.Minimum = float64ptr(0)
+	}
+	if bounds, ok := integerBounds[kind]; ok && r.options.IntegerBounds {
+		p.Minimum = float64ptr(bounds[0])
+		p.Maximum = float64ptr(bounds[1])
+	}
+}
+
+func (r *reader) readFromSlice(n *Node, t goType) error {
 	jsType, _, kind := getTypeFromMapping(t.Elem())
-	if kind == reflect.Uint8 {
-		p.Type = "string"
+	// encoding/json encodes byte slices, but not byte arrays, as strings
+	if kind == reflect.Uint8 && t.Kind() == reflect.Slice {
+		n.Kind = KindString
+		return n.Schema.setBytesFormat(r.options.BytesFormat)
 	} else if jsType != "" || kind == reflect.Ptr {
-		p.Items = p.child()
-		return p.Items.read(t.Elem())
+		n.Elem = newNode()
+		return r.read(n.Elem, t.Elem())
 	}
 	return nil
 }
 
-func (p *Property) readFromMap(t reflect.Type) error {
+func (r *reader) readFromMap(n *Node, t goType) error {
+	elem := t.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	n.Kind = KindMap
+	if jsType, _, _ := getTypeFromMapping(elem); jsType == "object" || jsType == "array" {
+		// values are described like slice elements, so registered types are referenced
+		n.Elem = newNode()
+		return r.read(n.Elem, t.Elem())
+	}
+
 	jsType, format, _ := getTypeFromMapping(t.Elem())
 
 	if jsType != "" {
-		p.Properties = make(map[string]*Property, 0)
-		p.Properties[".*"] = &Property{Type: jsType, Format: format}
-	} else {
-		p.AdditionalProperties = true
+		n.Elem = &Node{Kind: kindOfType(jsType), Schema: &Property{Format: format}}
+		n.patternValues = true
+		return r.readTime(n.Elem)
 	}
+	n.Schema.AdditionalProperties = true
 	return nil
 }
 
-func (p *Property) readFromStruct(t reflect.Type) error {
-	var ok bool
-	if !p.isDefinition {
-		if p.Ref, ok = p.knownTypes.getReference(t); ok {
-			p.Type = ""
+func (r *reader) readFromStruct(n *Node, t goType) error {
+	if resourceType, ok := jsonAPIResourceType(t); ok && r.options.JSONAPI {
+		return r.readResource(n, t, resourceType)
+	}
+	// a map of any values until its fields are read
+	n.Kind = KindMap
+	if !r.enter(t) {
+		if r.options.DefineRecursiveTypes || r.options.UnrollRecursion <= 0 {
+			n.setRef(r.promote(t))
+			r.tracef("%s: recursive, referenced as %s", t, n.Ref)
 			return nil
 		}
+		// a recursive type unrolled to the depth set is described as any
+		// object
+		r.tracef("%s: recursive, described as any object", t)
+		return nil
 	}
+	defer r.leave(t)
 
-	p.Type = "object"
-	p.Properties = make(map[string]*Property, 0)
-	p.AdditionalProperties = false
+	n.Kind = KindObject
+	n.Fields = nil
 
+	var rules []crossFieldRule
+	var squashed []goField
 	count := t.NumField()
 	for i := 0; i < count; i++ {
 		field := t.Field(i)
+		field.Tag = r.resolveTagAliases(field.Tag)
+		var err error
+		if field.Tag, err = r.expandPresets(field.Tag); err != nil {
+			return fmt.Errorf("property:%s:%s", field.Name, err)
+		}
+		field.Tag = r.addTypeTags(field.Tag, fieldTypeName(field.Type))
 
-		tag := field.Tag.Get("json")
+		tag := r.nameTag(field.Tag)
 
 		name, opts := parseTag(tag)
 
-		var target *Property
-		if field.PkgPath == "" {
-			// this is an exported property
-			target = p.child()
-
-			err := target.read(field.Type)
-			if err != nil {
-				return fmt.Errorf("property:%s:%s", field.Name, err)
-			}
+		var target *Node
+		if field.Exported {
 			if name == "" {
-				name = field.Name
+				name = r.propertyName(field.Name)
 			}
 			if name == "-" {
+				r.tracef("%s.%s: skipped, named -", t, field.Name)
+				continue
+			}
+			if opts.Contains("squash") {
+				r.tracef("%s.%s: squashed into %s", t, field.Name, t)
+				squashed = append(squashed, field)
+				continue
+			}
+			if opts.Contains("remain") {
+				r.tracef("%s.%s: describes the remaining properties", t, field.Name)
+				if err := r.remain(n, field); err != nil {
+					return fmt.Errorf("property:%s:%s", field.Name, err)
+				}
 				continue
 			}
-			p.Properties[name] = target
-		} else {
-			// not an exported field, tags apply to this property
-			target = p
-		}
 
-		target.Description = field.Tag.Get("description")
-		target.Title = field.Tag.Get("title")
-		target.addValidatorsFromTags(&field.Tag)
+			raw, err := rawSchemaFromTags(&field.Tag)
+			if err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			if raw != nil {
+				// the schema replaces the generated one, other tags are ignored
+				r.tracef("%s.%s: replaced by the schema of its tags, other tags skipped", t, field.Name)
+				_, required := field.Tag.Lookup("required")
+				n.setField(name, parseNode(raw), required && !opts.Contains("omitempty"))
+				continue
+			}
 
-		extensionsRaw, hasExtensions := field.Tag.Lookup("extensions")
-		if hasExtensions {
-			var extensionsMap map[string]interface{}
-			err := json.Unmarshal([]byte(extensionsRaw), &extensionsMap)
+			target = newNode()
+			err = r.read(target, field.Type)
+			if err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			n.setField(name, target, false)
+			rules = append(rules, crossFieldRuleFromTags(field.Name, name, &field.Tag))
+			err = target.Schema.addBytesFormatFromTags(&field.Tag, field.Type)
+			if err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			err = target.addValuesFromTags(&field.Tag, field.Type)
+			if err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			err = target.addLinksFromTags(&field.Tag, field.Type)
+			if err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			err = r.addOneOfFromTags(target, &field.Tag, field.Type)
 			if err != nil {
-				return fmt.Errorf(`invalid "extensions" tag value %q: %s`, extensionsRaw, err)
+				return fmt.Errorf("property:%s:%s", field.Name, err)
 			}
-			target.Extensions = extensionsMap
+		} else {
+			// not an exported field, tags apply to this node
+			target = n
+		}
+
+		if err := r.readTags(target, field.Name, name, field.Exported, &field.Tag); err != nil {
+			return err
+		}
+		if field.Exported {
+			r.describe(target.Schema, field.Doc)
+			r.traceField(t.String(), field.Name, field.Type.String(), field.Tag, target)
 		}
 
 		_, required := field.Tag.Lookup("required")
 		if opts.Contains("omitempty") || !required {
 			continue
 		}
-		p.Required = append(p.Required, name)
+		if f := n.field(name); f != nil {
+			f.Required = true
+		} else {
+			n.Schema.Required = append(n.Schema.Required, name)
+		}
+	}
+
+	for _, field := range squashed {
+		if err := r.squash(n, field); err != nil {
+			return fmt.Errorf("property:%s:%s", field.Name, err)
+		}
+	}
+	return r.addCrossFieldRules(n, rules)
+}
+
+// readTags reads the tags of a field which don't depend on its type into
+// target, the node of the field, or of its struct when the field isn't
+// exported.
+func (r *reader) readTags(target *Node, fieldName, name string, exported bool, tag *reflect.StructTag) error {
+	if exported {
+		if err := r.addTypesFromTags(target, tag); err != nil {
+			return fmt.Errorf("property:%s:%s", fieldName, err)
+		}
+		if err := r.addFlagsFromTags(target, tag); err != nil {
+			return fmt.Errorf("property:%s:%s", fieldName, err)
+		}
+		if err := target.addTimeEncodingFromTags(tag); err != nil {
+			return fmt.Errorf("property:%s:%s", fieldName, err)
+		}
+		if err := target.Schema.addTimeFormatFromTags(tag); err != nil {
+			return fmt.Errorf("property:%s:%s", fieldName, err)
+		}
+		if err := target.Schema.addUTCFromTags(tag); err != nil {
+			return fmt.Errorf("property:%s:%s", fieldName, err)
+		}
+	}
+	description, err := r.interpolate(tag.Get("description"))
+	if err != nil {
+		return fmt.Errorf("property:%s:description:%s", fieldName, err)
+	}
+	markdown, err := r.interpolate(tag.Get("markdownDescription"))
+	if err != nil {
+		return fmt.Errorf("property:%s:markdownDescription:%s", fieldName, err)
+	}
+	if markdown == "" && r.options.MarkdownDescriptions {
+		markdown = description
+	}
+	// the metadata of the type of the field applies without tags
+	p := target.Schema
+	if description != "" || markdown != "" {
+		p.Description = description
+		p.MarkdownDescription = markdown
+	}
+	if title := tag.Get("title"); title != "" {
+		p.Title = title
+	} else if p.Title == "" && exported && r.options.HumanizeTitles {
+		p.Title = humanize(name)
+	}
+	if _, err := expandPattern(tag.Get("pattern")); err != nil {
+		return fmt.Errorf("property:%s:%s", fieldName, err)
+	}
+	target.addValidatorsFromTags(tag)
+	if exported {
+		err := p.addExampleFromTags(tag, target.schemaType())
+		if err != nil {
+			return fmt.Errorf("property:%s:%s", fieldName, err)
+		}
+	}
+
+	extensionsRaw, hasExtensions := tag.Lookup("extensions")
+	if hasExtensions {
+		var extensionsMap map[string]interface{}
+		err := json.Unmarshal([]byte(extensionsRaw), &extensionsMap)
+		if err != nil {
+			return fmt.Errorf(`invalid "extensions" tag value %q: %s`, extensionsRaw, err)
+		}
+		p.Extensions = extensionsMap
 	}
 
+	err = p.addDeprecationFromTags(tag)
+	if err != nil {
+		return fmt.Errorf("property:%s:%s", fieldName, err)
+	}
+	err = p.addSensitiveFromTags(tag)
+	if err != nil {
+		return fmt.Errorf("property:%s:%s", fieldName, err)
+	}
+	err = p.addClassificationFromTags(tag)
+	if err != nil {
+		return fmt.Errorf("property:%s:%s", fieldName, err)
+	}
+	err = p.addNaNFromTags(tag, target.schemaType())
+	if err != nil {
+		return fmt.Errorf("property:%s:%s", fieldName, err)
+	}
 	return nil
 }
 
-func (p *Property) addValidatorsFromTags(tag *reflect.StructTag) {
-	switch p.Type {
+// addValidatorsFromTags reads the validators of the type of the values of n.
+// They only apply to the values of their type when n is nullable, but for
+// enum and const, which the serializer extends with null.
+func (n *Node) addValidatorsFromTags(tag *reflect.StructTag) {
+	n.Schema.addValidatorsFromTags(tag, n.schemaType())
+}
+
+// addValidatorsFromTags reads the validators of values of the type t into p.
+func (p *Property) addValidatorsFromTags(tag *reflect.StructTag, t string) {
+	switch t {
 	case "string":
 		p.addStringValidators(tag)
 	case "number", "integer":
-		p.addNumberValidators(tag)
+		p.addNumberValidators(tag, t)
+	case "array":
+		p.addArrayValidators(tag)
+	}
+}
+
+func (p *Property) addArrayValidators(tag *reflect.StructTag) {
+	if n, err := strconv.ParseInt(tag.Get("minItems"), 10, 64); err == nil {
+		p.MinItems = &n
+	}
+	if n, err := strconv.ParseInt(tag.Get("maxItems"), 10, 64); err == nil {
+		p.MaxItems = &n
 	}
 }
 
@@ -383,12 +1392,18 @@ func (p *Property) addStringValidators(tag *reflect.StructTag) {
 	// pattern
 	pat := tag.Get("pattern")
 	if pat != "" {
-		p.Pattern = pat
+		// unknown macros are reported by readTags
+		if expanded, err := expandPattern(pat); err == nil {
+			p.Pattern = expanded
+		}
+	}
+	if format := tag.Get("format"); format != "" {
+		p.Format = format
 	}
 	// enum
 	en := tag.Get("enum")
 	if en != "" {
-		p.Enum = strings.Split(en, "|")
+		p.Enum = enumValues(strings.Split(en, "|"))
 	}
 	// const
 	c := tag.Get("const")
@@ -397,7 +1412,7 @@ func (p *Property) addStringValidators(tag *reflect.StructTag) {
 	}
 }
 
-func (p *Property) addNumberValidators(tag *reflect.StructTag) {
+func (p *Property) addNumberValidators(tag *reflect.StructTag, t string) {
 	m, err := strconv.ParseFloat(tag.Get("multipleOf"), 64)
 	if err == nil {
 		p.MultipleOf = float64ptr(m)
@@ -418,7 +1433,7 @@ func (p *Property) addNumberValidators(tag *reflect.StructTag) {
 	if err == nil {
 		p.ExclusiveMaximum = float64ptr(m)
 	}
-	c, err := parseType(tag.Get("const"), p.Type)
+	c, err := parseType(tag.Get("const"), t)
 	if err == nil {
 		p.Const = c
 	}
@@ -435,6 +1450,41 @@ func parseType(str, ty string) (interface{}, error) {
 	return v, err
 }
 
+// enumValues returns the enum of the strings values.
+func enumValues(values []string) []interface{} {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return enum
+}
+
+// enumStrings returns the values of enum, or false if they aren't all
+// strings.
+func enumStrings(enum []interface{}) ([]string, bool) {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		values[i] = s
+	}
+	return values, true
+}
+
+// joinEnum returns the values of enum separated by |, as in the enum tag.
+func joinEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprint(v)
+		if v == nil {
+			values[i] = "null"
+		}
+	}
+	return strings.Join(values, "|")
+}
+
 var formatMapping = map[string][]string{
 	"time.Time": []string{"string", "date-time"},
 }
@@ -455,25 +1505,23 @@ var kindMapping = map[reflect.Kind]string{
 	reflect.Float64: "number",
 	reflect.String:  "string",
 	reflect.Slice:   "array",
+	reflect.Array:   "array",
 	reflect.Struct:  "object",
 	reflect.Map:     "object",
 }
 
+// isPrimitive reports whether the values of kind k are booleans, numbers or
+// strings.
 func isPrimitive(k reflect.Kind) bool {
-	if v, ok := kindMapping[k]; ok {
-		switch v {
-		case "boolean":
-		case "integer":
-		case "number":
-		case "string":
-			return true
-		}
+	switch kindMapping[k] {
+	case "boolean", "integer", "number", "string":
+		return true
 	}
 	return false
 }
 
-func getTypeFromMapping(t reflect.Type) (string, string, reflect.Kind) {
-	if v, ok := formatMapping[t.String()]; ok {
+func getTypeFromMapping(t goType) (string, string, reflect.Kind) {
+	if v, ok := formatMapping[t.Name()]; ok {
 		return v[0], v[1], reflect.String
 	}
 
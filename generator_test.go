@@ -1,8 +1,10 @@
 package jsonschema
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -123,7 +125,7 @@ func (self *propertySuite) TestLoadWithTag(c *C) {
 				},
 				"fruit": &Property{
 					Type: "string",
-					Enum: []string{"apple", "banana", "pear"},
+					Enum: []interface{}{"apple", "banana", "pear"},
 				},
 			},
 		},
@@ -173,7 +175,7 @@ func (self *propertySuite) TestExtension(c *C) {
 			Properties: map[string]*Property{
 				"value": &Property{
 					Type: "string",
-					Enum: []string{"a", "b", "c"},
+					Enum: []interface{}{"a", "b", "c"},
 					Extensions: map[string]interface{}{
 						"enumNames": []interface{}{"A", "B", "C"},
 					},
@@ -266,6 +268,39 @@ func (self *propertySuite) TestLoadNestedWithDefinitions(c *C) {
 	c.Assert(findDiff(j.String(), k.String()), Equals, "")
 }
 
+func (self *propertySuite) TestLoadNestedWithDefinitionTypes(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONNestedStructReferenceGrandParent{}).
+		WithDefinitionType("parent", reflect.TypeOf(ExampleJSONNestedStructReferenceParent{})).
+		WithDefinition("child", reflect.TypeOf(&ExampleJSONNestedStructReferenceChild{})).
+		MustGenerate()
+
+	k := NewGenerator().WithRoot(&ExampleJSONNestedStructReferenceGrandParent{}).
+		WithDefinitions(map[string]interface{}{
+			"parent": ExampleJSONNestedStructReferenceParent{},
+			"child":  ExampleJSONNestedStructReferenceChild{},
+		}).MustGenerate()
+
+	c.Assert(findDiff(j.String(), k.String()), Equals, "")
+}
+
+func (self *propertySuite) TestDefinitionAliases(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONNestedStructReferenceParent{}).
+		WithDefinitions(map[string]interface{}{
+			"child":    ExampleJSONNestedStructReferenceChild{},
+			"oldChild": &ExampleJSONNestedStructReferenceChild{},
+		}).
+		WithDefinitionAlias("legacyChild", "child").
+		MustGenerate()
+
+	c.Assert(j.Properties["Child"].Ref, Equals, "#/definitions/child")
+	c.Assert(j.Definitions["child"].Type, Equals, "object")
+	c.Assert(j.Definitions["oldChild"], DeepEquals, Property{Ref: "#/definitions/child"})
+	c.Assert(j.Definitions["legacyChild"], DeepEquals, Property{Ref: "#/definitions/child"})
+
+	_, err := NewGenerator().WithDefinitionAlias("legacyChild", "missing").Generate()
+	c.Assert(err, ErrorMatches, "alias legacyChild refers to unknown definition missing")
+}
+
 type ExampleJSONBasicMaps struct {
 	Maps           map[string]string `json:",omitempty"`
 	MapOfInterface map[string]interface{}
@@ -410,6 +445,433 @@ func (self *propertySuite) TestLoadNestedSlice(c *C) {
 	})
 }
 
+type ExampleJSONNullable struct {
+	Name *string `json:"name"`
+}
+
+type ExampleJSONNullableValues struct {
+	Count *int    `json:"count" const:"3"`
+	Size  *string `json:"size" enum:"s|m" minLength:"1"`
+	Mode  *string `json:"mode" const:"x"`
+}
+
+func (self *propertySuite) TestNullableTypeArray(c *C) {
+	j := NewGenerator(Options{NullableStyle: TypeArray}).WithRoot(&ExampleJSONNullable{}).MustGenerate()
+
+	c.Assert(j.Properties["name"], DeepEquals, &Property{Type: "string", Types: []string{"string", "null"}})
+	b, err := json.Marshal(j.Properties["name"])
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"type":["string","null"]}`)
+
+	j = NewGenerator().WithRoot(&ExampleJSONNullable{}).MustGenerate()
+	c.Assert(j.Properties["name"], DeepEquals, &Property{AnyOf: []*Property{{Type: "string"}, {Type: "null"}}})
+}
+
+func (self *propertySuite) TestNullableValues(c *C) {
+	j := NewGenerator(Options{NullableStyle: TypeArray}).WithRoot(&ExampleJSONNullableValues{}).MustGenerate()
+	c.Assert(j.Properties["count"], DeepEquals, &Property{Type: "integer", Const: int64(3)})
+	c.Assert(j.Properties["size"], DeepEquals, &Property{Type: "string", Types: []string{"string", "null"}, MinLength: int64ptr(1), Enum: []interface{}{"s", "m", nil}})
+	c.Assert(j.Properties["mode"], DeepEquals, &Property{Type: "string", Types: []string{"string", "null"}, Enum: []interface{}{"x", nil}})
+
+	j = NewGenerator().WithRoot(&ExampleJSONNullableValues{}).MustGenerate()
+	c.Assert(j.Properties["count"], DeepEquals, &Property{Type: "integer", Const: int64(3)})
+	c.Assert(j.Properties["size"], DeepEquals, &Property{AnyOf: []*Property{{Type: "string", Enum: []interface{}{"s", "m"}}, {Type: "null"}}, MinLength: int64ptr(1)})
+	c.Assert(j.Properties["mode"], DeepEquals, &Property{AnyOf: []*Property{{Type: "string", Const: "x"}, {Type: "null"}}})
+
+	for _, style := range []NullableStyle{AnyOf, TypeArray} {
+		v, err := NewValidator(NewGenerator(Options{NullableStyle: style}).WithRoot(&ExampleJSONNullableValues{}).MustGenerate())
+		c.Assert(err, IsNil)
+		errs, err := v.Validate([]byte(`{"count": 3, "size": null, "mode": null}`))
+		c.Assert(err, IsNil)
+		c.Assert(errs, HasLen, 0, Commentf("%v", style))
+		errs, err = v.Validate([]byte(`{"count": 2, "size": "l", "mode": "y"}`))
+		c.Assert(err, IsNil)
+		c.Assert(errs, HasLen, 3, Commentf("%v", style))
+	}
+}
+
+func (self *propertySuite) TestUnmarshalTypeArray(c *C) {
+	var j JSONSchema
+	err := json.Unmarshal([]byte(`{
+		"$schema": "http://json-schema.org/schema#",
+		"definitions": {"name": {"type": ["null", "string"]}},
+		"type": "object",
+		"properties": {
+			"name": {"$ref": "#/definitions/name"},
+			"nickname": {"anyOf": [{"type": "string"}, {"type": "null"}]}
+		}
+	}`), &j)
+	c.Assert(err, IsNil)
+
+	c.Assert(j.Schema, Equals, DEFAULT_SCHEMA)
+	c.Assert(j.Type, Equals, "object")
+	c.Assert(j.Definitions["name"], DeepEquals, Property{Type: "string", Types: []string{"null", "string"}})
+	c.Assert(j.Properties["nickname"].AnyOf, HasLen, 2)
+	c.Assert(j.String(), Equals, `{
+  "$schema": "http://json-schema.org/schema#",
+  "definitions": {
+    "name": {
+      "type": [
+        "null",
+        "string"
+      ]
+    }
+  },
+  "type": "object",
+  "properties": {
+    "name": {
+      "$ref": "#/definitions/name"
+    },
+    "nickname": {
+      "anyOf": [
+        {
+          "type": "string"
+        },
+        {
+          "type": "null"
+        }
+      ]
+    }
+  }
+}`)
+}
+
+func (self *propertySuite) TestUnmarshalExtensions(c *C) {
+	var j JSONSchema
+	err := json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {
+			"mode": {"type": "integer", "x-flags": {"read": 1, "write": 2}},
+			"file": {"type": "object", "properties": {"mode": {"$ref": "#/$defs/mode"}}, "x-table": "files"}
+		},
+		"type": "array",
+		"items": {"$ref": "#/$defs/file"},
+		"x-version": 2
+	}`), &j)
+	c.Assert(err, IsNil)
+
+	c.Assert(j.Extensions, DeepEquals, map[string]interface{}{"x-version": float64(2)})
+	c.Assert(j.Items.Ref, Equals, "#/definitions/file")
+	c.Assert(j.Definitions["file"].Extensions, DeepEquals, map[string]interface{}{"x-table": "files"})
+	c.Assert(j.Definitions["file"].Properties["mode"].Ref, Equals, "#/definitions/mode")
+	mode := j.Definitions["mode"]
+	flags, ok := mode.Flags()
+	c.Assert(ok, Equals, true)
+	c.Assert(flags, DeepEquals, map[string]int64{"read": 1, "write": 2})
+
+	j.Items.Extensions = map[string]interface{}{"x-order": 1}
+	c.Assert(j.String(), Equals, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "file": {
+      "properties": {
+        "mode": {
+          "$ref": "#/$defs/mode"
+        }
+      },
+      "type": "object",
+      "x-table": "files"
+    },
+    "mode": {
+      "type": "integer",
+      "x-flags": {
+        "read": 1,
+        "write": 2
+      }
+    }
+  },
+  "items": {
+    "$ref": "#/$defs/file",
+    "x-order": 1
+  },
+  "type": "array",
+  "x-version": 2
+}`)
+
+	generated := NewGenerator().WithRoot(&ExampleJSONSettlement{}).MustGenerate()
+	var read JSONSchema
+	c.Assert(json.Unmarshal([]byte(generated.String()), &read), IsNil)
+	c.Assert(read.String(), Equals, generated.String())
+	c.Assert(read.Properties["paid"].Extensions, DeepEquals, map[string]interface{}{TimeEncodingExtension: "unix"})
+}
+
+func (self *propertySuite) TestUnmarshalRoundTrip(c *C) {
+	for _, schema := range []string{
+		`{"enum":[1,"a",null,true,{"b":[2]}]}`,
+		`{"type":"object","additionalProperties":false,"properties":{"a":{"type":"string"}}}`,
+		`{"type":"object","additionalProperties":true}`,
+		`{"type":"object","additionalProperties":{"type":"integer"}}`,
+	} {
+		var p Property
+		c.Assert(json.Unmarshal([]byte(schema), &p), IsNil, Commentf("%s", schema))
+		b, err := json.Marshal(p)
+		c.Assert(err, IsNil)
+		c.Check(string(b), Equals, schema)
+	}
+
+	var p Property
+	c.Assert(json.Unmarshal([]byte(`{"additionalProperties":false}`), &p), IsNil)
+	c.Assert(p.NoAdditionalProperties, Equals, true)
+	c.Assert(json.Unmarshal([]byte(`{"enum":[1,2]}`), &p), IsNil)
+	c.Assert(p.Enum, DeepEquals, []interface{}{float64(1), float64(2)})
+}
+
+func (self *propertySuite) TestIntegerFormats(c *C) {
+	j := NewGenerator(Options{IntegerFormats: true}).WithRoot(&ExampleJSONBasic{}).MustGenerate()
+
+	c.Assert(j.Properties["Integer"], DeepEquals, &Property{Type: "integer", Format: "int64"})
+	c.Assert(j.Properties["Integer8"], DeepEquals, &Property{Type: "integer", Format: "int32"})
+	c.Assert(j.Properties["Integer32"], DeepEquals, &Property{Type: "integer", Format: "int32"})
+	c.Assert(j.Properties["Integer64"], DeepEquals, &Property{Type: "integer", Format: "int64"})
+	c.Assert(j.Properties["UInteger16"], DeepEquals, &Property{Type: "integer", Format: "int32", Minimum: float64ptr(0)})
+	c.Assert(j.Properties["UInteger32"], DeepEquals, &Property{Type: "integer", Format: "int64", Minimum: float64ptr(0)})
+	c.Assert(j.Properties["Float64"], DeepEquals, &Property{Type: "number"})
+	c.Assert(j.Properties["Bytes"], DeepEquals, &Property{Type: "string"})
+
+	j = NewGenerator().WithRoot(&ExampleJSONBasic{}).MustGenerate()
+	c.Assert(j.Properties["UInteger32"], DeepEquals, &Property{Type: "integer"})
+}
+
+func (self *propertySuite) TestIntegerBounds(c *C) {
+	j := NewGenerator(Options{IntegerBounds: true}).WithRoot(&ExampleJSONBasic{}).MustGenerate()
+
+	c.Assert(j.Properties["Integer"], DeepEquals, &Property{Type: "integer"})
+	c.Assert(j.Properties["Integer8"], DeepEquals, &Property{Type: "integer", Minimum: float64ptr(-128), Maximum: float64ptr(127)})
+	c.Assert(j.Properties["Integer32"], DeepEquals, &Property{Type: "integer", Minimum: float64ptr(-2147483648), Maximum: float64ptr(2147483647)})
+	c.Assert(j.Properties["UInteger"], DeepEquals, &Property{Type: "integer", Minimum: float64ptr(0)})
+	c.Assert(j.Properties["UInteger16"], DeepEquals, &Property{Type: "integer", Minimum: float64ptr(0), Maximum: float64ptr(65535)})
+	c.Assert(j.Properties["UInteger64"], DeepEquals, &Property{Type: "integer", Minimum: float64ptr(0)})
+}
+
+type ExampleJSONBoundedTags struct {
+	Percent uint8 `json:"percent" max:"100"`
+}
+
+func (self *propertySuite) TestIntegerBoundsWithTags(c *C) {
+	j := NewGenerator(Options{IntegerBounds: true}).WithRoot(&ExampleJSONBoundedTags{}).MustGenerate()
+
+	c.Assert(j.Properties["percent"], DeepEquals, &Property{Type: "integer", Minimum: float64ptr(0), Maximum: float64ptr(100)})
+}
+
+type ExampleJSONMsgpack struct {
+	UserID  string `msgpack:"user_id" json:"userId" required:"true"`
+	Comment string `msgpack:"comment,omitempty" required:"true"`
+	Secret  string `msgpack:"-"`
+	Count   int
+}
+
+func (self *propertySuite) TestJSONTagName(c *C) {
+	j := NewGenerator(Options{JSONTagName: "msgpack"}).WithRoot(&ExampleJSONMsgpack{}).MustGenerate()
+
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{"Count", "comment", "user_id"})
+	c.Assert(j.Required, DeepEquals, []string{"user_id"})
+
+	j = NewGenerator().WithRoot(&ExampleJSONMsgpack{}).MustGenerate()
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{"Comment", "Count", "Secret", "userId"})
+}
+
+type ExampleJSONMapItem struct {
+	Name string `json:"name"`
+}
+
+type ExampleJSONMapOfDefinitions struct {
+	Items    map[string]ExampleJSONMapItem   `json:"items"`
+	Pointers map[string]*ExampleJSONMapItem  `json:"pointers"`
+	Inline   map[string]ExampleJSONBasicMaps `json:"inline"`
+}
+
+func (self *propertySuite) TestMapOfDefinitions(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONMapOfDefinitions{}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		MustGenerate()
+
+	c.Assert(j.Properties["items"], DeepEquals, &Property{
+		Type:                       "object",
+		AdditionalPropertiesSchema: &Property{Ref: "#/definitions/item"},
+	})
+	c.Assert(j.Properties["pointers"].AdditionalPropertiesSchema, DeepEquals, &Property{Ref: "#/definitions/item"})
+	c.Assert(j.Properties["inline"].AdditionalPropertiesSchema.Properties["Maps"], DeepEquals, &Property{
+		Type:       "object",
+		Properties: map[string]*Property{".*": {Type: "string"}},
+	})
+
+	b, err := json.Marshal(j.Properties["items"])
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"type":"object","additionalProperties":{"$ref":"#/definitions/item"}}`)
+
+	var k JSONSchema
+	c.Assert(json.Unmarshal([]byte(j.String()), &k), IsNil)
+	c.Assert(k.Properties["items"].AdditionalPropertiesSchema, DeepEquals, &Property{Ref: "#/definitions/item"})
+	c.Assert(k.String(), Equals, j.String())
+}
+
+type ExampleJSONDeeplyNested struct {
+	Matrix  [][]ExampleJSONMapItem                     `json:"matrix"`
+	Groups  map[string][]*ExampleJSONMapItem           `json:"groups"`
+	Indexes []map[string]ExampleJSONMapItem            `json:"indexes"`
+	Pair    [2]ExampleJSONMapItem                      `json:"pair"`
+	Nested  map[string]map[string][]ExampleJSONMapItem `json:"nested"`
+	Digest  [4]byte                                    `json:"digest"`
+}
+
+func (self *propertySuite) TestDeeplyNestedDefinitions(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONDeeplyNested{}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		MustGenerate()
+
+	ref := &Property{Ref: "#/definitions/item"}
+	c.Assert(j.Properties["matrix"], DeepEquals, &Property{Type: "array", Items: &Property{Type: "array", Items: ref}})
+	c.Assert(j.Properties["groups"], DeepEquals, &Property{Type: "object",
+		AdditionalPropertiesSchema: &Property{Type: "array", Items: ref}})
+	c.Assert(j.Properties["indexes"], DeepEquals, &Property{Type: "array",
+		Items: &Property{Type: "object", AdditionalPropertiesSchema: ref}})
+	c.Assert(j.Properties["pair"], DeepEquals, &Property{Type: "array", Items: ref})
+	c.Assert(j.Properties["nested"], DeepEquals, &Property{Type: "object",
+		AdditionalPropertiesSchema: &Property{Type: "object",
+			AdditionalPropertiesSchema: &Property{Type: "array", Items: ref}}})
+	c.Assert(j.Properties["digest"], DeepEquals, &Property{Type: "array", Items: &Property{Type: "integer"}})
+}
+
+func (self *propertySuite) TestDefinitionFor(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONMapOfDefinitions{}).
+		WithDefinition("item", &ExampleJSONMapItem{}).
+		WithDefinitionAlias("legacyItem", "item").
+		MustGenerate()
+
+	name, ok := j.DefinitionFor(reflect.TypeOf(ExampleJSONMapItem{}))
+	c.Assert(ok, Equals, true)
+	c.Assert(name, Equals, "item")
+	name, ok = j.DefinitionFor(reflect.TypeOf(&ExampleJSONMapItem{}))
+	c.Assert(name, Equals, "item")
+
+	_, ok = j.DefinitionFor(reflect.TypeOf(ExampleJSONBasicMaps{}))
+	c.Assert(ok, Equals, false)
+
+	c.Assert(j.DefinitionTypes(), DeepEquals, map[string]reflect.Type{
+		"item": reflect.TypeOf(ExampleJSONMapItem{}),
+	})
+
+	s := NewSchema(j).WithoutDefinition("item")
+	_, ok = s.JSONSchema().DefinitionFor(reflect.TypeOf(ExampleJSONMapItem{}))
+	c.Assert(ok, Equals, false)
+}
+
+type ExampleJSONSchemaDocument struct {
+	Name     string                `json:"name"`
+	Schema   *JSONSchema           `json:"schema"`
+	Versions map[string]JSONSchema `json:"versions"`
+}
+
+func (self *propertySuite) TestMarshalEmbedded(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONMapItem{}).
+		WithDefinition("b", ExampleJSONMapItem{}).
+		WithDefinitionAlias("a", "b").
+		MustGenerate()
+	j.ID = "https://example.com/item.json"
+	j.Extensions = map[string]interface{}{"x-owner": "team"}
+
+	b, err := json.Marshal(ExampleJSONSchemaDocument{
+		Name:     "item",
+		Schema:   j,
+		Versions: map[string]JSONSchema{"v1": *j},
+	})
+	c.Assert(err, IsNil)
+
+	schema := `{"$schema":"http://json-schema.org/schema#","$id":"https://example.com/item.json",` +
+		`"definitions":{"a":{"$ref":"#/definitions/b"},"b":{"type":"object","properties":{"name":{"type":"string"}}}},` +
+		`"$ref":"#/definitions/b","x-owner":"team"}`
+	c.Assert(string(b), Equals, `{"name":"item","schema":`+schema+`,"versions":{"v1":`+schema+`}}`)
+
+	var k ExampleJSONSchemaDocument
+	c.Assert(json.Unmarshal(b, &k), IsNil)
+	c.Assert(k.Schema.ID, Equals, j.ID)
+	c.Assert(k.Versions["v1"].Definitions["a"].Ref, Equals, "#/definitions/b")
+}
+
+func (self *propertySuite) TestMarshalEmpty(c *C) {
+	b, err := json.Marshal(JSONSchema{})
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "{}")
+
+	b, err = json.Marshal(JSONSchema{Schema: DEFAULT_SCHEMA})
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"$schema":"http://json-schema.org/schema#"}`)
+}
+
+type ExampleJSONTree struct {
+	Value    string            `json:"value"`
+	Children []ExampleJSONTree `json:"children"`
+}
+
+type ExampleJSONLinkedList struct {
+	Value int                    `json:"value"`
+	Next  *ExampleJSONLinkedList `json:"next"`
+}
+
+func (self *propertySuite) TestUnrollRecursion(c *C) {
+	j := NewGenerator(Options{UnrollRecursion: 2}).WithRoot(&ExampleJSONTree{}).MustGenerate()
+	child := j.Properties["children"].Items
+	c.Assert(child.Properties["value"], DeepEquals, &Property{Type: "string"})
+	grandchild := child.Properties["children"].Items
+	c.Assert(grandchild.Properties["value"], DeepEquals, &Property{Type: "string"})
+	c.Assert(grandchild.Properties["children"].Items, DeepEquals, &Property{Type: "object"})
+
+	// definitions are unrolled rather than referencing themselves
+	j = NewGenerator(Options{UnrollRecursion: 1}).
+		WithRoot(&ExampleJSONLinkedList{}).
+		WithDefinition("list", ExampleJSONLinkedList{}).
+		MustGenerate()
+	c.Assert(j.Ref, Equals, "#/definitions/list")
+	next := j.Definitions["list"].Properties["next"]
+	c.Assert(next.Ref, Equals, "")
+	c.Assert(next.Properties["value"], DeepEquals, &Property{Type: "integer"})
+	c.Assert(next.Properties["next"], DeepEquals, &Property{Type: "object"})
+	_, err := j.Inline()
+	c.Assert(err, IsNil)
+
+	j = NewGenerator().
+		WithRoot(&ExampleJSONLinkedList{}).
+		WithDefinition("list", ExampleJSONLinkedList{}).
+		MustGenerate()
+	c.Assert(j.Definitions["list"].Properties["next"].Ref, Equals, "#/definitions/list")
+}
+
+func (self *propertySuite) TestDefineRecursiveTypes(c *C) {
+	// by default recursive types are promoted to definitions
+	// and the root references its definition rather than repeating it
+	j := NewGenerator().WithRoot(&ExampleJSONTree{}).MustGenerate()
+	c.Assert(j.Property, DeepEquals, Property{Ref: "#/definitions/exampleJSONTree"})
+	c.Assert(j.Definitions, HasLen, 1)
+	tree := j.Definitions["exampleJSONTree"]
+	c.Assert(tree.Properties["value"], DeepEquals, &Property{Type: "string"})
+	c.Assert(tree.Properties["children"].Items.Ref, Equals, "#/definitions/exampleJSONTree")
+	name, ok := j.DefinitionFor(reflect.TypeOf(ExampleJSONTree{}))
+	c.Assert(ok, Equals, true)
+	c.Assert(name, Equals, "exampleJSONTree")
+
+	// names aren't taken from registered definitions
+	j = NewGenerator(Options{OnlyReferencedDefinitions: true}).
+		WithRoot(&ExampleJSONLinkedList{}).
+		WithDefinition("exampleJSONLinkedList", ExampleJSONTree{}).
+		MustGenerate()
+	c.Assert(j.Ref, Equals, "#/definitions/exampleJSONLinkedList2")
+	c.Assert(j.Definitions["exampleJSONLinkedList2"].Properties["next"].Ref, Equals, "#/definitions/exampleJSONLinkedList2")
+	c.Assert(j.Definitions, HasLen, 1)
+
+	// unrolled types end with any object, or with a $ref once defined
+	j = NewGenerator(Options{UnrollRecursion: 1}).WithRoot(&ExampleJSONLinkedList{}).MustGenerate()
+	c.Assert(j.Properties["next"].Properties["next"], DeepEquals, &Property{Type: "object"})
+	j = NewGenerator(Options{DefineRecursiveTypes: true, UnrollRecursion: 1}).WithRoot(&ExampleJSONLinkedList{}).MustGenerate()
+	c.Assert(j.Properties["next"].Properties["next"].Ref, Equals, "#/definitions/exampleJSONLinkedList")
+	errs, err := j.Validate([]byte(`{"value": 1, "next": {"value": 2, "next": {"value": 3, "next": {"value": "4"}}}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+}
+
 func findDiff(a, b string) string {
 	var index int
 	var different bool
@@ -436,3 +898,42 @@ func getNearby(a string, index int) string {
 
 	return a[int(min):int(max)]
 }
+
+type ExampleJSONUserID string
+
+func (ExampleJSONUserID) ModifySchema(p *Property) {
+	p.Pattern = "^u-[0-9]+$"
+}
+
+type ExampleJSONCount int
+
+type ExampleJSONMembership struct {
+	User    ExampleJSONUserID   `json:"user" description:"The member."`
+	Friends []ExampleJSONUserID `json:"friends"`
+	Sponsor *ExampleJSONUserID  `json:"sponsor"`
+	Visits  *ExampleJSONCount   `json:"visits"`
+}
+
+func (self *propertySuite) TestReferencePrimitives(c *C) {
+	j := NewGenerator(Options{ReferencePrimitives: true}).
+		WithDefinition("userID", ExampleJSONUserID("")).
+		WithDefinition("count", ExampleJSONCount(0)).
+		WithRoot(&ExampleJSONMembership{}).
+		MustGenerate()
+	c.Assert(j.Definitions["count"], DeepEquals, Property{Type: "integer"})
+	c.Assert(j.Definitions["userID"], DeepEquals, Property{Type: "string", Pattern: "^u-[0-9]+$"})
+	c.Assert(j.Properties["user"], DeepEquals, &Property{Ref: "#/definitions/userID", Description: "The member."})
+	c.Assert(j.Properties["friends"].Items, DeepEquals, &Property{Ref: "#/definitions/userID"})
+	c.Assert(j.Properties["sponsor"], DeepEquals, &Property{AnyOf: []*Property{
+		{Ref: "#/definitions/userID"},
+		{Type: "null"},
+	}})
+	c.Assert(j.Properties["visits"], DeepEquals, &Property{Ref: "#/definitions/count"})
+
+	// inline by default
+	j = NewGenerator().
+		WithDefinition("userID", ExampleJSONUserID("")).
+		WithRoot(&ExampleJSONMembership{}).
+		MustGenerate()
+	c.Assert(j.Properties["user"], DeepEquals, &Property{Type: "string", Pattern: "^u-[0-9]+$", Description: "The member."})
+}
@@ -1,6 +1,15 @@
 module github.com/naveego/go-json-schema
 
+go 1.23.0
+
 require (
-	github.com/kr/pretty v0.1.0 // indirect
+	golang.org/x/tools v0.34.0
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127
 )
+
+require (
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	golang.org/x/mod v0.25.0 // indirect
+	golang.org/x/sync v0.15.0 // indirect
+)
@@ -10,3 +10,8 @@ for d in $(go list ./... | grep -v vendor); do
         rm profile.out
     fi
 done
+
+# the core generator builds for WebAssembly, and without the packages left
+# out of TinyGo builds; .travis.yml builds the example with TinyGo itself
+GOOS=js GOARCH=wasm go build .
+go vet -tags tinygo .
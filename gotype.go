@@ -0,0 +1,85 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// goType gives the reader access to a Go type, read by reflection from
+// values or read from source, so that both are described the same way.
+type goType interface {
+	// Kind returns the kind of the type.
+	Kind() reflect.Kind
+	// Name returns the name of the type qualified by the import path of its
+	// package, as types.TypeString does, or "" if the type isn't named.
+	Name() string
+	// String returns the type, for traces and errors.
+	String() string
+	// Elem returns the type of the elements of arrays, slices and maps, or
+	// of the values pointers point to.
+	Elem() goType
+	// NumField returns the number of fields of a struct.
+	NumField() int
+	// Field returns the i-th field of a struct.
+	Field(i int) goField
+	// Doc returns the doc comment of the type, or "" if it isn't known.
+	Doc() string
+	// Instance returns a pointer to a zero value of the type, to call its
+	// methods, or nil if the type has no values, e.g. read from source.
+	Instance() interface{}
+	// Key identifies the type in maps.
+	Key() interface{}
+}
+
+// goField is a field of a struct type.
+type goField struct {
+	Name     string
+	Exported bool
+	Tag      reflect.StructTag
+	Type     goType
+	// Doc is the doc comment of the field, or "" if it isn't known.
+	Doc string
+}
+
+// reflectType is a goType read by reflection.
+type reflectType struct {
+	reflect.Type
+}
+
+func (t reflectType) Name() string {
+	return typeName(t.Type)
+}
+
+func (t reflectType) Elem() goType {
+	return reflectType{t.Type.Elem()}
+}
+
+func (t reflectType) Field(i int) goField {
+	field := t.Type.Field(i)
+	return goField{Name: field.Name, Exported: field.PkgPath == "", Tag: field.Tag, Type: reflectType{field.Type}}
+}
+
+func (t reflectType) Doc() string {
+	return ""
+}
+
+func (t reflectType) Instance() interface{} {
+	return reflect.New(t.Type).Interface()
+}
+
+func (t reflectType) Key() interface{} {
+	return t.Type
+}
+
+// shortName returns the name of the named type t without the import path of
+// its package, nor its type arguments, e.g. "Node" for example.com/tree.Node.
+func shortName(t goType) string {
+	name := t.Name()
+	if name == "" {
+		return t.String()
+	}
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
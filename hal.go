@@ -0,0 +1,146 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// HALLink is a link of a HAL resource.
+type HALLink struct {
+	Href        string `json:"href" required:"true" description:"The URI or URI template of the target."`
+	Templated   bool   `json:"templated,omitempty" description:"Whether href is a URI template."`
+	Type        string `json:"type,omitempty" description:"The media type of the target."`
+	Deprecation string `json:"deprecation,omitempty" description:"A URL explaining the deprecation of the link."`
+	Name        string `json:"name,omitempty" description:"A secondary key of the links of the same relation."`
+	Profile     string `json:"profile,omitempty" description:"A URI of the profile of the target."`
+	Title       string `json:"title,omitempty" description:"The title of the link."`
+	Hreflang    string `json:"hreflang,omitempty" description:"The language of the target."`
+}
+
+// HALLinks holds the links of a HAL resource, the _links property, by their
+// relation: a HALLink or a slice of them. The relations of a field of type
+// HALLinks may be listed by the links tag, e.g. `links:"self|next|items[]"`,
+// where relations ending with [] hold several links.
+type HALLinks map[string]interface{}
+
+// ModifySchema describes the links of any relation.
+func (HALLinks) ModifySchema(p *Property) {
+	p.addHALLinks(nil)
+}
+
+var rTypeHALLinks = reflect.TypeOf(HALLinks{})
+
+// halLink returns the schema of HAL links.
+func halLink() *Property {
+	r := &reader{options: NewGenerator().options, visiting: map[interface{}]int{}}
+	n := newNode()
+	r.readFromStruct(n, reflectType{reflect.TypeOf(HALLink{})})
+	return r.schema(n)
+}
+
+// addHALLinks describes the links of a HAL resource with the given relations,
+// and links of other relations, by p.
+func (p *Property) addHALLinks(relations []string) {
+	link := halLink()
+	p.Type = "object"
+	p.Properties = nil
+	p.AdditionalProperties = false
+	p.AdditionalPropertiesSchema = &Property{AnyOf: []*Property{link, {Type: "array", Items: link}}}
+	for _, relation := range relations {
+		if p.Properties == nil {
+			p.Properties = map[string]*Property{}
+		}
+		if name := strings.TrimSuffix(relation, "[]"); name != relation {
+			p.Properties[name] = &Property{Type: "array", Items: link}
+		} else {
+			p.Properties[name] = link
+		}
+	}
+}
+
+func (n *Node) addLinksFromTags(tag *reflect.StructTag, t goType) error {
+	relations, ok := tag.Lookup("links")
+	if !ok {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() != typeName(rTypeHALLinks) {
+		return fmt.Errorf(`"links" tag on %s, which is not HALLinks`, t)
+	}
+	if relations == "" {
+		return fmt.Errorf(`empty "links" tag`)
+	}
+	p := &Property{}
+	p.addHALLinks(strings.Split(relations, "|"))
+	*n = *parseNode(p)
+	return nil
+}
+
+// halEmitter emits the HAL representations of the objects described by the
+// root and the definitions of the schema: their properties referencing
+// definitions are embedded resources, under _embedded, and their links are
+// described under _links, unless the objects have a _links property.
+type halEmitter struct{}
+
+func (e halEmitter) Emit(model *Property) ([]byte, error) {
+	return e.EmitDocument(&JSONSchema{Property: *model})
+}
+
+func (e halEmitter) EmitDocument(schema *JSONSchema) ([]byte, error) {
+	toHAL(&schema.Property)
+	for name, def := range schema.Definitions {
+		toHAL(&def)
+		schema.Definitions[name] = def
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// toHAL rewrites the object described by p as a HAL resource.
+func toHAL(p *Property) {
+	if p.Type != "object" || p.Properties == nil {
+		return
+	}
+	if _, ok := p.Properties["_links"]; !ok {
+		links := &Property{}
+		links.addHALLinks([]string{"self"})
+		links.Required = []string{"self"}
+		p.Properties["_links"] = links
+	}
+	if _, ok := p.Properties["_embedded"]; ok {
+		return
+	}
+
+	embedded := &Property{Type: "object", Properties: map[string]*Property{}}
+	names := sortedPropertyNames(p.Properties)
+	for _, name := range names {
+		s := p.Properties[name]
+		resource := s.Ref != "" || s.Type == "array" && s.Items != nil && s.Items.Ref != ""
+		if i, ok := nullableBranch(s.AnyOf); ok {
+			resource = s.AnyOf[i].Ref != ""
+		}
+		if !resource || name == "_links" {
+			continue
+		}
+		embedded.Properties[name] = s
+		delete(p.Properties, name)
+		for i, required := range p.Required {
+			if required == name {
+				p.Required = append(p.Required[:i:i], p.Required[i+1:]...)
+				embedded.Required = append(embedded.Required, name)
+				break
+			}
+		}
+	}
+	if len(embedded.Properties) > 0 {
+		sort.Strings(embedded.Required)
+		p.Properties["_embedded"] = embedded
+		if len(embedded.Required) > 0 {
+			p.Required = append(p.Required, "_embedded")
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type halSuite struct{}
+
+var _ = Suite(&halSuite{})
+
+type ExampleHALOrder struct {
+	Links    HALLinks            `json:"_links" links:"self|customer|items[]"`
+	Total    float64             `json:"total" required:"true"`
+	Customer *ExampleHALCustomer `json:"customer" required:"true"`
+	Items    []ExampleHALItem    `json:"items"`
+}
+
+type ExampleHALCustomer struct {
+	Name string `json:"name"`
+}
+
+type ExampleHALItem struct {
+	SKU string `json:"sku"`
+}
+
+type ExampleHALInvalidLinks struct {
+	Links map[string]string `json:"_links" links:"self"`
+}
+
+func (self *halSuite) TestLinks(c *C) {
+	j := NewGenerator().WithRoot(&ExampleHALOrder{}).MustGenerate()
+
+	link := j.Properties["_links"].Properties["self"]
+	c.Assert(link.Required, DeepEquals, []string{"href"})
+	c.Assert(sortedPropertyNames(j.Properties["_links"].Properties), DeepEquals, []string{"customer", "items", "self"})
+	c.Assert(j.Properties["_links"].Properties["items"], DeepEquals, &Property{Type: "array", Items: link})
+	c.Assert(j.Properties["_links"].AdditionalPropertiesSchema.AnyOf, HasLen, 2)
+
+	v, err := NewValidator(j)
+	c.Assert(err, IsNil)
+	errs, err := v.Validate([]byte(`{"_links": {"self": {"href": "/orders/1"}, "items": [{"href": "/items/1"}], "next": [{"href": "/orders/2"}]}, "total": 1, "customer": {}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	errs, err = v.Validate([]byte(`{"_links": {"self": {"title": "no href"}}, "total": 1, "customer": {}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+
+	_, err = NewGenerator().WithRoot(&ExampleHALInvalidLinks{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Links:"links" tag on map\[string\]string, which is not HALLinks`)
+}
+
+func (self *halSuite) TestDialect(c *C) {
+	j := NewGenerator().
+		WithDefinition("customer", ExampleHALCustomer{}).
+		WithDefinition("item", ExampleHALItem{}).
+		WithRoot(&ExampleHALOrder{}).
+		MustGenerate()
+
+	b, err := j.Emit(DialectHAL)
+	c.Assert(err, IsNil)
+	var hal JSONSchema
+	c.Assert(json.Unmarshal(b, &hal), IsNil)
+
+	// the links of the order are kept, the resources referenced are embedded
+	c.Assert(hal.Properties["_links"], DeepEquals, j.Properties["_links"])
+	c.Assert(sortedPropertyNames(hal.Properties), DeepEquals, []string{"_embedded", "_links", "total"})
+	c.Assert(hal.Required, DeepEquals, []string{"total", "_embedded"})
+	embedded := hal.Properties["_embedded"]
+	c.Assert(embedded.Required, DeepEquals, []string{"customer"})
+	c.Assert(embedded.Properties["customer"], DeepEquals, &Property{Ref: "#/definitions/customer"})
+	c.Assert(embedded.Properties["items"], DeepEquals, &Property{Type: "array", Items: &Property{Ref: "#/definitions/item"}})
+
+	// definitions are resources with a self link
+	customer := hal.Definitions["customer"]
+	c.Assert(sortedPropertyNames(customer.Properties), DeepEquals, []string{"_links", "name"})
+	c.Assert(customer.Properties["_links"].Required, DeepEquals, []string{"self"})
+}
@@ -0,0 +1,229 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HintKind identifies the data migration suggested by a MigrationHint.
+type HintKind string
+
+const (
+	// HintRenamed suggests that a removed property was renamed to an added
+	// one, so that its values can be moved.
+	HintRenamed HintKind = "renamed"
+	// HintDropped suggests that the values of a removed property be dropped.
+	HintDropped HintKind = "dropped"
+	// HintBackfill suggests that a value be backfilled in the documents
+	// without a newly required property.
+	HintBackfill HintKind = "backfill"
+	// HintTypeNarrowed suggests that the values which don't have the
+	// narrower type be converted, e.g. numbers which aren't integers.
+	HintTypeNarrowed HintKind = "type-narrowed"
+	// HintTypeConverted suggests that all the values be converted to the new
+	// type, e.g. integers to strings.
+	HintTypeConverted HintKind = "type-converted"
+	// HintRevalidate suggests that the values be checked against a
+	// tightened or changed constraint, and fixed.
+	HintRevalidate HintKind = "revalidate"
+)
+
+// RenameThreshold is the similarity from which a removed and an added
+// property of the same object are hinted as a rename.
+const RenameThreshold = 0.6
+
+// MigrationHint is a machine-readable suggestion of how to migrate the
+// documents of a schema, for a breaking change to a new version.
+type MigrationHint struct {
+	Kind HintKind `json:"kind"`
+	// Path is the JSON pointer of the element in the old schema, or of the
+	// required property in the new schema for backfills.
+	Path string `json:"path"`
+	// NewPath is the JSON pointer of the property renamed in the new schema.
+	NewPath string `json:"newPath,omitempty"`
+	// Similarity is the likelihood of a rename, from RenameThreshold to 1,
+	// after the names and the schemas of the properties.
+	Similarity float64 `json:"similarity,omitempty"`
+	// Keyword is the keyword which changed, for revalidations.
+	Keyword string `json:"keyword,omitempty"`
+	// Old and New are the old and new types, names or keyword values, and New
+	// the default of the property, if any, for backfills.
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+func (h MigrationHint) String() string {
+	switch h.Kind {
+	case HintRenamed:
+		return fmt.Sprintf("%s: renamed? old %v new %v similarity %.2f", h.Path, h.Old, h.New, h.Similarity)
+	case HintDropped:
+		return fmt.Sprintf("%s: dropped", h.Path)
+	case HintBackfill:
+		if h.New != nil {
+			return fmt.Sprintf("%s: backfill with %s", h.Path, describeValue(h.New))
+		}
+		return fmt.Sprintf("%s: backfill", h.Path)
+	case HintTypeNarrowed:
+		return fmt.Sprintf("%s: type narrowed %s→%s", h.Path, describeValue(h.Old), describeValue(h.New))
+	case HintTypeConverted:
+		return fmt.Sprintf("%s: type converted %s→%s", h.Path, describeValue(h.Old), describeValue(h.New))
+	}
+	return fmt.Sprintf("%s: revalidate %s", h.Path, h.Keyword)
+}
+
+// MigrationHints returns hints on migrating the documents of the old schema
+// to the new one, for the breaking changes reported by Diff, sorted by path.
+// A removed property is hinted as renamed to the added property of the same
+// object most similar to it, if any reaches RenameThreshold.
+func MigrationHints(old, new *JSONSchema) []MigrationHint {
+	d := &differ{}
+	d.definitions("/definitions", old.Definitions, new.Definitions)
+	d.property("", &old.Property, &new.Property)
+
+	sort.SliceStable(d.hints, func(i, j int) bool {
+		return d.hints[i].Path < d.hints[j].Path
+	})
+	return d.hints
+}
+
+func (d *differ) hint(h MigrationHint) {
+	d.hints = append(d.hints, h)
+}
+
+// typeHint hints at the conversion of the values of a property whose types
+// changed, unless the new types accept all the old ones.
+func (d *differ) typeHint(path string, old, new []string) {
+	narrowed, widened := true, true
+	for _, t := range new {
+		narrowed = narrowed && acceptsType(old, t)
+	}
+	for _, t := range old {
+		widened = widened && acceptsType(new, t)
+	}
+	kind := HintTypeConverted
+	switch {
+	case widened:
+		return
+	case narrowed:
+		kind = HintTypeNarrowed
+	}
+	d.hint(MigrationHint{Kind: kind, Path: path, Old: hintTypes(old), New: hintTypes(new)})
+}
+
+// acceptsType reports whether values of type t have one of the types, where
+// no type accepts any value.
+func acceptsType(types []string, t string) bool {
+	for _, accepted := range types {
+		if accepted == "" || accepted == t || accepted == "number" && t == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// hintTypes returns a single type as a string, and none as nil.
+func hintTypes(types []string) interface{} {
+	switch {
+	case len(types) == 1 && types[0] == "":
+		return nil
+	case len(types) == 1:
+		return types[0]
+	}
+	return types
+}
+
+// renameHints pairs the removed and added properties of an object by
+// decreasing similarity, hinting the remaining removed ones as dropped.
+func (d *differ) renameHints(path string, removed, added map[string]*Property) {
+	type candidate struct {
+		old, new   string
+		similarity float64
+	}
+	var candidates []candidate
+	for _, o := range sortedPropertyNames(removed) {
+		for _, n := range sortedPropertyNames(added) {
+			if s := renameSimilarity(o, n, removed[o], added[n]); s >= RenameThreshold {
+				candidates = append(candidates, candidate{o, n, s})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].similarity > candidates[j].similarity
+	})
+
+	renamed := map[string]bool{}
+	for _, c := range candidates {
+		if renamed[c.old] || renamed[c.new] {
+			continue
+		}
+		renamed[c.old], renamed[c.new] = true, true
+		d.hint(MigrationHint{
+			Kind:       HintRenamed,
+			Path:       path + "/" + escapePointer(c.old),
+			NewPath:    path + "/" + escapePointer(c.new),
+			Similarity: c.similarity,
+			Old:        c.old,
+			New:        c.new,
+		})
+	}
+	for _, name := range sortedPropertyNames(removed) {
+		if !renamed[name] {
+			d.hint(MigrationHint{Kind: HintDropped, Path: path + "/" + escapePointer(name)})
+		}
+	}
+}
+
+// renameSimilarity averages the similarity of the names of two properties,
+// ignoring case and separators, with that of their schemas: 1 when equal,
+// 0.5 for the same types.
+func renameSimilarity(oldName, newName string, old, new *Property) float64 {
+	o, n := normalizeName(oldName), normalizeName(newName)
+	longest := len(o)
+	if len(n) > longest {
+		longest = len(n)
+	}
+	names := 1.0
+	if longest > 0 {
+		names = 1 - float64(editDistance(o, n))/float64(longest)
+	}
+	var schemas float64
+	switch {
+	case jsonEqual(old, new):
+		schemas = 1
+	case strings.Join(typeList(old), ",") == strings.Join(typeList(new), ","):
+		schemas = 0.5
+	}
+	return (names + schemas) / 2
+}
+
+func normalizeName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,87 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// SchemaInstanceMediaType is the media type of JSON documents described by a
+// schema, which carries the schema URL in its "schema" parameter.
+const SchemaInstanceMediaType = "application/schema-instance+json"
+
+// DescribedByLink returns the value of a Link header pointing to the schema
+// describing the response, e.g. `<https://example.com/domain.json>; rel="describedby"`.
+func DescribedByLink(schemaURL string) string {
+	return fmt.Sprintf(`<%s>; rel="describedby"`, schemaURL)
+}
+
+// SchemaInstanceContentType returns the Content-Type of a document described
+// by the schema at schemaURL, using the schema-instance media type.
+func SchemaInstanceContentType(schemaURL string) string {
+	return fmt.Sprintf(`%s; schema=%s`, SchemaInstanceMediaType, quoteParameter(schemaURL))
+}
+
+// ProfileContentType returns mediaType with a profile parameter pointing to
+// the schema at schemaURL, e.g. `application/json; profile="https://..."`.
+func ProfileContentType(mediaType, schemaURL string) string {
+	return fmt.Sprintf(`%s; profile=%s`, mediaType, quoteParameter(schemaURL))
+}
+
+func quoteParameter(v string) string {
+	v = strings.Replace(v, `\`, `\\`, -1)
+	v = strings.Replace(v, `"`, `\"`, -1)
+	return `"` + v + `"`
+}
+
+// SchemaLinks maps root types to the URLs their schemas are published at,
+// so that HTTP handlers can advertise the schema of the value they return.
+// It is safe for concurrent use.
+type SchemaLinks struct {
+	mu   sync.RWMutex
+	urls map[reflect.Type]string
+}
+
+// NewSchemaLinks returns an empty SchemaLinks.
+func NewSchemaLinks() *SchemaLinks {
+	return &SchemaLinks{urls: map[reflect.Type]string{}}
+}
+
+// Register associates the type of root with the URL of its schema.
+// root may be an instance of the type or its reflect.Type.
+func (l *SchemaLinks) Register(root interface{}, schemaURL string) *SchemaLinks {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.urls == nil {
+		l.urls = map[reflect.Type]string{}
+	}
+	l.urls[linkType(root)] = schemaURL
+	return l
+}
+
+// URL returns the schema URL registered for the type of v.
+func (l *SchemaLinks) URL(v interface{}) (string, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	u, ok := l.urls[linkType(v)]
+	return u, ok
+}
+
+// Attach adds a describedby Link header for the schema of v to h, and sets
+// the Content-Type to the schema-instance media type if it is not already set.
+// It reports whether a schema was registered for the type of v.
+func (l *SchemaLinks) Attach(h http.Header, v interface{}) bool {
+	u, ok := l.URL(v)
+	if !ok {
+		return false
+	}
+	h.Add("Link", DescribedByLink(u))
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", SchemaInstanceContentType(u))
+	}
+	return true
+}
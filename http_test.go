@@ -0,0 +1,44 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"net/http"
+	"reflect"
+
+	. "gopkg.in/check.v1"
+)
+
+type httpSuite struct{}
+
+var _ = Suite(&httpSuite{})
+
+func (self *httpSuite) TestHeaderValues(c *C) {
+	c.Assert(DescribedByLink("https://example.com/domain.json"), Equals,
+		`<https://example.com/domain.json>; rel="describedby"`)
+	c.Assert(SchemaInstanceContentType("https://example.com/domain.json"), Equals,
+		`application/schema-instance+json; schema="https://example.com/domain.json"`)
+	c.Assert(ProfileContentType("application/json", "https://example.com/domain.json"), Equals,
+		`application/json; profile="https://example.com/domain.json"`)
+}
+
+func (self *httpSuite) TestSchemaLinks(c *C) {
+	links := NewSchemaLinks().
+		Register(&ExampleJSONBasic{}, "https://example.com/basic.json").
+		Register(reflect.TypeOf(ExampleJSONBasicMaps{}), "https://example.com/maps.json")
+
+	u, ok := links.URL(ExampleJSONBasic{})
+	c.Assert(ok, Equals, true)
+	c.Assert(u, Equals, "https://example.com/basic.json")
+
+	h := http.Header{}
+	c.Assert(links.Attach(h, &ExampleJSONBasicMaps{}), Equals, true)
+	c.Assert(h.Get("Link"), Equals, `<https://example.com/maps.json>; rel="describedby"`)
+	c.Assert(h.Get("Content-Type"), Equals, `application/schema-instance+json; schema="https://example.com/maps.json"`)
+
+	h = http.Header{"Content-Type": []string{"application/json"}}
+	c.Assert(links.Attach(h, ExampleJSONBasic{}), Equals, true)
+	c.Assert(h.Get("Content-Type"), Equals, "application/json")
+
+	c.Assert(links.Attach(http.Header{}, 42), Equals, false)
+}
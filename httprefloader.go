@@ -0,0 +1,176 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HTTPRefLoader is a RefLoader fetching documents over HTTP, and caching them
+// on disk. Cached documents are served without request until their TTL
+// expires, then revalidated with their ETag. Offline, documents are only
+// served from the cache, e.g. a snapshot of it committed for air-gapped CI.
+type HTTPRefLoader struct {
+	dir     string
+	client  *http.Client
+	ttl     time.Duration
+	offline bool
+}
+
+// NewHTTPRefLoader returns a loader caching documents in dir, or not caching
+// them if dir is "".
+func NewHTTPRefLoader(dir string) *HTTPRefLoader {
+	return &HTTPRefLoader{dir: dir, client: http.DefaultClient}
+}
+
+// WithClient fetches documents with client rather than http.DefaultClient.
+func (l *HTTPRefLoader) WithClient(client *http.Client) *HTTPRefLoader {
+	l.client = client
+	return l
+}
+
+// WithTTL serves documents cached for less than ttl without request. By
+// default, cached documents are revalidated each time they are loaded.
+func (l *HTTPRefLoader) WithTTL(ttl time.Duration) *HTTPRefLoader {
+	l.ttl = ttl
+	return l
+}
+
+// WithOffline only serves documents from the cache, failing for others.
+func (l *HTTPRefLoader) WithOffline() *HTTPRefLoader {
+	l.offline = true
+	return l
+}
+
+// defaultMaxRefDocumentSize is the maximum size of the documents fetched,
+// unless ValidatorLimits.MaxRefDocumentSize sets it.
+const defaultMaxRefDocumentSize = 10 << 20
+
+// cacheEntry is the metadata of a cached document, stored beside it.
+type cacheEntry struct {
+	URI     string    `json:"uri"`
+	ETag    string    `json:"etag,omitempty"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// Load returns the document at uri, from the cache or fetched with a request
+// bound to ctx. Documents larger than ValidatorLimits.MaxRefDocumentSize, or
+// 10 MiB by default, fail to load.
+func (l *HTTPRefLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	body, entry, cached := l.cached(uri)
+	if l.offline {
+		if !cached {
+			return nil, fmt.Errorf("%s is not cached in %s", uri, l.dir)
+		}
+		return body, nil
+	}
+	if cached && l.ttl > 0 && time.Since(entry.Fetched) < l.ttl {
+		return body, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cached && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached:
+		entry.Fetched = time.Now()
+		return body, l.store(uri, nil, entry)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	limit := maxRefDocumentSize(ctx, defaultMaxRefDocumentSize)
+	body, err = io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > limit {
+		return nil, fmt.Errorf("the document exceeds the maximum size of %d bytes", limit)
+	}
+	return body, l.store(uri, body, cacheEntry{URI: uri, ETag: resp.Header.Get("ETag"), Fetched: time.Now()})
+}
+
+// cachePath returns the path of the cached document at uri, and that of its
+// metadata.
+func (l *HTTPRefLoader) cachePath(uri string) (string, string) {
+	sum := sha256.Sum256([]byte(uri))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(l.dir, name+".json"), filepath.Join(l.dir, name+".meta.json")
+}
+
+// cached returns the cached document at uri and its metadata, if cached.
+func (l *HTTPRefLoader) cached(uri string) ([]byte, cacheEntry, bool) {
+	var entry cacheEntry
+	if l.dir == "" {
+		return nil, entry, false
+	}
+	bodyPath, metaPath := l.cachePath(uri)
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return nil, entry, false
+	}
+	if meta, err := os.ReadFile(metaPath); err == nil {
+		// without metadata, as in a snapshot trimmed by hand, the document
+		// is revalidated
+		_ = json.Unmarshal(meta, &entry)
+	}
+	return body, entry, true
+}
+
+// store caches the document at uri, if body is set, and its metadata. Files
+// are written then renamed, so that concurrent loads never read them partly
+// written.
+func (l *HTTPRefLoader) store(uri string, body []byte, entry cacheEntry) error {
+	if l.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	bodyPath, metaPath := l.cachePath(uri)
+	if body != nil {
+		if err := writeFileAtomic(bodyPath, body); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(metaPath, meta)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
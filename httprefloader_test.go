@@ -0,0 +1,87 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type httpRefLoaderSuite struct{}
+
+var _ = Suite(&httpRefLoaderSuite{})
+
+// schemaServer serves a schema with an ETag, counting the requests and those
+// answered by 304 Not Modified.
+type schemaServer struct {
+	requests    int
+	notModified int
+}
+
+func (s *schemaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	if r.Header.Get("If-None-Match") == `"v1"` {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", `"v1"`)
+	w.Write([]byte(`{"type": "string"}`))
+}
+
+func (self *httpRefLoaderSuite) TestHTTPRefLoader(c *C) {
+	s := &schemaServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	dir := c.MkDir()
+	ctx := context.Background()
+
+	body, err := NewHTTPRefLoader(dir).Load(ctx, server.URL+"/name.json")
+	c.Assert(err, IsNil)
+	c.Assert(string(body), Equals, `{"type": "string"}`)
+	c.Assert(s.requests, Equals, 1)
+
+	// revalidated with the ETag
+	body, err = NewHTTPRefLoader(dir).Load(ctx, server.URL+"/name.json")
+	c.Assert(err, IsNil)
+	c.Assert(string(body), Equals, `{"type": "string"}`)
+	c.Assert(s.requests, Equals, 2)
+	c.Assert(s.notModified, Equals, 1)
+
+	// served from the cache within the TTL
+	_, err = NewHTTPRefLoader(dir).WithTTL(time.Hour).Load(ctx, server.URL+"/name.json")
+	c.Assert(err, IsNil)
+	c.Assert(s.requests, Equals, 2)
+
+	// offline, only from the cache
+	offline := NewHTTPRefLoader(dir).WithOffline()
+	body, err = offline.Load(ctx, server.URL+"/name.json")
+	c.Assert(err, IsNil)
+	c.Assert(string(body), Equals, `{"type": "string"}`)
+	_, err = offline.Load(ctx, server.URL+"/other.json")
+	c.Assert(err, ErrorMatches, ".*/other.json is not cached in .*")
+	c.Assert(s.requests, Equals, 2)
+
+	// validating with remote $refs
+	js := &JSONSchema{Property: Property{Ref: server.URL + "/name.json"}}
+	v, err := NewValidatorWithLoader(ctx, js, offline)
+	c.Assert(err, IsNil)
+	errs, err := v.Validate([]byte(`1`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+
+	// documents larger than the limits aren't read
+	_, err = NewValidatorWithLoader(ctx, js, NewHTTPRefLoader(""), ValidatorLimits{MaxRefDocumentSize: 8})
+	c.Assert(err, ErrorMatches, "error loading .*/name.json: the document exceeds the maximum size of 8 bytes")
+	c.Assert(s.requests, Equals, 3)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = NewHTTPRefLoader("").Load(canceled, server.URL+"/name.json")
+	c.Assert(err, ErrorMatches, ".*context canceled")
+	c.Assert(s.requests, Equals, 3)
+}
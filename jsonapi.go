@@ -0,0 +1,170 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// jsonAPIResourceType returns the type of the JSON:API resources described by
+// the struct type t, from the primary field tagged `jsonapi:"primary,type"`.
+func jsonAPIResourceType(t goType) (string, bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return "", false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		kind, name, _ := parseJSONAPITag(t.Field(i).Tag)
+		if kind == "primary" && name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// parseJSONAPITag returns the kind of a jsonapi tag, e.g. "attr", the name it
+// holds and its options.
+func parseJSONAPITag(tag reflect.StructTag) (string, string, structTag) {
+	kind, opts := parseTag(tag.Get("jsonapi"))
+	name, opts := parseTag(string(opts))
+	return kind, name, opts
+}
+
+// readResource reads into n the JSON:API resource object of type resourceType
+// described by the struct type t: its primary field is the id, and the fields
+// tagged `jsonapi:"attr,name"` and `jsonapi:"relation,name"` are its
+// attributes and relationships. Other fields are left out.
+func (r *reader) readResource(n *Node, t goType, resourceType string) error {
+	n.Kind = KindMap
+	if !r.enter(t) {
+		return nil
+	}
+	defer r.leave(t)
+
+	n.Kind = KindObject
+	id := &Node{Kind: KindString, Schema: &Property{}}
+	n.setField("type", &Node{Kind: KindString, Schema: &Property{Const: resourceType}}, true)
+	n.setField("id", id, false)
+	attributes := &Node{Kind: KindObject, Schema: &Property{}}
+	relationships := &Node{Kind: KindObject, Schema: &Property{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Tag = r.resolveTagAliases(field.Tag)
+		var err error
+		if field.Tag, err = r.expandPresets(field.Tag); err != nil {
+			return fmt.Errorf("property:%s:%s", field.Name, err)
+		}
+		kind, name, opts := parseJSONAPITag(field.Tag)
+		if !field.Exported {
+			continue
+		}
+		_, required := field.Tag.Lookup("required")
+		required = required && !opts.Contains("omitempty")
+		switch kind {
+		case "primary":
+			if !opts.Contains("omitempty") {
+				// only client-generated ids may be left out
+				n.field("id").Required = true
+			}
+			description, err := r.interpolate(field.Tag.Get("description"))
+			if err != nil {
+				return fmt.Errorf("property:%s:description:%s", field.Name, err)
+			}
+			id.Schema.Description = description
+		case "attr":
+			target := newNode()
+			if err := r.read(target, field.Type); err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			if err := target.Schema.addBytesFormatFromTags(&field.Tag, field.Type); err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			if err := target.addValuesFromTags(&field.Tag, field.Type); err != nil {
+				return fmt.Errorf("property:%s:%s", field.Name, err)
+			}
+			if err := r.readTags(target, field.Name, name, true, &field.Tag); err != nil {
+				return err
+			}
+			attributes.setField(name, target, required)
+		case "relation":
+			related, ok := jsonAPIResourceType(field.Type)
+			if !ok {
+				return fmt.Errorf("property:%s:relation to %s, which is not a JSON:API resource", field.Name, field.Type)
+			}
+			identifier := &Node{Kind: KindObject, Schema: &Property{}}
+			identifier.setField("type", &Node{Kind: KindString, Schema: &Property{Const: related}}, true)
+			identifier.setField("id", &Node{Kind: KindString, Schema: &Property{}}, true)
+			data := identifier
+			switch field.Type.Kind() {
+			case reflect.Slice:
+				data = &Node{Kind: KindArray, Elem: identifier, Schema: &Property{}}
+			case reflect.Ptr:
+				// empty to-one relationships have null data
+				identifier.setNullable()
+			}
+			relationship := &Node{Kind: KindObject, Schema: &Property{}}
+			relationship.setField("data", data, false)
+			relationship.setField("links", anyObject(), false)
+			relationship.setField("meta", anyObject(), false)
+			if err := r.readTags(relationship, field.Name, name, true, &field.Tag); err != nil {
+				return err
+			}
+			relationships.setField(name, relationship, required)
+		}
+	}
+	if len(attributes.Fields) > 0 {
+		n.setField("attributes", attributes, len(requiredFields(attributes)) > 0)
+	}
+	if len(relationships.Fields) > 0 {
+		n.setField("relationships", relationships, len(requiredFields(relationships)) > 0)
+	}
+	n.setField("links", anyObject(), false)
+	n.setField("meta", anyObject(), false)
+	return nil
+}
+
+// anyObject returns the node of objects holding any properties.
+func anyObject() *Node {
+	return &Node{Kind: KindMap, Schema: &Property{AdditionalProperties: true}}
+}
+
+// jsonAPIEmitter emits JSON:API top-level documents whose primary data is the
+// root of the schema, and whose included resources are the resources among
+// the definitions.
+type jsonAPIEmitter struct{}
+
+func (e jsonAPIEmitter) Emit(model *Property) ([]byte, error) {
+	return e.EmitDocument(&JSONSchema{Property: *model})
+}
+
+func (e jsonAPIEmitter) EmitDocument(schema *JSONSchema) ([]byte, error) {
+	data := schema.Property.Clone()
+	schema.Property = Property{
+		Type: "object",
+		Properties: map[string]*Property{
+			"data":  data,
+			"links": {Type: "object", AdditionalProperties: true},
+			"meta":  {Type: "object", AdditionalProperties: true},
+		},
+		Required: []string{"data"},
+	}
+
+	var resources []string
+	for name, t := range schema.DefinitionTypes() {
+		if _, ok := jsonAPIResourceType(reflectType{t}); ok {
+			resources = append(resources, name)
+		}
+	}
+	sort.Strings(resources)
+	if len(resources) > 0 {
+		included := &Property{}
+		for _, name := range resources {
+			included.AnyOf = append(included.AnyOf, &Property{Ref: definitionReference(name)})
+		}
+		schema.Properties["included"] = &Property{Type: "array", Items: included}
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
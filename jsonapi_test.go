@@ -0,0 +1,101 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type jsonAPISuite struct{}
+
+var _ = Suite(&jsonAPISuite{})
+
+type ExampleJSONAPIPerson struct {
+	ID   string `jsonapi:"primary,people"`
+	Name string `jsonapi:"attr,name" required:"true" minLength:"1"`
+}
+
+type ExampleJSONAPIComment struct {
+	ID   string `jsonapi:"primary,comments,omitempty"`
+	Body string `jsonapi:"attr,body"`
+}
+
+type ExampleJSONAPIArticle struct {
+	ID       string                   `jsonapi:"primary,articles" description:"The slug of the article."`
+	Title    string                   `jsonapi:"attr,title" description:"The title of the article."`
+	Views    uint                     `jsonapi:"attr,views,omitempty"`
+	Author   *ExampleJSONAPIPerson    `jsonapi:"relation,author" required:"true"`
+	Comments []*ExampleJSONAPIComment `jsonapi:"relation,comments"`
+	internal string
+	Cached   bool
+}
+
+type ExampleJSONAPIInvalid struct {
+	ID     string `jsonapi:"primary,invalid"`
+	Author string `jsonapi:"relation,author"`
+}
+
+func (self *jsonAPISuite) TestResources(c *C) {
+	j := NewGenerator(Options{JSONAPI: true}).
+		WithDefinition("person", ExampleJSONAPIPerson{}).
+		WithDefinition("comment", ExampleJSONAPIComment{}).
+		WithRoot(&ExampleJSONAPIArticle{}).
+		MustGenerate()
+
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{"attributes", "id", "links", "meta", "relationships", "type"})
+	c.Assert(j.Required, DeepEquals, []string{"type", "id", "relationships"})
+	c.Assert(j.Properties["type"], DeepEquals, &Property{Type: "string", Const: "articles"})
+	c.Assert(j.Properties["id"], DeepEquals, &Property{Type: "string", Description: "The slug of the article."})
+	c.Assert(j.Properties["attributes"], DeepEquals, &Property{
+		Type: "object",
+		Properties: map[string]*Property{
+			"title": {Type: "string", Description: "The title of the article."},
+			"views": {Type: "integer"},
+		},
+	})
+
+	relationships := j.Properties["relationships"]
+	c.Assert(relationships.Required, DeepEquals, []string{"author"})
+	identifier := func(resourceType string) *Property {
+		return &Property{
+			Type: "object",
+			Properties: map[string]*Property{
+				"type": {Type: "string", Const: resourceType},
+				"id":   {Type: "string"},
+			},
+			Required: []string{"type", "id"},
+		}
+	}
+	c.Assert(relationships.Properties["author"].Properties["data"], DeepEquals, &Property{AnyOf: []*Property{identifier("people"), {Type: "null"}}})
+	c.Assert(relationships.Properties["comments"].Properties["data"], DeepEquals, &Property{Type: "array", Items: identifier("comments")})
+
+	c.Assert(j.Definitions["person"].Required, DeepEquals, []string{"type", "id", "attributes"})
+	c.Assert(j.Definitions["comment"].Required, DeepEquals, []string{"type"})
+
+	// without the option, the structs are described as usual
+	j = NewGenerator().WithRoot(&ExampleJSONAPIArticle{}).MustGenerate()
+	c.Assert(j.Properties["Cached"], NotNil)
+
+	_, err := NewGenerator(Options{JSONAPI: true}).WithRoot(ExampleJSONAPIInvalid{}).Generate()
+	c.Assert(err, ErrorMatches, ".*property:Author:relation to string, which is not a JSON:API resource")
+}
+
+func (self *jsonAPISuite) TestDialect(c *C) {
+	j := NewGenerator(Options{JSONAPI: true}).
+		WithDefinition("person", ExampleJSONAPIPerson{}).
+		WithDefinition("comment", ExampleJSONAPIComment{}).
+		WithRoot(&ExampleJSONAPIArticle{}).
+		MustGenerate()
+
+	b, err := j.Emit(DialectJSONAPI)
+	c.Assert(err, IsNil)
+	var document JSONSchema
+	c.Assert(json.Unmarshal(b, &document), IsNil)
+	c.Assert(document.Required, DeepEquals, []string{"data"})
+	c.Assert(document.Properties["data"].Properties["type"].Const, Equals, "articles")
+	c.Assert(document.Properties["included"].Items.AnyOf, DeepEquals, []*Property{
+		{Ref: "#/definitions/comment"},
+		{Ref: "#/definitions/person"},
+	})
+	c.Assert(sortedDefinitionNames(document.Definitions), DeepEquals, []string{"comment", "person"})
+}
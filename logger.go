@@ -0,0 +1,30 @@
+package jsonschema
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger logs the warnings of the schemas generated to logger at the
+// warn level, rather than only returning them from Warnings, and the trace
+// of the generation at the debug level, for services generating their
+// schemas at startup.
+func (g *Generator) WithLogger(logger *slog.Logger) *Generator {
+	g.logger = logger
+	return g
+}
+
+// logWarnings logs each warning with its path, if logger is set.
+func logWarnings(logger *slog.Logger, warnings []Inconsistency) {
+	if logger == nil {
+		return
+	}
+	for _, w := range warnings {
+		logger.Warn(w.Message, "path", w.Path)
+	}
+}
+
+// debugging reports whether logger logs the trace.
+func debugging(logger *slog.Logger) bool {
+	return logger != nil && logger.Enabled(context.Background(), slog.LevelDebug)
+}
@@ -0,0 +1,60 @@
+package jsonschema
+
+import (
+	"bytes"
+	"log/slog"
+
+	. "gopkg.in/check.v1"
+)
+
+type loggerSuite struct{}
+
+var _ = Suite(&loggerSuite{})
+
+type ExampleJSONLogged struct {
+	Percent int `json:"percent" min:"100" max:"0"`
+}
+
+func newTestLogger(b *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(b, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func (self *loggerSuite) TestLogWarnings(c *C) {
+	var b bytes.Buffer
+	_, err := NewGenerator().
+		WithRoot(&ExampleJSONLogged{}).
+		WithLogger(newTestLogger(&b, slog.LevelWarn)).
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, `level=WARN msg="no value is within minimum 100 and maximum 0" path=/properties/percent`+"\n")
+}
+
+func (self *loggerSuite) TestLogTrace(c *C) {
+	var b bytes.Buffer
+	_, err := NewGenerator().
+		WithRoot(&ExampleJSONLogged{}).
+		WithLogger(newTestLogger(&b, slog.LevelDebug)).
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, `level=DEBUG msg="root: *jsonschema.ExampleJSONLogged"`+"\n"+
+		`level=DEBUG msg="jsonschema.ExampleJSONLogged.Percent: type integer for int"`+"\n"+
+		`level=DEBUG msg="jsonschema.ExampleJSONLogged.Percent: tags min, max applied"`+"\n"+
+		`level=WARN msg="no value is within minimum 100 and maximum 0" path=/properties/percent`+"\n")
+}
+
+func (self *loggerSuite) TestLogSetWarningsOnce(c *C) {
+	var b bytes.Buffer
+	_, err := NewGenerator().
+		WithLogger(newTestLogger(&b, slog.LevelWarn)).
+		GenerateSet(map[string]interface{}{"logged": &ExampleJSONLogged{}})
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, `level=WARN msg="no value is within minimum 100 and maximum 0" path=/roots/logged/properties/percent`+"\n")
+}
@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CompatibilityMode is the compatibility a schema registry enforces between
+// successive versions of the schema of a topic.
+type CompatibilityMode string
+
+const (
+	CompatibilityNone     CompatibilityMode = "NONE"
+	CompatibilityBackward CompatibilityMode = "BACKWARD"
+	CompatibilityForward  CompatibilityMode = "FORWARD"
+	CompatibilityFull     CompatibilityMode = "FULL"
+)
+
+// Fingerprint returns a digest of the JSON encoding of the schema,
+// e.g. "sha256:3b1f...". Schemas with the same content have the same fingerprint.
+func Fingerprint(js *JSONSchema) (string, error) {
+	b, err := json.Marshal(*js)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Manifest is a machine-readable list of topics and the schemas of their
+// messages, for deployment tooling to compare against a schema registry.
+type Manifest struct {
+	Topics []ManifestTopic `json:"topics"`
+}
+
+// ManifestTopic describes the schema of the messages of one topic.
+type ManifestTopic struct {
+	Topic    string `json:"topic"`
+	SchemaID string `json:"schemaId"`
+	// Fingerprint is computed before the $id is set, so that it only
+	// changes when the content of the schema does.
+	Fingerprint   string            `json:"fingerprint"`
+	Compatibility CompatibilityMode `json:"compatibility"`
+	// Schema is the generated schema, which is published separately.
+	Schema *JSONSchema `json:"-"`
+}
+
+// String return the JSON encoding of the Manifest as a string
+func (m Manifest) String() string {
+	b, _ := json.MarshalIndent(m, "", "  ")
+	return string(b)
+}
+
+// TopicManifest builds a Manifest from topic to root type mappings,
+// for NATS subjects, Kafka topics and the like.
+type TopicManifest struct {
+	options     []Options
+	definitions map[string]interface{}
+	topics      map[string]manifestTopic
+	schemaID    func(topic string) string
+}
+
+type manifestTopic struct {
+	root          interface{}
+	compatibility CompatibilityMode
+}
+
+// NewTopicManifest returns a TopicManifest identifying the schema of each
+// topic as baseURI followed by the topic name and ".json".
+func NewTopicManifest(baseURI string, options ...Options) *TopicManifest {
+	baseURI = strings.TrimSuffix(baseURI, "/")
+	return &TopicManifest{
+		options:     options,
+		definitions: map[string]interface{}{},
+		topics:      map[string]manifestTopic{},
+		schemaID: func(topic string) string {
+			return fmt.Sprintf("%s/%s.json", baseURI, topic)
+		},
+	}
+}
+
+// WithSchemaID sets the function computing the $id of the schema of a topic.
+func (m *TopicManifest) WithSchemaID(fn func(topic string) string) *TopicManifest {
+	m.schemaID = fn
+	return m
+}
+
+// WithTopic registers root as the type of the messages of topic.
+// An empty compatibility defaults to CompatibilityBackward.
+func (m *TopicManifest) WithTopic(topic string, root interface{}, compatibility CompatibilityMode) *TopicManifest {
+	m.topics[topic] = manifestTopic{root: root, compatibility: compatibility}
+	return m
+}
+
+// WithDefinition registers a definition included in the schema of every topic.
+func (m *TopicManifest) WithDefinition(name string, d interface{}) *TopicManifest {
+	m.definitions[name] = d
+	return m
+}
+
+// Generate generates the schema of every topic and the manifest listing them, sorted by topic.
+func (m *TopicManifest) Generate() (*Manifest, error) {
+	names := make([]string, 0, len(m.topics))
+	for topic := range m.topics {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+
+	manifest := &Manifest{Topics: make([]ManifestTopic, 0, len(names))}
+	for _, topic := range names {
+		t := m.topics[topic]
+		g := NewGenerator(m.options...).WithDefinitions(m.definitions)
+		js, err := g.WithRoot(t.root).Generate()
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %s", topic, err)
+		}
+		fingerprint, err := Fingerprint(js)
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %s", topic, err)
+		}
+		js.ID = m.schemaID(topic)
+
+		compatibility := t.compatibility
+		if compatibility == "" {
+			compatibility = CompatibilityBackward
+		}
+		manifest.Topics = append(manifest.Topics, ManifestTopic{
+			Topic:         topic,
+			SchemaID:      js.ID,
+			Fingerprint:   fingerprint,
+			Compatibility: compatibility,
+			Schema:        js,
+		})
+	}
+	return manifest, nil
+}
@@ -0,0 +1,64 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type manifestSuite struct{}
+
+var _ = Suite(&manifestSuite{})
+
+func (self *manifestSuite) TestManifest(c *C) {
+	m, err := NewTopicManifest("https://example.com/schemas/").
+		WithTopic("orders.created", &ExampleJSONOrderCreated{}, CompatibilityFull).
+		WithTopic("chat", ExampleJSONChatMessage{}, "").
+		WithDefinition("order", ExampleJSONNestedStructReferenceChild{}).
+		Generate()
+	c.Assert(err, IsNil)
+
+	c.Assert(m.Topics, HasLen, 2)
+	chat, orders := m.Topics[0], m.Topics[1]
+
+	c.Assert(chat.Topic, Equals, "chat")
+	c.Assert(chat.SchemaID, Equals, "https://example.com/schemas/chat.json")
+	c.Assert(chat.Compatibility, Equals, CompatibilityBackward)
+	c.Assert(chat.Schema.ID, Equals, chat.SchemaID)
+
+	c.Assert(orders.Topic, Equals, "orders.created")
+	c.Assert(orders.Compatibility, Equals, CompatibilityFull)
+	c.Assert(orders.Schema.Properties["order"].Ref, Equals, "#/definitions/order")
+
+	c.Assert(chat.Fingerprint, Matches, "sha256:[0-9a-f]{64}")
+	c.Assert(chat.Fingerprint, Not(Equals), orders.Fingerprint)
+
+	again, err := NewTopicManifest("https://example.com/other").
+		WithTopic("chat", &ExampleJSONChatMessage{}, CompatibilityNone).
+		WithDefinition("order", ExampleJSONNestedStructReferenceChild{}).
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(again.Topics[0].Fingerprint, Equals, chat.Fingerprint)
+}
+
+func (self *manifestSuite) TestManifestString(c *C) {
+	m, err := NewTopicManifest("https://example.com").
+		WithSchemaID(func(topic string) string { return "urn:" + topic }).
+		WithTopic("chat", ExampleJSONChatMessage{}, CompatibilityNone).
+		Generate()
+	c.Assert(err, IsNil)
+
+	fingerprint, err := Fingerprint(m.Topics[0].Schema.Clone())
+	c.Assert(err, IsNil)
+	c.Assert(fingerprint, Not(Equals), m.Topics[0].Fingerprint)
+
+	expected := "{\n" +
+		"  \"topics\": [\n" +
+		"    {\n" +
+		"      \"topic\": \"chat\",\n" +
+		"      \"schemaId\": \"urn:chat\",\n" +
+		"      \"fingerprint\": \"" + m.Topics[0].Fingerprint + "\",\n" +
+		"      \"compatibility\": \"NONE\"\n" +
+		"    }\n" +
+		"  ]\n" +
+		"}"
+	c.Assert(m.String(), Equals, expected)
+}
@@ -0,0 +1,47 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var valueTypes = map[string]bool{
+	"string":  true,
+	"integer": true,
+	"number":  true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"null":    true,
+}
+
+// addValuesFromTags describes the values of a map with the values tag, a
+// $ref, or the valuesType tag, a type, e.g. for map[string]interface{}.
+func (n *Node) addValuesFromTags(tag *reflect.StructTag, t goType) error {
+	ref, hasRef := tag.Lookup("values")
+	valuesType, hasType := tag.Lookup("valuesType")
+	if !hasRef && !hasType {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t.Kind() != reflect.Map:
+		return fmt.Errorf(`"values" and "valuesType" tags on %s, which is not a map`, t)
+	case hasRef && hasType:
+		return fmt.Errorf(`"values" and "valuesType" tags are exclusive`)
+	case hasType && !valueTypes[valuesType]:
+		return fmt.Errorf(`invalid "valuesType" tag value %q`, valuesType)
+	case hasRef && ref == "":
+		return fmt.Errorf(`empty "values" tag`)
+	}
+
+	n.Elem = &Node{Kind: kindOfType(valuesType), Schema: &Property{}}
+	if hasRef {
+		n.Elem.setRef(ref)
+	}
+	n.patternValues = false
+	n.verbatim = false
+	return nil
+}
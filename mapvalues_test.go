@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type mapValuesSuite struct{}
+
+var _ = Suite(&mapValuesSuite{})
+
+type ExampleJSONDynamicMaps struct {
+	Items   map[string]interface{} `json:"items" values:"#/definitions/item"`
+	Counts  map[string]interface{} `json:"counts" valuesType:"integer"`
+	Names   *map[string]string     `json:"names" valuesType:"string"`
+	Untyped map[string]interface{} `json:"untyped"`
+}
+
+func (self *mapValuesSuite) TestValuesTags(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONDynamicMaps{}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		MustGenerate()
+
+	c.Assert(j.Properties["items"], DeepEquals, &Property{Type: "object",
+		AdditionalPropertiesSchema: &Property{Ref: "#/definitions/item"}})
+	c.Assert(j.Properties["counts"], DeepEquals, &Property{Type: "object",
+		AdditionalPropertiesSchema: &Property{Type: "integer"}})
+	c.Assert(j.Properties["names"], DeepEquals, &Property{Type: "object",
+		AdditionalPropertiesSchema: &Property{Type: "string"}})
+	c.Assert(j.Properties["untyped"], DeepEquals, &Property{Type: "object", AdditionalProperties: true})
+}
+
+type ExampleJSONValuesOnString struct {
+	Name string `json:"name" valuesType:"string"`
+}
+
+type ExampleJSONInvalidValuesType struct {
+	Counts map[string]interface{} `json:"counts" valuesType:"int"`
+}
+
+type ExampleJSONValuesAndType struct {
+	Counts map[string]interface{} `json:"counts" values:"#/definitions/count" valuesType:"integer"`
+}
+
+func (self *mapValuesSuite) TestValuesTagsErrors(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONValuesOnString{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Name:"values" and "valuesType" tags on string, which is not a map`)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONInvalidValuesType{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Counts:invalid "valuesType" tag value "int"`)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONValuesAndType{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Counts:"values" and "valuesType" tags are exclusive`)
+}
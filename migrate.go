@@ -0,0 +1,173 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Renames maps the JSON pointers of renamed properties in an old schema, as
+// in MigrationHint.Path, e.g. "/definitions/address/properties/zip", to
+// their names in the new schema.
+type Renames map[string]string
+
+// RenamesFromHints returns the renames hinted with at least the similarity.
+func RenamesFromHints(hints []MigrationHint, similarity float64) Renames {
+	renames := Renames{}
+	for _, h := range hints {
+		if h.Kind == HintRenamed && h.Similarity >= similarity {
+			renames[h.Path] = h.New.(string)
+		}
+	}
+	return renames
+}
+
+// Migrate upgrades a JSON document of the old schema to the new one, applying
+// the transformations which are safe without knowledge of the data: the
+// properties renamed by renames are moved to their new names, the other
+// properties removed from the schema are dropped, and the missing properties
+// newly required are added with their defaults. Properties the old schema
+// doesn't describe are kept. It fails if a required property has no default,
+// or if a property is renamed to one the document already has; other changes
+// hinted by MigrationHints are left to the caller.
+func Migrate(old, new *JSONSchema, doc []byte, renames ...Renames) ([]byte, error) {
+	value, err := decodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	m := &migration{old: old, new: new, renames: Renames{}}
+	for _, r := range renames {
+		for path, name := range r {
+			m.renames[path] = name
+		}
+	}
+	value, err = m.migrate(value, "", &old.Property, "", &new.Property)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// migration holds the schemas and the renames of a Migrate.
+type migration struct {
+	old, new *JSONSchema
+	renames  Renames
+}
+
+// migrate upgrades the value at the instance path from the old property, at
+// oldPath in the old schema, to the new one.
+func (m *migration) migrate(value interface{}, instancePath string, old *Property, oldPath string, new *Property) (interface{}, error) {
+	old, oldPath = dereferenceMigration(m.old, old, oldPath)
+	new, _ = dereferenceMigration(m.new, new, "")
+	if old == nil || new == nil {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if old.Items == nil || new.Items == nil {
+			return value, nil
+		}
+		for i, item := range v {
+			migrated, err := m.migrate(item, fmt.Sprintf("%s/%d", instancePath, i), old.Items, oldPath+"/items", new.Items)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = migrated
+		}
+	case map[string]interface{}:
+		return m.migrateObject(v, instancePath, old, oldPath, new)
+	}
+	return value, nil
+}
+
+func (m *migration) migrateObject(object map[string]interface{}, instancePath string, old *Property, oldPath string, new *Property) (interface{}, error) {
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// sources holds the name of each property of the migrated object in the
+	// document.
+	sources := map[string]string{}
+	for _, name := range names {
+		newName := name
+		if _, described := old.Properties[name]; described {
+			var renamed bool
+			newName, renamed = m.renames[oldPath+"/properties/"+escapePointer(name)]
+			if _, ok := new.Properties[newName]; renamed && !ok {
+				return nil, fmt.Errorf("cannot rename %s/%s to %s: no such property in the new schema", instancePath, escapePointer(name), newName)
+			}
+			if !renamed {
+				newName = name
+				if _, ok := new.Properties[name]; !ok {
+					continue
+				}
+			}
+		}
+		if source, ok := sources[newName]; ok {
+			if source == newName {
+				source = name
+			}
+			return nil, fmt.Errorf("cannot rename %s/%s to %s: the property is already set", instancePath, escapePointer(source), newName)
+		}
+		sources[newName] = name
+	}
+
+	migrated := make(map[string]interface{}, len(sources))
+	for newName, name := range sources {
+		value := object[name]
+		if o, described := old.Properties[name]; described {
+			var err error
+			value, err = m.migrate(value, instancePath+"/"+escapePointer(newName), o, oldPath+"/properties/"+escapePointer(name), new.Properties[newName])
+			if err != nil {
+				return nil, err
+			}
+		}
+		migrated[newName] = value
+	}
+
+	for _, name := range new.Required {
+		if _, ok := migrated[name]; ok {
+			continue
+		}
+		p, ok := new.Properties[name]
+		if !ok || p.Default == nil {
+			return nil, fmt.Errorf("cannot add the required property %s/%s: no default", instancePath, escapePointer(name))
+		}
+		migrated[name] = cloneValue(p.Default)
+	}
+	return migrated, nil
+}
+
+// dereferenceMigration follows the $refs of p, and the value branch of the
+// anyOf emitted for pointers, returning the schema with its JSON pointer in
+// js, or nil if a reference can't be resolved.
+func dereferenceMigration(js *JSONSchema, p *Property, path string) (*Property, string) {
+	for i := 0; ; i++ {
+		if p == nil || i > len(js.Definitions)+1 {
+			return nil, ""
+		}
+		switch {
+		case p.Ref == "#":
+			p, path = &js.Property, ""
+		case strings.HasPrefix(p.Ref, definitionsPrefix):
+			name := unescapePointer(strings.TrimPrefix(p.Ref, definitionsPrefix))
+			def, ok := js.Definitions[name]
+			if !ok {
+				return nil, ""
+			}
+			p, path = &def, "/definitions/"+escapePointer(name)
+		case p.Ref != "":
+			return nil, ""
+		default:
+			if branch, ok := nullableBranch(p.AnyOf); ok && p.Type == "" {
+				p, path = p.AnyOf[branch], fmt.Sprintf("%s/anyOf/%d", path, branch)
+				continue
+			}
+			return p, path
+		}
+	}
+}
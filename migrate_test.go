@@ -0,0 +1,60 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type migrateSuite struct{}
+
+var _ = Suite(&migrateSuite{})
+
+type ExampleJSONLedgerV1 struct {
+	Customers []ExampleJSONCustomerV1 `json:"customers"`
+}
+
+type ExampleJSONLedgerV2 struct {
+	Customers []ExampleJSONCustomerV2 `json:"customers"`
+}
+
+func (self *migrateSuite) TestMigrate(c *C) {
+	old := NewGenerator().WithRoot(&ExampleJSONCustomerV1{}).MustGenerate()
+	new := NewGenerator().WithRoot(&ExampleJSONCustomerV2{}).MustGenerate()
+	doc := []byte(`{"postal_code": "12345", "balance": 10, "fax": "555-0100", "note": "kept"}`)
+
+	_, err := Migrate(old, new, doc)
+	c.Assert(err, ErrorMatches, "cannot add the required property /country: no default")
+
+	new.Properties["country"].Default = "US"
+	migrated, err := Migrate(old, new, doc)
+	c.Assert(err, IsNil)
+	c.Assert(string(migrated), Equals, `{"balance":10,"country":"US","note":"kept"}`)
+
+	renames := RenamesFromHints(MigrationHints(old, new), 0.9)
+	c.Assert(renames, DeepEquals, Renames{"/properties/postal_code": "postalCode"})
+	migrated, err = Migrate(old, new, doc, renames)
+	c.Assert(err, IsNil)
+	c.Assert(string(migrated), Equals, `{"balance":10,"country":"US","note":"kept","postalCode":"12345"}`)
+
+	_, err = Migrate(old, new, []byte(`{"postal_code": "12345", "postalCode": "54321"}`), renames)
+	c.Assert(err, ErrorMatches, "cannot rename /postal_code to postalCode: the property is already set")
+	_, err = Migrate(old, new, doc, Renames{"/properties/fax": "phone"})
+	c.Assert(err, ErrorMatches, "cannot rename /fax to phone: no such property in the new schema")
+}
+
+func (self *migrateSuite) TestMigrateDefinitions(c *C) {
+	old := NewGenerator().WithRoot(&ExampleJSONLedgerV1{}).
+		WithDefinition("customer", ExampleJSONCustomerV1{}).
+		MustGenerate()
+	new := NewGenerator().WithRoot(&ExampleJSONLedgerV2{}).
+		WithDefinition("customer", ExampleJSONCustomerV2{}).
+		MustGenerate()
+	country := new.Definitions["customer"].Properties["country"]
+	country.Default = "US"
+
+	renames := RenamesFromHints(MigrationHints(old, new), 0.9)
+	c.Assert(renames, DeepEquals, Renames{"/definitions/customer/properties/postal_code": "postalCode"})
+
+	migrated, err := Migrate(old, new, []byte(`{"customers": [{"postal_code": "12345", "fax": "555-0100"}, {"country": "FR", "balance": 1.5}]}`), renames)
+	c.Assert(err, IsNil)
+	c.Assert(string(migrated), Equals, `{"customers":[{"country":"US","postalCode":"12345"},{"balance":1.5,"country":"FR"}]}`)
+}
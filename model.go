@@ -0,0 +1,335 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Kind is the kind of the values described by a Node.
+type Kind string
+
+const (
+	KindAny     Kind = "any"
+	KindNull    Kind = "null"
+	KindBoolean Kind = "boolean"
+	KindInteger Kind = "integer"
+	KindNumber  Kind = "number"
+	KindString  Kind = "string"
+	KindArray   Kind = "array"
+	// KindMap describes objects whose properties all hold values of the same kind.
+	KindMap Kind = "map"
+	// KindObject describes objects with a fixed set of fields.
+	KindObject Kind = "object"
+	// KindUnion describes values of any of the Variants.
+	KindUnion Kind = "union"
+	// KindRef references a definition, or another document.
+	KindRef Kind = "ref"
+)
+
+// Model is a representation of a schema independent of the keywords of JSON
+// Schema: nullable values, maps and unions are spelled out rather than encoded
+// as anyOf branches, ".*" properties or type arrays. References to
+// definitions are kept, so recursive schemas may be represented.
+//
+// The Generator reads the Go types into a Model, which it then serializes as
+// the keywords of the draft and the NullableStyle of its options.
+// Generator.GenerateModel returns it as read. JSONSchema.Model derives one from
+// a schema generated or parsed, for emitters of other formats.
+type Model struct {
+	Root        *Node
+	Definitions map[string]*Node
+}
+
+// Node describes values in a Model.
+type Node struct {
+	Kind Kind
+	// Nullable tells whether the values may also be null.
+	Nullable    bool
+	Title       string
+	Description string
+	Deprecated  bool
+	Format      string
+	// Values holds the values allowed by an enum or a const, if any.
+	Values []interface{}
+	// Fields holds the fields of objects, in the order of the fields of the
+	// struct read, or sorted by name when derived from a schema.
+	Fields []*Field
+	// Elem describes the items of arrays, the values of maps and the
+	// properties of objects which are not fields, if any. It is nil when
+	// they may be of any kind.
+	Elem *Node
+	// Variants holds the nodes of unions.
+	Variants []*Node
+	// Exclusive tells whether the values of a union match exactly one of
+	// its Variants, as with oneOf, rather than any of them.
+	Exclusive bool
+	// Ref is the $ref of references, and Definition the name of the
+	// definition it references, if any.
+	Ref        string
+	Definition string
+	// Schema holds the keywords of the node which aren't spelled out by the
+	// fields above, such as its validation keywords and extensions. When the
+	// node is derived from a schema, it is that schema.
+	Schema *Property
+
+	// patternValues tells whether the values of a map are described by the
+	// ".*" property, as for the maps of primitives read, rather than by
+	// additionalProperties.
+	patternValues bool
+	// verbatim tells whether the node is serialized as its Schema, the
+	// schema it was derived from, as long as its structure isn't changed.
+	verbatim bool
+}
+
+// Field is a field of an object.
+type Field struct {
+	Name     string
+	Required bool
+	*Node
+}
+
+// Model returns the representation of the schema independent of the keywords
+// of JSON Schema.
+func (d *JSONSchema) Model() *Model {
+	m := &Model{Root: nodeOf(&d.Property), Definitions: make(map[string]*Node, len(d.Definitions))}
+	for name := range d.Definitions {
+		def := d.Definitions[name]
+		m.Definitions[name] = nodeOf(&def)
+	}
+	return m
+}
+
+func nodeOf(p *Property) *Node {
+	if i, ok := nullableBranch(p.AnyOf); ok && p.Type == "" {
+		n := nodeOf(p.AnyOf[i])
+		// the keywords of the nullable schema take precedence over those of
+		// the branch of its values
+		n.Schema = mergeKeywords(p, p.AnyOf[i])
+		n.Schema.AnyOf = nil
+		n.Title = firstNonEmpty(p.Title, n.Title)
+		n.Description = firstNonEmpty(p.Description, n.Description)
+		n.Deprecated = p.Deprecated || n.Deprecated
+		n.Nullable = true
+		n.Values = nonNullValues(n.Schema)
+		return n
+	}
+
+	n := &Node{
+		Kind:        KindAny,
+		Title:       p.Title,
+		Description: p.Description,
+		Deprecated:  p.Deprecated,
+		Format:      p.Format,
+		Schema:      p,
+	}
+	if p.Ref != "" {
+		n.setRef(p.Ref)
+		return n
+	}
+	n.Values = nonNullValues(p)
+
+	types := p.Types
+	if len(types) == 0 && p.Type != "" {
+		types = []string{p.Type}
+	}
+	var kinds []string
+	for _, t := range types {
+		if t == "null" && len(types) > 1 {
+			n.Nullable = true
+		} else {
+			kinds = append(kinds, t)
+		}
+	}
+
+	switch {
+	case len(kinds) == 1:
+		setKind(n, p, kinds[0])
+	case len(kinds) > 1:
+		n.Kind = KindUnion
+		for _, t := range kinds {
+			variant := &Node{Kind: KindAny, Format: p.Format, Values: n.Values, Schema: &Property{Type: t}}
+			setKind(variant, p, t)
+			n.Variants = append(n.Variants, variant)
+		}
+		n.Values = nil
+	case len(p.AnyOf) > 0:
+		// a oneOf along the anyOf is left to Schema
+		n.Kind = KindUnion
+		for _, branch := range p.AnyOf {
+			n.Variants = append(n.Variants, nodeOf(branch))
+		}
+	case len(p.OneOf) > 0:
+		n.Kind = KindUnion
+		n.Exclusive = true
+		for _, branch := range p.OneOf {
+			n.Variants = append(n.Variants, nodeOf(branch))
+		}
+	}
+	return n
+}
+
+// newNode returns a node of values of any kind, without keywords yet.
+func newNode() *Node {
+	return &Node{Kind: KindAny, Schema: &Property{}}
+}
+
+// parseNode returns the node of p, which is serialized as p as long as its
+// structure isn't changed.
+func parseNode(p *Property) *Node {
+	n := nodeOf(p)
+	n.verbatim = n.Schema == p
+	return n
+}
+
+// kindOfType returns the kind of the values of the JSON type t, objects being
+// maps of any values until their fields are known.
+func kindOfType(t string) Kind {
+	switch t {
+	case "":
+		return KindAny
+	case "object":
+		return KindMap
+	}
+	return Kind(t)
+}
+
+// setNullable allows null along the values of n.
+func (n *Node) setNullable() {
+	n.Nullable = true
+	n.verbatim = false
+}
+
+// setRef makes n a reference to ref.
+func (n *Node) setRef(ref string) {
+	n.Kind = KindRef
+	n.Ref = ref
+	n.Definition = ""
+	if strings.HasPrefix(ref, definitionsPrefix) {
+		n.Definition = unescapePointer(strings.TrimPrefix(ref, definitionsPrefix))
+	}
+}
+
+// nonNullValues returns the values allowed by the enum or the const of p,
+// but null, which nullable nodes allow.
+func nonNullValues(p *Property) []interface{} {
+	var values []interface{}
+	if p.Const != nil {
+		values = []interface{}{p.Const}
+	}
+	for _, v := range p.Enum {
+		if v != nil {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// mergeKeywords returns a copy of p with the keywords it doesn't have taken
+// from other.
+func mergeKeywords(p, other *Property) *Property {
+	merged := *p
+	v, o := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(other).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsZero() {
+			v.Field(i).Set(o.Field(i))
+		}
+	}
+	return &merged
+}
+
+// annotate sets the annotations and the values of n and its subnodes from
+// their Schema.
+func (n *Node) annotate() {
+	if n.Schema != nil {
+		n.Title = n.Schema.Title
+		n.Description = n.Schema.Description
+		n.Deprecated = n.Schema.Deprecated
+		n.Format = n.Schema.Format
+		n.Values = nonNullValues(n.Schema)
+	}
+	if n.Elem != nil {
+		n.Elem.annotate()
+	}
+	for _, f := range n.Fields {
+		f.annotate()
+	}
+	for _, variant := range n.Variants {
+		variant.annotate()
+	}
+}
+
+// field returns the field of n of the given name, if any.
+func (n *Node) field(name string) *Field {
+	for _, f := range n.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// setField sets the field of n of the given name, in place of the field of
+// the same name if any.
+func (n *Node) setField(name string, node *Node, required bool) {
+	if f := n.field(name); f != nil {
+		f.Node = node
+		f.Required = f.Required || required
+		return
+	}
+	n.Fields = append(n.Fields, &Field{Name: name, Required: required, Node: node})
+}
+
+// primitive reports whether the values of n are booleans, numbers or strings.
+func (n *Node) primitive() bool {
+	switch n.Kind {
+	case KindBoolean, KindInteger, KindNumber, KindString:
+		return true
+	}
+	return false
+}
+
+// schemaType returns the type keyword of the values of n, or "" if they may
+// be of several types.
+func (n *Node) schemaType() string {
+	switch n.Kind {
+	case KindNull, KindBoolean, KindInteger, KindNumber, KindString, KindArray:
+		return string(n.Kind)
+	case KindMap, KindObject:
+		return "object"
+	}
+	return ""
+}
+
+// setKind sets the kind of n, built from p, to that of the values of type t.
+func setKind(n *Node, p *Property, t string) {
+	switch t {
+	case "null", "boolean", "integer", "number", "string":
+		n.Kind = Kind(t)
+	case "array":
+		n.Kind = KindArray
+		if p.Items != nil {
+			n.Elem = nodeOf(p.Items)
+		}
+	case "object":
+		n.Kind = KindObject
+		for _, name := range sortedPropertyNames(p.Properties) {
+			if name == ".*" {
+				continue
+			}
+			n.Fields = append(n.Fields, &Field{
+				Name:     name,
+				Required: containsString(p.Required, name),
+				Node:     nodeOf(p.Properties[name]),
+			})
+		}
+		if values, ok := p.Properties[".*"]; ok {
+			n.Elem = nodeOf(values)
+			n.patternValues = true
+		} else if p.AdditionalPropertiesSchema != nil {
+			n.Elem = nodeOf(p.AdditionalPropertiesSchema)
+		}
+		if len(n.Fields) == 0 {
+			n.Kind = KindMap
+		}
+	}
+}
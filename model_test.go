@@ -0,0 +1,100 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type modelSuite struct{}
+
+var _ = Suite(&modelSuite{})
+
+func (self *modelSuite) TestModel(c *C) {
+	m := NewGenerator().WithRoot(emitterOrder{}).WithDefinition("line", emitterLine{}).MustGenerate().Model()
+
+	c.Assert(m.Root.Kind, Equals, KindObject)
+	c.Assert(m.Root.Title, Equals, "Order")
+	c.Assert(m.Root.Fields, HasLen, 3)
+
+	labels := m.Root.Fields[0]
+	c.Assert(labels.Name, Equals, "labels")
+	c.Assert(labels.Kind, Equals, KindMap)
+	c.Assert(labels.Elem.Kind, Equals, KindString)
+
+	lines := m.Root.Fields[1]
+	c.Assert(lines.Kind, Equals, KindArray)
+	c.Assert(lines.Elem.Kind, Equals, KindRef)
+	c.Assert(lines.Elem.Definition, Equals, "line")
+
+	status := m.Root.Fields[2]
+	c.Assert(status.Required, Equals, true)
+	c.Assert(status.Values, DeepEquals, []interface{}{"new", "shipped"})
+
+	line := m.Definitions["line"]
+	c.Assert(line.Kind, Equals, KindObject)
+	note := line.Fields[0]
+	c.Assert(note.Name, Equals, "note")
+	c.Assert(note.Kind, Equals, KindString)
+	c.Assert(note.Nullable, Equals, true)
+	quantity := line.Fields[1]
+	c.Assert(*quantity.Schema.Minimum, Equals, 1.0)
+}
+
+func (self *modelSuite) TestGenerateModel(c *C) {
+	m, err := NewGenerator().WithRoot(emitterOrder{}).WithDefinition("line", emitterLine{}).GenerateModel()
+	c.Assert(err, IsNil)
+
+	// fields are in the order of the struct, rather than sorted
+	c.Assert(m.Root.Kind, Equals, KindObject)
+	c.Assert(m.Root.Title, Equals, "Order")
+	var names []string
+	for _, f := range m.Root.Fields {
+		names = append(names, f.Name)
+	}
+	c.Assert(names, DeepEquals, []string{"status", "lines", "labels"})
+	c.Assert(m.Root.Fields[0].Required, Equals, true)
+	c.Assert(m.Root.Fields[0].Values, DeepEquals, []interface{}{"new", "shipped"})
+	c.Assert(m.Root.Fields[1].Elem.Definition, Equals, "line")
+	c.Assert(m.Root.Fields[2].Kind, Equals, KindMap)
+	c.Assert(m.Root.Fields[2].Elem.Kind, Equals, KindString)
+
+	note := m.Definitions["line"].Fields[2]
+	c.Assert(note.Name, Equals, "note")
+	c.Assert(note.Kind, Equals, KindString)
+	c.Assert(note.Nullable, Equals, true)
+	c.Assert(note.Schema.AnyOf, IsNil)
+
+	// each draft and nullable style serializes the same model
+	for _, options := range []Options{{}, {NullableStyle: TypeArray}, {Draft: Draft202012}} {
+		g := NewGenerator(options).WithRoot(emitterOrder{}).WithDefinition("line", emitterLine{})
+		d := g.MustGenerate()
+		m, err := g.GenerateModel()
+		c.Assert(err, IsNil)
+		c.Assert(d.Model().Definitions["line"].field("note").Nullable, Equals, m.Definitions["line"].field("note").Nullable)
+		c.Assert(d.Definitions["line"], DeepEquals, *newSerializer(g.options).schema(m.Definitions["line"]))
+	}
+}
+
+func (self *modelSuite) TestUnions(c *C) {
+	schema := &JSONSchema{Property: Property{
+		Type:  "string",
+		Types: []string{"string", "integer", "null"},
+	}}
+	root := schema.Model().Root
+	c.Assert(root.Kind, Equals, KindUnion)
+	c.Assert(root.Nullable, Equals, true)
+	c.Assert(root.Variants, HasLen, 2)
+	c.Assert(root.Variants[0].Kind, Equals, KindString)
+	c.Assert(root.Variants[1].Kind, Equals, KindInteger)
+
+	schema = &JSONSchema{Property: Property{
+		OneOf: []*Property{{Type: "boolean"}, {Ref: "other.json"}},
+	}}
+	root = schema.Model().Root
+	c.Assert(root.Kind, Equals, KindUnion)
+	c.Assert(root.Variants[0].Kind, Equals, KindBoolean)
+	c.Assert(root.Variants[1].Kind, Equals, KindRef)
+	c.Assert(root.Variants[1].Ref, Equals, "other.json")
+	c.Assert(root.Variants[1].Definition, Equals, "")
+
+	c.Assert((&JSONSchema{}).Model().Root.Kind, Equals, KindAny)
+}
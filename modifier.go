@@ -0,0 +1,64 @@
+package jsonschema
+
+// SchemaModifier is implemented by types adjusting their own schema, e.g. to
+// add oneOf branches or set a format, once it has been generated.
+// ModifySchema is called on the zero value of the type, with either a value
+// or a pointer receiver. Types registered as definitions are modified once,
+// in the definition, rather than where they are referenced.
+type SchemaModifier interface {
+	ModifySchema(p *Property)
+}
+
+// modifySchema lets t modify the schema of n, and reads it back into n.
+func (r *reader) modifySchema(n *Node, t goType) {
+	// a type assertion rather than Type.Implements, which TinyGo doesn't
+	// fully support
+	if m, ok := t.Instance().(SchemaModifier); ok {
+		p := r.schema(n)
+		m.ModifySchema(p)
+		*n = *parseNode(p)
+	}
+}
+
+// Metadata documents a type, wherever it is described.
+type Metadata struct {
+	Title       string
+	Description string
+	Examples    []interface{}
+	Default     interface{}
+	Deprecated  bool
+}
+
+// MetadataProvider is implemented by types documenting themselves, as a single
+// source for the docs of the type whether it is described inline or as a
+// definition. SchemaMetadata is called on the zero value of the type, before
+// ModifySchema, and the tags of the fields of the type take precedence.
+type MetadataProvider interface {
+	SchemaMetadata() Metadata
+}
+
+func (r *reader) addMetadata(p *Property, t goType) {
+	m, ok := t.Instance().(MetadataProvider)
+	if !ok {
+		return
+	}
+	metadata := m.SchemaMetadata()
+	if metadata.Title != "" {
+		p.Title = metadata.Title
+	}
+	if metadata.Description != "" {
+		p.Description = metadata.Description
+		if r.options.MarkdownDescriptions {
+			p.MarkdownDescription = metadata.Description
+		}
+	}
+	if len(metadata.Examples) > 0 {
+		p.Examples = metadata.Examples
+	}
+	if metadata.Default != nil {
+		p.Default = metadata.Default
+	}
+	if metadata.Deprecated {
+		p.Deprecated = true
+	}
+}
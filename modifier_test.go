@@ -0,0 +1,117 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type modifierSuite struct{}
+
+var _ = Suite(&modifierSuite{})
+
+type ExampleJSONColor string
+
+func (ExampleJSONColor) ModifySchema(p *Property) {
+	p.Pattern = "^#[0-9a-f]{6}$"
+}
+
+type ExampleJSONShape struct {
+	Kind string `json:"kind"`
+}
+
+func (s *ExampleJSONShape) ModifySchema(p *Property) {
+	p.OneOf = []*Property{
+		{Properties: map[string]*Property{"kind": {Const: "circle"}}},
+		{Properties: map[string]*Property{"kind": {Const: "square"}}},
+	}
+}
+
+type ExampleJSONDrawing struct {
+	Background ExampleJSONColor   `json:"background"`
+	Stroke     *ExampleJSONColor  `json:"stroke"`
+	Palette    []ExampleJSONColor `json:"palette"`
+	Highlight  ExampleJSONColor   `json:"highlight" pattern:"^#fff$"`
+	Shape      ExampleJSONShape   `json:"shape"`
+	Shapes     []ExampleJSONShape `json:"shapes"`
+}
+
+func (self *modifierSuite) TestSchemaModifier(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONDrawing{}).MustGenerate()
+
+	c.Assert(j.Properties["background"], DeepEquals, &Property{Type: "string", Pattern: "^#[0-9a-f]{6}$"})
+	c.Assert(j.Properties["stroke"].AnyOf[0], DeepEquals, &Property{Type: "string"})
+	c.Assert(j.Properties["palette"].Items, DeepEquals, &Property{Type: "string", Pattern: "^#[0-9a-f]{6}$"})
+	c.Assert(j.Properties["highlight"].Pattern, Equals, "^#fff$")
+	c.Assert(j.Properties["shape"].OneOf, HasLen, 2)
+	c.Assert(j.Properties["shape"].Properties["kind"], DeepEquals, &Property{Type: "string"})
+}
+
+func (self *modifierSuite) TestSchemaModifierOnDefinition(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONDrawing{}).
+		WithDefinition("shape", ExampleJSONShape{}).
+		MustGenerate()
+
+	c.Assert(j.Properties["shape"], DeepEquals, &Property{Ref: "#/definitions/shape"})
+	c.Assert(j.Properties["shapes"].Items, DeepEquals, &Property{Ref: "#/definitions/shape"})
+	c.Assert(j.Definitions["shape"].OneOf, HasLen, 2)
+}
+
+type ExampleJSONCurrency string
+
+func (ExampleJSONCurrency) SchemaMetadata() Metadata {
+	return Metadata{
+		Title:       "Currency",
+		Description: "An ISO 4217 currency code.",
+		Examples:    []interface{}{"EUR"},
+		Default:     "USD",
+	}
+}
+
+type ExampleJSONLegacyAmount struct {
+	Value int64 `json:"value"`
+}
+
+func (*ExampleJSONLegacyAmount) SchemaMetadata() Metadata {
+	return Metadata{Description: "An amount in cents.", Deprecated: true}
+}
+
+type ExampleJSONInvoiceTotals struct {
+	Currency  ExampleJSONCurrency       `json:"currency"`
+	Local     *ExampleJSONCurrency      `json:"local"`
+	Billing   ExampleJSONCurrency       `json:"billing" title:"Billing currency" description:"The currency invoiced."`
+	Total     ExampleJSONLegacyAmount   `json:"total"`
+	Subtotals []ExampleJSONLegacyAmount `json:"subtotals"`
+}
+
+func (self *modifierSuite) TestSchemaMetadata(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONInvoiceTotals{}).MustGenerate()
+
+	c.Assert(j.Properties["currency"], DeepEquals, &Property{
+		Type:        "string",
+		Title:       "Currency",
+		Description: "An ISO 4217 currency code.",
+		Examples:    []interface{}{"EUR"},
+		Default:     "USD",
+	})
+	c.Assert(j.Properties["local"].Description, Equals, "An ISO 4217 currency code.")
+	c.Assert(j.Properties["local"].AnyOf, HasLen, 2)
+	c.Assert(j.Properties["billing"].Title, Equals, "Billing currency")
+	c.Assert(j.Properties["billing"].Description, Equals, "The currency invoiced.")
+	c.Assert(j.Properties["billing"].Default, Equals, "USD")
+	c.Assert(j.Properties["total"].Description, Equals, "An amount in cents.")
+	c.Assert(j.Properties["total"].Deprecated, Equals, true)
+	c.Assert(j.Properties["subtotals"].Items.Deprecated, Equals, true)
+}
+
+func (self *modifierSuite) TestSchemaMetadataOnDefinition(c *C) {
+	j := NewGenerator(Options{HumanizeTitles: true}).
+		WithRoot(&ExampleJSONInvoiceTotals{}).
+		WithDefinition("amount", ExampleJSONLegacyAmount{}).
+		MustGenerate()
+
+	c.Assert(j.Properties["total"], DeepEquals, &Property{Ref: "#/definitions/amount", Title: "Total"})
+	c.Assert(j.Definitions["amount"].Description, Equals, "An amount in cents.")
+	c.Assert(j.Definitions["amount"].Deprecated, Equals, true)
+	// the metadata of the type takes precedence over humanized titles
+	c.Assert(j.Properties["currency"].Title, Equals, "Currency")
+}
@@ -0,0 +1,48 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// jsonTypes are the types of JSON values which may be named by the types tag.
+var jsonTypes = []string{"string", "integer", "number", "boolean", "object", "array", "null"}
+
+// addTypesFromTags describes the values of a field accepting several JSON
+// types with the types tag, naming them separated by vertical bars, e.g.
+// `types:"string|integer"` for IDs which may be numeric, in the style set by
+// Options.NullableStyle.
+func (r *reader) addTypesFromTags(n *Node, tag *reflect.StructTag) error {
+	types, err := typesFromTags(tag)
+	if err != nil || types == nil {
+		return err
+	}
+	*n = *types
+	return nil
+}
+
+// typesFromTags returns the node of the values of the types named by the
+// types tag, a union of them unless it names a single one, or nil without
+// the tag.
+func typesFromTags(tag *reflect.StructTag) (*Node, error) {
+	raw, ok := tag.Lookup("types")
+	if !ok {
+		return nil, nil
+	}
+	if raw == "" {
+		return nil, fmt.Errorf(`empty "types" tag`)
+	}
+	names := strings.Split(raw, "|")
+	variants := make([]*Node, len(names))
+	for i, name := range names {
+		if !containsString(jsonTypes, name) {
+			return nil, fmt.Errorf(`unknown type %s in "types" tag`, name)
+		}
+		variants[i] = &Node{Kind: kindOfType(name), Schema: &Property{}}
+	}
+	if len(variants) == 1 {
+		return variants[0], nil
+	}
+	return &Node{Kind: KindUnion, Variants: variants, Schema: &Property{}}, nil
+}
@@ -0,0 +1,45 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type multiTypeSuite struct{}
+
+var _ = Suite(&multiTypeSuite{})
+
+type ExampleJSONExternalRef struct {
+	ID     interface{} `json:"id" types:"string|integer" required:"true"`
+	Amount json.Number `json:"amount" types:"number|string" description:"An amount, quoted by some clients."`
+	Label  string      `json:"label" types:"string"`
+}
+
+type ExampleJSONUnknownType struct {
+	ID interface{} `json:"id" types:"string|text"`
+}
+
+func (self *multiTypeSuite) TestTypesTag(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONExternalRef{}).MustGenerate()
+	c.Assert(j.Properties["id"], DeepEquals, &Property{AnyOf: []*Property{{Type: "string"}, {Type: "integer"}}})
+	c.Assert(j.Properties["amount"], DeepEquals, &Property{
+		AnyOf:       []*Property{{Type: "number"}, {Type: "string"}},
+		Description: "An amount, quoted by some clients.",
+	})
+	c.Assert(j.Properties["label"], DeepEquals, &Property{Type: "string"})
+
+	errs, err := j.Validate([]byte(`{"id": 42, "amount": "1.50"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	errs, err = j.Validate([]byte(`{"id": true}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Keyword, Equals, "anyOf")
+
+	j = NewGenerator(Options{NullableStyle: TypeArray}).WithRoot(&ExampleJSONExternalRef{}).MustGenerate()
+	c.Assert(j.Properties["id"], DeepEquals, &Property{Types: []string{"string", "integer"}})
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONUnknownType{}).Generate()
+	c.Assert(err, ErrorMatches, `error on root type .*: property:ID:unknown type text in "types" tag`)
+}
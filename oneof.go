@@ -0,0 +1,57 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var rTypeRawMessage = reflect.TypeOf(json.RawMessage(nil))
+
+// addOneOfFromTags describes the values of an interface or json.RawMessage
+// field with the oneOf tag, naming the definitions of the alternatives
+// separated by vertical bars, e.g. `oneOf:"catPayload|dogPayload"`.
+func (r *reader) addOneOfFromTags(n *Node, tag *reflect.StructTag, t goType) error {
+	if _, ok := tag.Lookup("oneOf"); !ok {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Interface && t.Name() != typeName(rTypeRawMessage) {
+		return fmt.Errorf(`"oneOf" tag on %s, which is neither an interface nor a json.RawMessage`, t)
+	}
+	union, err := oneOfFromTags(tag, func(name string) bool {
+		for _, known := range r.knownTypes {
+			if known == name {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	*n = *union
+	return nil
+}
+
+// oneOfFromTags returns the exclusive union of references to the definitions
+// named by the oneOf tag, which must be known.
+func oneOfFromTags(tag *reflect.StructTag, known func(name string) bool) (*Node, error) {
+	raw := tag.Get("oneOf")
+	if raw == "" {
+		return nil, fmt.Errorf(`empty "oneOf" tag`)
+	}
+	union := &Node{Kind: KindUnion, Exclusive: true, Schema: &Property{}}
+	for _, name := range strings.Split(raw, "|") {
+		if !known(name) {
+			return nil, fmt.Errorf(`unknown definition %s in "oneOf" tag`, name)
+		}
+		variant := newNode()
+		variant.setRef(definitionReference(name))
+		union.Variants = append(union.Variants, variant)
+	}
+	return union, nil
+}
@@ -0,0 +1,65 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type oneOfSuite struct{}
+
+var _ = Suite(&oneOfSuite{})
+
+type ExampleJSONCatPayload struct {
+	Lives int `json:"lives" required:"true"`
+}
+
+type ExampleJSONDogPayload struct {
+	Breed string `json:"breed" required:"true"`
+}
+
+type ExampleJSONPetEvent struct {
+	Payload  interface{}     `json:"payload" oneOf:"catPayload|dogPayload" description:"The pet."`
+	Raw      json.RawMessage `json:"raw" oneOf:"dogPayload"`
+	Previous *interface{}    `json:"previous" oneOf:"catPayload"`
+}
+
+type ExampleJSONMisplacedOneOf struct {
+	Payload string `json:"payload" oneOf:"catPayload"`
+}
+
+type ExampleJSONUnknownOneOf struct {
+	Payload interface{} `json:"payload" oneOf:"catPayload|fishPayload"`
+}
+
+func petGenerator() *Generator {
+	return NewGenerator().
+		WithDefinition("catPayload", ExampleJSONCatPayload{}).
+		WithDefinition("dogPayload", ExampleJSONDogPayload{})
+}
+
+func (self *oneOfSuite) TestOneOfTag(c *C) {
+	j := petGenerator().WithRoot(&ExampleJSONPetEvent{}).MustGenerate()
+
+	c.Assert(j.Properties["payload"], DeepEquals, &Property{
+		Description: "The pet.",
+		OneOf: []*Property{
+			{Ref: "#/definitions/catPayload"},
+			{Ref: "#/definitions/dogPayload"},
+		},
+	})
+	c.Assert(j.Properties["raw"], DeepEquals, &Property{OneOf: []*Property{{Ref: "#/definitions/dogPayload"}}})
+	c.Assert(j.Properties["previous"].OneOf, HasLen, 1)
+
+	errs, err := j.Validate([]byte(`{"payload": {"lives": 9}, "raw": {"breed": "corgi"}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+}
+
+func (self *oneOfSuite) TestOneOfTagErrors(c *C) {
+	_, err := petGenerator().WithRoot(&ExampleJSONMisplacedOneOf{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Payload:"oneOf" tag on string, which is neither an interface nor a json.RawMessage`)
+
+	_, err = petGenerator().WithRoot(&ExampleJSONUnknownOneOf{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Payload:unknown definition fishPayload in "oneOf" tag`)
+}
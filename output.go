@@ -0,0 +1,167 @@
+package jsonschema
+
+import (
+	"context"
+)
+
+// OutputFormat is one of the output formats of validation results defined by
+// JSON Schema, understood by other tooling and generic schema UIs.
+type OutputFormat int
+
+const (
+	// OutputFlag only tells whether the document is valid.
+	OutputFlag OutputFormat = iota
+	// OutputBasic lists the violations.
+	OutputBasic
+	// OutputDetailed arranges the violations by the subschemas they occur in,
+	// leaving out the subschemas with a single violation.
+	OutputDetailed
+	// OutputVerbose arranges the evaluations of every subschema, valid or not.
+	OutputVerbose
+)
+
+// OutputUnit is a unit of the output formats. Locations are JSON pointers,
+// omitted when they point to the root of the schema or of the document.
+type OutputUnit struct {
+	Valid            bool   `json:"valid"`
+	KeywordLocation  string `json:"keywordLocation,omitempty"`
+	InstanceLocation string `json:"instanceLocation,omitempty"`
+	Error            string `json:"error,omitempty"`
+	// Errors holds the units of the subschemas and keywords which failed.
+	Errors []OutputUnit `json:"errors,omitempty"`
+	// Annotations holds the units of the subschemas which passed, in the
+	// verbose format.
+	Annotations []OutputUnit `json:"annotations,omitempty"`
+}
+
+// ValidateOutput validates the JSON document data and returns the result in
+// the given output format. It returns an error if data isn't a JSON document.
+func (v *Validator) ValidateOutput(data []byte, format OutputFormat) (*OutputUnit, error) {
+	return v.ValidateOutputContext(context.Background(), data, format)
+}
+
+// ValidateOutputContext validates the JSON document data as ValidateOutput
+// does, and stops with the error of ctx once it is done, as ValidateContext
+// does.
+func (v *Validator) ValidateOutputContext(ctx context.Context, data []byte, format OutputFormat) (*OutputUnit, error) {
+	doc, err := v.decode(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &outputNode{}
+	s, err := v.run(ctx, v.root, doc, "", "", root)
+	if err != nil {
+		return nil, err
+	}
+	evaluation := root.children[0]
+
+	switch format {
+	case OutputFlag:
+		return &OutputUnit{Valid: len(s.errors) == 0}, nil
+	case OutputBasic:
+		unit := &OutputUnit{Valid: len(s.errors) == 0}
+		for _, e := range s.errors {
+			unit.Errors = append(unit.Errors, errorUnit(e))
+		}
+		return unit, nil
+	case OutputDetailed:
+		unit := evaluation.detailed()
+		return &unit, nil
+	default:
+		unit := evaluation.verbose()
+		return &unit, nil
+	}
+}
+
+// outputNode is the evaluation of a subschema against a value.
+type outputNode struct {
+	keywordLocation  string
+	instanceLocation string
+	// errors holds the violations of the keywords of the subschema
+	errors []ValidationError
+	// children holds the evaluations of the subschemas of the subschema
+	children []*outputNode
+	// branch is set for the evaluations of the subschemas of anyOf, oneOf
+	// and not, whose failure doesn't make the subschema itself invalid
+	branch bool
+}
+
+func (n *outputNode) valid() bool {
+	if len(n.errors) > 0 {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.branch && !c.valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// explainsFailure reports whether the failure of the child is part of the
+// failure of n: the failed branches are, when none of them matched.
+func (n *outputNode) explainsFailure(c *outputNode) bool {
+	if c.valid() {
+		return false
+	}
+	if !c.branch {
+		return true
+	}
+	for _, e := range n.errors {
+		if e.Keyword == "anyOf" || e.Keyword == "oneOf" {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *outputNode) detailed() OutputUnit {
+	unit := OutputUnit{
+		Valid:            n.valid(),
+		KeywordLocation:  n.keywordLocation,
+		InstanceLocation: n.instanceLocation,
+	}
+	for _, e := range n.errors {
+		unit.Errors = append(unit.Errors, errorUnit(e))
+	}
+	for _, c := range n.children {
+		if !n.explainsFailure(c) {
+			continue
+		}
+		child := c.detailed()
+		if len(child.Errors) == 1 {
+			// subschemas with a single violation are left out
+			child = child.Errors[0]
+		}
+		unit.Errors = append(unit.Errors, child)
+	}
+	return unit
+}
+
+func (n *outputNode) verbose() OutputUnit {
+	unit := OutputUnit{
+		Valid:            n.valid(),
+		KeywordLocation:  n.keywordLocation,
+		InstanceLocation: n.instanceLocation,
+	}
+	for _, e := range n.errors {
+		unit.Errors = append(unit.Errors, errorUnit(e))
+	}
+	for _, c := range n.children {
+		if c.valid() {
+			unit.Annotations = append(unit.Annotations, c.verbose())
+		} else {
+			unit.Errors = append(unit.Errors, c.verbose())
+		}
+	}
+	return unit
+}
+
+func errorUnit(e ValidationError) OutputUnit {
+	return OutputUnit{
+		KeywordLocation:  e.SchemaPath,
+		InstanceLocation: e.InstancePath,
+		Error:            e.Message,
+	}
+}
@@ -0,0 +1,146 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	. "gopkg.in/check.v1"
+)
+
+type outputSuite struct{}
+
+var _ = Suite(&outputSuite{})
+
+func outputValidator(c *C) *Validator {
+	js := &JSONSchema{}
+	c.Assert(json.Unmarshal([]byte(`{
+		"definitions": {
+			"point": {
+				"type": "object",
+				"properties": {"x": {"type": "number"}, "y": {"type": "number"}},
+				"required": ["x", "y"]
+			}
+		},
+		"type": "array",
+		"items": {"$ref": "#/definitions/point"}
+	}`), js), IsNil)
+	v, err := NewValidator(js)
+	c.Assert(err, IsNil)
+	return v
+}
+
+func (self *outputSuite) TestFlagAndBasic(c *C) {
+	v := outputValidator(c)
+
+	unit, err := v.ValidateOutput([]byte(`[{"x": 1, "y": 2}]`), OutputFlag)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{Valid: true})
+
+	unit, err = v.ValidateOutput([]byte(`[{"x": 1}, {"x": "a", "y": 2}]`), OutputFlag)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{Valid: false})
+
+	unit, err = v.ValidateOutput([]byte(`[{"x": 1}, {"x": "a", "y": 2}]`), OutputBasic)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{
+		Errors: []OutputUnit{
+			{KeywordLocation: "/items/$ref/required", InstanceLocation: "/0", Error: "missing required property y"},
+			{KeywordLocation: "/items/$ref/properties/x/type", InstanceLocation: "/1/x", Error: "must be of type number, not string"},
+		},
+	})
+	b, err := json.Marshal(unit.Errors[0])
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"valid":false,"keywordLocation":"/items/$ref/required","instanceLocation":"/0","error":"missing required property y"}`)
+}
+
+func (self *outputSuite) TestDetailed(c *C) {
+	v := outputValidator(c)
+
+	unit, err := v.ValidateOutput([]byte(`[{"x": 1}, {"x": "a", "y": "b"}]`), OutputDetailed)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{
+		Errors: []OutputUnit{
+			{KeywordLocation: "/items/$ref/required", InstanceLocation: "/0", Error: "missing required property y"},
+			{
+				KeywordLocation:  "/items/$ref",
+				InstanceLocation: "/1",
+				Errors: []OutputUnit{
+					{KeywordLocation: "/items/$ref/properties/x/type", InstanceLocation: "/1/x", Error: "must be of type number, not string"},
+					{KeywordLocation: "/items/$ref/properties/y/type", InstanceLocation: "/1/y", Error: "must be of type number, not string"},
+				},
+			},
+		},
+	})
+
+	unit, err = v.ValidateOutput([]byte(`[]`), OutputDetailed)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{Valid: true})
+}
+
+func (self *outputSuite) TestDetailedBranches(c *C) {
+	js := &JSONSchema{}
+	c.Assert(json.Unmarshal([]byte(`{
+		"properties": {
+			"a": {"anyOf": [{"type": "string"}, {"type": "integer", "minimum": 1}]},
+			"b": {"anyOf": [{"type": "string"}, {"type": "integer"}]}
+		}
+	}`), js), IsNil)
+	v, err := NewValidator(js)
+	c.Assert(err, IsNil)
+
+	// the branches which failed are only reported when none matched
+	unit, err := v.ValidateOutput([]byte(`{"a": 0, "b": 1}`), OutputDetailed)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{
+		Errors: []OutputUnit{{
+			KeywordLocation:  "/properties/a",
+			InstanceLocation: "/a",
+			Errors: []OutputUnit{
+				{KeywordLocation: "/properties/a/anyOf", InstanceLocation: "/a", Error: "must match at least one of the schemas"},
+				{KeywordLocation: "/properties/a/anyOf/0/type", InstanceLocation: "/a", Error: "must be of type string, not number"},
+				{KeywordLocation: "/properties/a/anyOf/1/minimum", InstanceLocation: "/a", Error: "must be greater than or equal to 1"},
+			},
+		}},
+	})
+}
+
+func (self *outputSuite) TestVerbose(c *C) {
+	v := outputValidator(c)
+
+	unit, err := v.ValidateOutput([]byte(`[{"x": 1, "y": 2}, {"x": 1}]`), OutputVerbose)
+	c.Assert(err, IsNil)
+	c.Assert(unit, DeepEquals, &OutputUnit{
+		Annotations: []OutputUnit{{
+			Valid:            true,
+			KeywordLocation:  "/items",
+			InstanceLocation: "/0",
+			Annotations: []OutputUnit{{
+				Valid:            true,
+				KeywordLocation:  "/items/$ref",
+				InstanceLocation: "/0",
+				Annotations: []OutputUnit{
+					{Valid: true, KeywordLocation: "/items/$ref/properties/x", InstanceLocation: "/0/x"},
+					{Valid: true, KeywordLocation: "/items/$ref/properties/y", InstanceLocation: "/0/y"},
+				},
+			}},
+		}},
+		Errors: []OutputUnit{{
+			KeywordLocation:  "/items",
+			InstanceLocation: "/1",
+			Errors: []OutputUnit{{
+				KeywordLocation:  "/items/$ref",
+				InstanceLocation: "/1",
+				Errors: []OutputUnit{
+					{KeywordLocation: "/items/$ref/required", InstanceLocation: "/1", Error: "missing required property y"},
+				},
+				Annotations: []OutputUnit{
+					{Valid: true, KeywordLocation: "/items/$ref/properties/x", InstanceLocation: "/1/x"},
+				},
+			}},
+		}},
+	})
+}
+
+func (self *outputSuite) TestInvalidDocument(c *C) {
+	_, err := outputValidator(c).ValidateOutput([]byte(`[`), OutputBasic)
+	c.Assert(err, ErrorMatches, "invalid JSON document: .*")
+}
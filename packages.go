@@ -0,0 +1,79 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadedPackage is a package parsed and type-checked from source.
+type loadedPackage struct {
+	Path  string
+	Fset  *token.FileSet
+	Files []*ast.File
+	Types *types.Package
+	Info  *types.Info
+}
+
+// loadMode is what is loaded of the packages matched and their dependencies:
+// their syntax and types, checked from source so that they don't depend on
+// the export data format of the toolchain.
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps
+
+// loadPackages parses and type-checks the packages matching pattern, as the
+// go command does, e.g. a directory or an import path, followed by "/..." to
+// include the packages below it. Test files are left out and build
+// constraints honored, with the build tags given set. Type errors are
+// tolerated, leaving the types involved invalid, so that packages which don't
+// build may still be inspected.
+func loadPackages(pattern string, tags ...string) ([]*loadedPackage, error) {
+	cfg := &packages.Config{Mode: loadMode, Fset: token.NewFileSet(), ParseFile: parseDeclarations}
+	if len(tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(tags, ",")}
+	}
+	loaded, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []*loadedPackage
+	for _, p := range loaded {
+		for _, e := range p.Errors {
+			// errors of types are tolerated, others leave the package
+			// unread
+			if e.Kind != packages.TypeError {
+				return nil, fmt.Errorf("package %s: %s", p.PkgPath, e.Msg)
+			}
+		}
+		pkgs = append(pkgs, &loadedPackage{
+			Path:  p.PkgPath,
+			Fset:  p.Fset,
+			Files: p.Syntax,
+			Types: p.Types,
+			Info:  p.TypesInfo,
+		})
+	}
+	return pkgs, nil
+}
+
+// parseDeclarations parses a file of the packages loaded without the bodies
+// of its functions, which the types declared don't depend on, so that the
+// dependencies are type-checked quickly.
+func parseDeclarations(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+	if f != nil {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				fn.Body = nil
+			}
+		}
+	}
+	return f, err
+}
@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PactMatcher is a Pact (specification v3) matcher.
+type PactMatcher struct {
+	Match string      `json:"match"`
+	Regex string      `json:"regex,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PactRule holds the matchers applying to one path of a Pact body.
+type PactRule struct {
+	Matchers []PactMatcher `json:"matchers"`
+	// Combine is "OR" when any of the matchers may match, e.g. for nullable values.
+	Combine string `json:"combine,omitempty"`
+}
+
+// dateTimeRegex matches the date-time format of RFC 3339.
+const dateTimeRegex = `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`
+
+// PactMatchingRules converts a schema to the matching rules of the body of a
+// Pact interaction, keyed by path (e.g. "$.items[*].name"), so that provider
+// verification checks the types and constraints of the schema rather than
+// the exact example values. The result goes under "matchingRules.body".
+func PactMatchingRules(schema *JSONSchema) map[string]PactRule {
+	c := &pactConverter{schema: schema, rules: map[string]PactRule{}, visiting: map[string]bool{}}
+	c.convert("$", &schema.Property)
+	return c.rules
+}
+
+type pactConverter struct {
+	schema   *JSONSchema
+	rules    map[string]PactRule
+	visiting map[string]bool
+}
+
+func (c *pactConverter) convert(path string, p *Property) {
+	if p == nil {
+		return
+	}
+	if ref := p.Ref; ref != "" {
+		if c.visiting[ref] {
+			return
+		}
+		c.visiting[ref] = true
+		defer delete(c.visiting, ref)
+	}
+	p = c.schema.resolve(p)
+
+	if p.Type == "" {
+		// nullable values emitted for pointers
+		var branch *Property
+		nullable := false
+		for _, s := range p.AnyOf {
+			if s.Type == "null" {
+				nullable = true
+			} else if branch == nil {
+				branch = s
+			}
+		}
+		if branch != nil && nullable && len(p.AnyOf) == 2 {
+			matcher, ok := c.matcher(c.schema.resolve(branch))
+			if ok {
+				c.rules[path] = PactRule{
+					Matchers: []PactMatcher{matcher, {Match: "null"}},
+					Combine:  "OR",
+				}
+			}
+			return
+		}
+	}
+
+	if matcher, ok := c.matcher(p); ok {
+		c.rules[path] = PactRule{Matchers: []PactMatcher{matcher}}
+	}
+
+	switch p.Type {
+	case "object":
+		for _, name := range sortedPropertyNames(p.Properties) {
+			if name == ".*" {
+				c.convert(path+".*", p.Properties[name])
+				continue
+			}
+			c.convert(pactPath(path, name), p.Properties[name])
+		}
+		c.convert(path+".*", p.AdditionalPropertiesSchema)
+	case "array":
+		c.convert(path+"[*]", p.Items)
+	}
+}
+
+// matcher returns the matcher of a value described by p, for values which are not objects.
+func (c *pactConverter) matcher(p *Property) (PactMatcher, bool) {
+	switch {
+	case p.Const != nil:
+		return PactMatcher{Match: "equality", Value: p.Const}, true
+	case p.Type == "string" && len(p.Enum) > 0:
+		values := make([]string, len(p.Enum))
+		for i, v := range p.Enum {
+			values[i] = regexp.QuoteMeta(fmt.Sprint(v))
+		}
+		return PactMatcher{Match: "regex", Regex: fmt.Sprintf("^(%s)$", strings.Join(values, "|"))}, true
+	case p.Type == "string" && p.Pattern != "":
+		return PactMatcher{Match: "regex", Regex: p.Pattern}, true
+	case p.Type == "string" && p.Format == "date-time":
+		return PactMatcher{Match: "regex", Regex: dateTimeRegex}, true
+	case p.Type == "integer":
+		return PactMatcher{Match: "integer"}, true
+	case p.Type == "number":
+		return PactMatcher{Match: "number"}, true
+	case p.Type == "string", p.Type == "boolean", p.Type == "array":
+		return PactMatcher{Match: "type"}, true
+	}
+	return PactMatcher{}, false
+}
+
+var pactIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func pactPath(parent, name string) string {
+	if pactIdentifier.MatchString(name) {
+		return parent + "." + name
+	}
+	return fmt.Sprintf("%s['%s']", parent, strings.Replace(name, "'", `\'`, -1))
+}
@@ -0,0 +1,46 @@
+package jsonschema
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type pactSuite struct{}
+
+var _ = Suite(&pactSuite{})
+
+type ExampleJSONPactOrder struct {
+	ID       int64              `json:"id"`
+	Status   string             `json:"status" enum:"open|closed"`
+	Code     string             `json:"code" pattern:"^[A-Z]{3}$"`
+	Version  string             `json:"version" const:"v1"`
+	Total    float64            `json:"total"`
+	Paid     bool               `json:"paid"`
+	Created  time.Time          `json:"created"`
+	Note     *string            `json:"note"`
+	Lines    []ExampleJSONCard  `json:"lines"`
+	Labels   map[string]string  `json:"labels"`
+	Customer ExampleJSONAddress `json:"customer info"`
+}
+
+func (self *pactSuite) TestPactMatchingRules(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONPactOrder{}).WithDefinition("card", ExampleJSONCard{}).MustGenerate()
+
+	c.Assert(PactMatchingRules(j), DeepEquals, map[string]PactRule{
+		"$.id":                      {Matchers: []PactMatcher{{Match: "integer"}}},
+		"$.status":                  {Matchers: []PactMatcher{{Match: "regex", Regex: "^(open|closed)$"}}},
+		"$.code":                    {Matchers: []PactMatcher{{Match: "regex", Regex: "^[A-Z]{3}$"}}},
+		"$.version":                 {Matchers: []PactMatcher{{Match: "equality", Value: "v1"}}},
+		"$.total":                   {Matchers: []PactMatcher{{Match: "number"}}},
+		"$.paid":                    {Matchers: []PactMatcher{{Match: "type"}}},
+		"$.created":                 {Matchers: []PactMatcher{{Match: "regex", Regex: dateTimeRegex}}},
+		"$.note":                    {Matchers: []PactMatcher{{Match: "type"}, {Match: "null"}}, Combine: "OR"},
+		"$.lines":                   {Matchers: []PactMatcher{{Match: "type"}}},
+		"$.lines[*].number":         {Matchers: []PactMatcher{{Match: "type"}}},
+		"$.lines[*].brand":          {Matchers: []PactMatcher{{Match: "type"}}},
+		"$.labels.*":                {Matchers: []PactMatcher{{Match: "type"}}},
+		"$['customer info'].street": {Matchers: []PactMatcher{{Match: "type"}}},
+		"$['customer info'].zip":    {Matchers: []PactMatcher{{Match: "type"}}},
+	})
+}
@@ -0,0 +1,43 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Page returns the type of the pages of a list of values of the type of of,
+// to register as a root or definition, e.g. of list endpoints:
+//
+//	{"items": [...], "total": 42, "nextCursor": "..."}
+//
+// The items reference the definition of their type, when registered. The
+// next cursor is absent from the last page. of may be an instance of the type
+// or its reflect.Type. Pages are built with reflect.StructOf, which TinyGo
+// doesn't support.
+func Page(of interface{}) reflect.Type {
+	return PageWithFields(of, "items", "total", "nextCursor")
+}
+
+// PageWithFields returns the type of the pages of a list of values of the
+// type of of, as Page does, with other names for the fields of the page.
+func PageWithFields(of interface{}, items, total, nextCursor string) reflect.Type {
+	return reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Items",
+			Type: reflect.SliceOf(linkType(of)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q required:"true" description:"The items of the page."`, items)),
+		},
+		{
+			Name: "Total",
+			Type: reflect.TypeOf(0),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q required:"true" min:"0" description:"The number of items of all the pages."`, total)),
+		},
+		{
+			Name: "NextCursor",
+			Type: reflect.TypeOf(""),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q description:"The cursor of the next page, absent from the last page."`, nextCursor+",omitempty")),
+		},
+	})
+}
@@ -0,0 +1,50 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type pageSuite struct{}
+
+var _ = Suite(&pageSuite{})
+
+type ExamplePageUser struct {
+	Name string `json:"name"`
+}
+
+func (self *pageSuite) TestPage(c *C) {
+	j := NewGenerator().
+		WithDefinition("user", ExamplePageUser{}).
+		WithRoot(Page(ExamplePageUser{})).
+		MustGenerate()
+
+	c.Assert(j.Type, Equals, "object")
+	c.Assert(j.Required, DeepEquals, []string{"items", "total"})
+	c.Assert(j.Properties["items"].Items, DeepEquals, &Property{Ref: "#/definitions/user"})
+	c.Assert(*j.Properties["total"].Minimum, Equals, 0.0)
+	c.Assert(j.Properties["nextCursor"].Type, Equals, "string")
+
+	v, err := NewValidator(j)
+	c.Assert(err, IsNil)
+	errs, err := v.Validate([]byte(`{"items": [{"name": "ada"}], "total": 1}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	errs, err = v.Validate([]byte(`{"items": [], "total": -1, "nextCursor": "a"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+
+	// pages of the same type are the same type, registered once
+	j = NewGenerator().
+		WithDefinition("userPage", Page(&ExamplePageUser{})).
+		WithRoot(Page(ExamplePageUser{})).
+		MustGenerate()
+	c.Assert(j.Ref, Equals, "#/definitions/userPage")
+}
+
+func (self *pageSuite) TestPageWithFields(c *C) {
+	j := NewGenerator().WithRoot(PageWithFields("", "data", "count", "next")).MustGenerate()
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{"count", "data", "next"})
+	c.Assert(j.Properties["data"].Items, DeepEquals, &Property{Type: "string"})
+}
@@ -0,0 +1,120 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// PathMap maps the paths of the fields of the type of root, e.g.
+// "Domain.NestedItem.NestedItemValue", to the JSON pointers of their values in
+// documents, e.g. "/nestedItem/nestedItemValue", and the pointers back to the
+// field paths, so that validation errors can be attached to struct fields.
+// The items of slices and the values of maps are matched by "*" in pointers,
+// e.g. "/lines/*/sku" for "Order.Lines.SKU": FieldForPointer finds the field
+// of pointers to a given item. root may be an instance of the type or its reflect.Type.
+func PathMap(root interface{}) map[string]string {
+	t, ok := root.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(root)
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	paths := map[string]string{}
+	addPaths(paths, t.Name(), "", t, map[reflect.Type]bool{})
+	return paths
+}
+
+func addPaths(paths map[string]string, field, pointer string, t reflect.Type, visiting map[reflect.Type]bool) {
+	paths[field] = pointer
+	paths[pointer] = field
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := formatMapping[t.String()]; ok {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		addElementPaths(paths, field, pointer+"/*", t.Elem(), visiting)
+	case reflect.Map:
+		addElementPaths(paths, field, pointer+"/*", t.Elem(), visiting)
+	case reflect.Struct:
+		if visiting[t] {
+			return
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			name, _ := parseTag(f.Tag.Get("json"))
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			addPaths(paths, field+"."+f.Name, pointer+"/"+escapePointer(name), f.Type, visiting)
+		}
+	}
+}
+
+// addElementPaths adds the paths of the fields of the items of slices and the
+// values of maps, which share the field path of the slice or map.
+func addElementPaths(paths map[string]string, field, pointer string, t reflect.Type, visiting map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		elements := map[string]string{}
+		addPaths(elements, field, pointer, t, visiting)
+		for k, v := range elements {
+			if k == field || k == pointer {
+				continue
+			}
+			paths[k] = v
+		}
+	}
+}
+
+// FieldForPointer returns the path of the field holding the value at the JSON
+// pointer of a document, e.g. "Order.Lines.SKU" for "/lines/3/sku", or of the
+// closest field holding it, using a PathMap.
+func FieldForPointer(paths map[string]string, pointer string) (string, bool) {
+	if field, ok := paths[pointer]; ok {
+		return field, true
+	}
+	if pointer == "" || !strings.HasPrefix(pointer, "/") {
+		return "", false
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for n := len(tokens); n >= 0; n-- {
+		if field, ok := matchPointer(paths, "", tokens[:n]); ok {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+// matchPointer returns the field of the pointer prefix followed by tokens,
+// with tokens matching themselves or the "*" of items and values.
+func matchPointer(paths map[string]string, prefix string, tokens []string) (string, bool) {
+	if len(tokens) == 0 {
+		field, ok := paths[prefix]
+		return field, ok
+	}
+	for _, token := range []string{tokens[0], "*"} {
+		if field, ok := matchPointer(paths, prefix+"/"+token, tokens[1:]); ok {
+			return field, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,82 @@
+package jsonschema
+
+import (
+	"reflect"
+
+	. "gopkg.in/check.v1"
+)
+
+type pathMapSuite struct{}
+
+var _ = Suite(&pathMapSuite{})
+
+type ExampleJSONPathLine struct {
+	SKU   string            `json:"sku"`
+	Attrs map[string]string `json:"attrs"`
+}
+
+type ExampleJSONPathOrder struct {
+	ID       string                          `json:"id"`
+	Lines    []ExampleJSONPathLine           `json:"lines"`
+	ByRegion map[string]*ExampleJSONPathLine `json:"byRegion"`
+	Parent   *ExampleJSONPathOrder           `json:"parent"`
+	Tags     []string
+	Secret   string `json:"-"`
+	internal string
+}
+
+func (self *pathMapSuite) TestPathMap(c *C) {
+	paths := PathMap(&ExampleJSONPathOrder{})
+	c.Assert(paths, DeepEquals, map[string]string{
+		"ExampleJSONPathOrder":                ``,
+		"ExampleJSONPathOrder.ID":             `/id`,
+		"ExampleJSONPathOrder.Lines":          `/lines`,
+		"ExampleJSONPathOrder.Lines.SKU":      `/lines/*/sku`,
+		"ExampleJSONPathOrder.Lines.Attrs":    `/lines/*/attrs`,
+		"ExampleJSONPathOrder.ByRegion":       `/byRegion`,
+		"ExampleJSONPathOrder.ByRegion.SKU":   `/byRegion/*/sku`,
+		"ExampleJSONPathOrder.ByRegion.Attrs": `/byRegion/*/attrs`,
+		"ExampleJSONPathOrder.Parent":         `/parent`,
+		"ExampleJSONPathOrder.Tags":           `/Tags`,
+		``:                                    "ExampleJSONPathOrder",
+		`/id`:                                 "ExampleJSONPathOrder.ID",
+		`/lines`:                              "ExampleJSONPathOrder.Lines",
+		`/lines/*/sku`:                        "ExampleJSONPathOrder.Lines.SKU",
+		`/lines/*/attrs`:                      "ExampleJSONPathOrder.Lines.Attrs",
+		`/byRegion`:                           "ExampleJSONPathOrder.ByRegion",
+		`/byRegion/*/sku`:                     "ExampleJSONPathOrder.ByRegion.SKU",
+		`/byRegion/*/attrs`:                   "ExampleJSONPathOrder.ByRegion.Attrs",
+		`/parent`:                             "ExampleJSONPathOrder.Parent",
+		`/Tags`:                               "ExampleJSONPathOrder.Tags",
+	})
+	c.Assert(PathMap(reflect.TypeOf(ExampleJSONPathOrder{})), DeepEquals, paths)
+}
+
+func (self *pathMapSuite) TestFieldForPointer(c *C) {
+	paths := PathMap(&ExampleJSONPathOrder{})
+	for pointer, field := range map[string]string{
+		"":                  "ExampleJSONPathOrder",
+		"/id":               "ExampleJSONPathOrder.ID",
+		"/lines/3/sku":      "ExampleJSONPathOrder.Lines.SKU",
+		"/lines/3":          "ExampleJSONPathOrder.Lines",
+		"/lines/3/attrs/x":  "ExampleJSONPathOrder.Lines.Attrs",
+		"/byRegion/eu/sku":  "ExampleJSONPathOrder.ByRegion.SKU",
+		"/Tags/0":           "ExampleJSONPathOrder.Tags",
+		"/parent/id":        "ExampleJSONPathOrder.Parent",
+		"/unknown/property": "ExampleJSONPathOrder",
+	} {
+		f, ok := FieldForPointer(paths, pointer)
+		c.Check(ok, Equals, true, Commentf("%s", pointer))
+		c.Check(f, Equals, field, Commentf("%s", pointer))
+	}
+
+	_, ok := FieldForPointer(paths, "id")
+	c.Assert(ok, Equals, false)
+
+	// validation errors attached to fields
+	errs, err := NewGenerator().WithRoot(&ExampleJSONPathOrder{}).MustGenerate().Validate([]byte(`{"lines": [{"sku": 1}]}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+	f, _ := FieldForPointer(paths, errs[0].InstancePath)
+	c.Assert(f, Equals, "ExampleJSONPathOrder.Lines.SKU")
+}
@@ -0,0 +1,89 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// compiledPatterns caches the patterns compiled when generating schemas,
+// for the validators of the schemas. Patterns of schemas from other sources
+// aren't cached, so that the cache remains bounded.
+var compiledPatterns sync.Map
+
+// compilePatterns compiles the patterns of the root and definitions of d,
+// so that invalid patterns fail the generation, with their location in the
+// schema, rather than the validation of documents.
+func (d *JSONSchema) compilePatterns() error {
+	var err error
+	compile := func(path string, p *Property) {
+		if err != nil || p.Pattern == "" {
+			return
+		}
+		if _, ok := compiledPatterns.Load(p.Pattern); ok {
+			return
+		}
+		re, compileErr := regexp.Compile(p.Pattern)
+		if compileErr != nil {
+			err = fmt.Errorf("invalid pattern %q at %s: %s", p.Pattern, path, compileErr)
+			return
+		}
+		compiledPatterns.Store(p.Pattern, re)
+	}
+	walkPropertyPaths("", &d.Property, compile)
+	for _, name := range sortedDefinitionNames(d.Definitions) {
+		def := d.Definitions[name]
+		walkPropertyPaths("/definitions/"+escapePointer(name), &def, compile)
+	}
+	return err
+}
+
+// Patterns of common string values, which the pattern tag names as macros.
+const (
+	PatternUUID    = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	PatternRFC3339 = `^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})$`
+	// PatternRFC3339UTC matches the RFC 3339 date-times in UTC, suffixed by Z.
+	PatternRFC3339UTC = `^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}(\.\d+)?Z$`
+	// PatternE164 matches phone numbers in the E.164 format, e.g. +33123456789.
+	PatternE164 = `^\+[1-9]\d{1,14}$`
+	// PatternSemver matches semantic versions, as suggested by semver.org.
+	PatternSemver = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
+	// PatternSlug matches lowercase words separated by hyphens, e.g. "blue-shirt".
+	PatternSlug = `^[a-z0-9]+(?:-[a-z0-9]+)*$`
+	// PatternInteger matches integers written as strings, e.g. "-42".
+	PatternInteger = `^-?(0|[1-9]\d*)$`
+	// PatternDecimal matches decimal numbers written as strings, e.g. "12.50".
+	PatternDecimal = `^-?(0|[1-9]\d*)(\.\d+)?$`
+)
+
+// Patterns are the patterns named by macros in pattern tags, e.g.
+// `pattern:"@uuid"`, which are expanded when generating schemas. Patterns
+// shared by a team may be added at init.
+var Patterns = map[string]string{
+	"uuid":    PatternUUID,
+	"rfc3339": PatternRFC3339,
+	"utc":     PatternRFC3339UTC,
+	"e164":    PatternE164,
+	"semver":  PatternSemver,
+	"slug":    PatternSlug,
+	"integer": PatternInteger,
+	"decimal": PatternDecimal,
+}
+
+var patternMacro = regexp.MustCompile(`^@([A-Za-z0-9_-]+)$`)
+
+// expandPattern returns the pattern named by the macro pattern, e.g. @uuid,
+// or pattern itself when it isn't a macro.
+func expandPattern(pattern string) (string, error) {
+	m := patternMacro.FindStringSubmatch(pattern)
+	if m == nil {
+		return pattern, nil
+	}
+	expanded, ok := Patterns[m[1]]
+	if !ok {
+		return "", fmt.Errorf(`unknown pattern %s in "pattern" tag`, pattern)
+	}
+	return expanded, nil
+}
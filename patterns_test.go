@@ -0,0 +1,96 @@
+package jsonschema
+
+import (
+	"regexp"
+
+	. "gopkg.in/check.v1"
+)
+
+type patternsSuite struct{}
+
+var _ = Suite(&patternsSuite{})
+
+type ExampleJSONRelease struct {
+	ID      string  `json:"id" pattern:"@uuid"`
+	Version string  `json:"version" pattern:"@semver"`
+	Slug    *string `json:"slug" pattern:"@slug"`
+	Handle  string  `json:"handle" pattern:"^@[a-z]+$"`
+}
+
+type ExampleJSONUnknownPattern struct {
+	Phone string `json:"phone" pattern:"@phone"`
+}
+
+func (self *patternsSuite) TestPatternMacros(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONRelease{}).MustGenerate()
+
+	c.Assert(j.Properties["id"].Pattern, Equals, PatternUUID)
+	c.Assert(j.Properties["version"].Pattern, Equals, PatternSemver)
+	c.Assert(j.Properties["handle"].Pattern, Equals, "^@[a-z]+$")
+
+	_, err := NewGenerator().WithRoot(&ExampleJSONUnknownPattern{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Phone:unknown pattern @phone in "pattern" tag`)
+
+	Patterns["phone"] = PatternE164
+	defer delete(Patterns, "phone")
+	j = NewGenerator().WithRoot(&ExampleJSONUnknownPattern{}).MustGenerate()
+	c.Assert(j.Properties["phone"].Pattern, Equals, PatternE164)
+}
+
+func (self *patternsSuite) TestPatterns(c *C) {
+	matches := map[string][]string{
+		"uuid":    {"123e4567-e89b-12d3-a456-426614174000"},
+		"rfc3339": {"2024-02-29T10:00:00Z", "2024-02-29T10:00:00.123+02:00"},
+		"e164":    {"+33123456789"},
+		"semver":  {"1.0.0", "2.1.3-rc.1+build.5"},
+		"slug":    {"blue-shirt", "a1"},
+		"integer": {"0", "-42"},
+		"decimal": {"12.50", "-3"},
+	}
+	mismatches := map[string][]string{
+		"uuid":    {"123e4567e89b12d3a456426614174000"},
+		"rfc3339": {"2024-02-29 10:00:00", "2024-02-29T10:00:00"},
+		"e164":    {"0123456789", "+0123"},
+		"semver":  {"1.0", "01.0.0"},
+		"slug":    {"Blue-shirt", "blue--shirt", "-blue"},
+		"integer": {"01", "1.5"},
+		"decimal": {"1.", ".5"},
+	}
+	for name, pattern := range Patterns {
+		re := regexp.MustCompile(pattern)
+		for _, v := range matches[name] {
+			c.Check(re.MatchString(v), Equals, true, Commentf("%s %s", name, v))
+		}
+		for _, v := range mismatches[name] {
+			c.Check(re.MatchString(v), Equals, false, Commentf("%s %s", name, v))
+		}
+	}
+}
+
+type ExampleJSONBrokenPattern struct {
+	Lines []struct {
+		SKU string `json:"sku" pattern:"^[A-Z+$"`
+	} `json:"lines"`
+}
+
+type ExampleJSONBrokenModifier string
+
+func (ExampleJSONBrokenModifier) ModifySchema(p *Property) {
+	p.Pattern = "(a"
+}
+
+func (self *patternsSuite) TestCompilePatterns(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONBrokenPattern{}).Generate()
+	c.Assert(err, ErrorMatches, `invalid pattern "\^\[A-Z\+\$" at /properties/lines/items/properties/sku: .*`)
+
+	_, err = NewGenerator().WithDefinition("code", ExampleJSONBrokenModifier("")).Generate()
+	c.Assert(err, ErrorMatches, `invalid pattern "\(a" at /definitions/code: .*`)
+
+	// the validators of generated schemas reuse the patterns compiled
+	j := NewGenerator().WithRoot(&ExampleJSONRelease{}).MustGenerate()
+	v, err := NewValidator(j)
+	c.Assert(err, IsNil)
+	compiled, ok := compiledPatterns.Load(PatternSemver)
+	c.Assert(ok, Equals, true)
+	c.Assert(v.patterns[PatternSemver], Equals, compiled)
+}
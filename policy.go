@@ -0,0 +1,113 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy enforces the rules of an organization on the schemas generated, e.g.
+// that every string has a maxLength. Apply is called on every property of a
+// schema, with its JSON pointer in the schema, once the schema is generated.
+// It may adjust the property, e.g. to set a default limit, or return an
+// error describing the violation, which fails the generation.
+type Policy interface {
+	Apply(path string, p *Property) error
+}
+
+// PolicyFunc is a function applied as a Policy.
+type PolicyFunc func(path string, p *Property) error
+
+func (f PolicyFunc) Apply(path string, p *Property) error {
+	return f(path, p)
+}
+
+// WithPolicy applies the policies to the schemas generated, in order.
+func (g *Generator) WithPolicy(policies ...Policy) *Generator {
+	g.policies = append(g.policies, policies...)
+	return g
+}
+
+// RequireMaxLength is a policy requiring strings to have a maxLength, other
+// than those with an enum or const. Strings without one get limit as
+// maxLength, or fail the generation when limit is zero.
+func RequireMaxLength(limit int64) Policy {
+	return PolicyFunc(func(path string, p *Property) error {
+		if p.Type != "string" || p.MaxLength != nil || len(p.Enum) > 0 || p.Const != nil {
+			return nil
+		}
+		if limit <= 0 {
+			return fmt.Errorf("strings must have a maxLength")
+		}
+		p.MaxLength = &limit
+		return nil
+	})
+}
+
+// RequireMaxItems is a policy requiring arrays to have a maxItems. Arrays
+// without one get limit as maxItems, or fail the generation when limit is
+// zero.
+func RequireMaxItems(limit int64) Policy {
+	return PolicyFunc(func(path string, p *Property) error {
+		if p.Type != "array" || p.MaxItems != nil {
+			return nil
+		}
+		if limit <= 0 {
+			return fmt.Errorf("arrays must have a maxItems")
+		}
+		p.MaxItems = &limit
+		return nil
+	})
+}
+
+// applyPolicies applies the policies to the root and the definitions of d,
+// and returns an error listing the violations.
+func applyPolicies(policies []Policy, d *JSONSchema) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	var violations []string
+	apply := func(path string, p *Property) {
+		for _, policy := range policies {
+			if err := policy.Apply(path, p); err != nil {
+				violations = append(violations, fmt.Sprintf("%s: %s", path, err))
+			}
+		}
+	}
+	walkPropertyPaths("", &d.Property, apply)
+	for _, name := range sortedDefinitionNames(d.Definitions) {
+		def := d.Definitions[name]
+		walkPropertyPaths("/definitions/"+escapePointer(name), &def, apply)
+		d.Definitions[name] = def
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("policy violations: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// walkPropertyPaths calls fn on p and its subschemas, with their JSON
+// pointers, in a stable order.
+func walkPropertyPaths(path string, p *Property, fn func(path string, p *Property)) {
+	if p == nil {
+		return
+	}
+	fn(path, p)
+	walkPropertyPaths(path+"/items", p.Items, fn)
+	walkPropertyPaths(path+"/additionalProperties", p.AdditionalPropertiesSchema, fn)
+	walkPropertyPaths(path+"/not", p.Not, fn)
+	for _, name := range sortedPropertyNames(p.Properties) {
+		walkPropertyPaths(path+"/properties/"+escapePointer(name), p.Properties[name], fn)
+	}
+	for _, name := range sortedPropertyNames(p.Dependencies) {
+		walkPropertyPaths(path+"/dependencies/"+escapePointer(name), p.Dependencies[name], fn)
+	}
+	for _, name := range sortedPropertyNames(p.DependentSchemas) {
+		walkPropertyPaths(path+"/dependentSchemas/"+escapePointer(name), p.DependentSchemas[name], fn)
+	}
+	for i, branch := range p.AnyOf {
+		walkPropertyPaths(fmt.Sprintf("%s/anyOf/%d", path, i), branch, fn)
+	}
+	for i, branch := range p.OneOf {
+		walkPropertyPaths(fmt.Sprintf("%s/oneOf/%d", path, i), branch, fn)
+	}
+}
@@ -0,0 +1,67 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+type policySuite struct{}
+
+var _ = Suite(&policySuite{})
+
+type ExampleJSONComment struct {
+	Author  string   `json:"author" maxLength:"50"`
+	Body    string   `json:"body"`
+	Kind    string   `json:"kind" enum:"note|reply"`
+	Tags    []string `json:"tags" minItems:"1" maxItems:"5"`
+	Replies []string `json:"replies"`
+}
+
+func (self *policySuite) TestPolicies(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONComment{}).
+		WithPolicy(RequireMaxLength(1000), RequireMaxItems(100)).
+		MustGenerate()
+
+	c.Assert(*j.Properties["author"].MaxLength, Equals, int64(50))
+	c.Assert(*j.Properties["body"].MaxLength, Equals, int64(1000))
+	c.Assert(j.Properties["kind"].MaxLength, IsNil)
+	c.Assert(*j.Properties["tags"].MinItems, Equals, int64(1))
+	c.Assert(*j.Properties["tags"].MaxItems, Equals, int64(5))
+	c.Assert(*j.Properties["tags"].Items.MaxLength, Equals, int64(1000))
+	c.Assert(*j.Properties["replies"].MaxItems, Equals, int64(100))
+
+	_, err := NewGenerator().
+		WithRoot(&ExampleJSONComment{}).
+		WithPolicy(RequireMaxLength(0), RequireMaxItems(0)).
+		Generate()
+	c.Assert(err, ErrorMatches, "policy violations: "+
+		"/properties/body: strings must have a maxLength; "+
+		"/properties/replies: arrays must have a maxItems; "+
+		"/properties/replies/items: strings must have a maxLength; "+
+		"/properties/tags/items: strings must have a maxLength")
+}
+
+func (self *policySuite) TestPolicyOnDefinitions(c *C) {
+	noTitles := PolicyFunc(func(path string, p *Property) error {
+		if p.Type == "object" && p.Title == "" {
+			return fmt.Errorf("objects must have a title")
+		}
+		return nil
+	})
+	_, err := NewGenerator().
+		WithDefinition("comment", ExampleJSONComment{}).
+		WithPolicy(noTitles).
+		Generate()
+	c.Assert(err, ErrorMatches, "policy violations: /definitions/comment: objects must have a title")
+}
+
+func (self *policySuite) TestItemsValidation(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONComment{}).MustGenerate()
+
+	errs, err := j.Validate([]byte(`{"tags": []}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Keyword, Equals, "minItems")
+}
@@ -0,0 +1,61 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithTagPreset registers tags which the preset tag expands to, e.g.
+//
+//	g.WithTagPreset("email", map[string]string{"format": "email", "maxLength": "254"})
+//
+// for fields tagged `preset:"email"`, so that the policies of a team are
+// written once. Several presets may be applied, separated by commas, and
+// the tags set on the field take precedence over those of its presets.
+func (g *Generator) WithTagPreset(name string, tags map[string]string) *Generator {
+	if g.presets == nil {
+		g.presets = map[string]map[string]string{}
+	}
+	g.presets[name] = tags
+	return g
+}
+
+// expandPresets returns tag with the tags of the presets named by its preset
+// tag added, unless they are already set. It is an error to name a preset
+// which wasn't registered.
+func (r *reader) expandPresets(tag reflect.StructTag) (reflect.StructTag, error) {
+	names, ok := tag.Lookup("preset")
+	if !ok {
+		return tag, nil
+	}
+	expanded := tag
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		preset, ok := r.presets[name]
+		if !ok {
+			return tag, fmt.Errorf("unknown preset %s", name)
+		}
+		expanded = addTags(expanded, preset)
+	}
+	return expanded, nil
+}
+
+// addTags returns tag with tags added, in sorted order, unless they are
+// already set.
+func addTags(tag reflect.StructTag, tags map[string]string) reflect.StructTag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, set := tag.Lookup(key); set {
+			continue
+		}
+		tag = reflect.StructTag(strings.TrimSpace(string(tag) + " " + key + ":" + strconv.Quote(tags[key])))
+	}
+	return tag
+}
@@ -0,0 +1,42 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type presetsSuite struct{}
+
+var _ = Suite(&presetsSuite{})
+
+type ExampleJSONSignup struct {
+	Email    string `json:"email" preset:"email"`
+	Backup   string `json:"backup" preset:"email" maxLength:"100"`
+	Nickname string `json:"nickname" preset:"short, described"`
+}
+
+type ExampleJSONUnknownPreset struct {
+	Phone string `json:"phone" preset:"phone"`
+}
+
+func presetsGenerator() *Generator {
+	return NewGenerator().
+		WithTagPreset("email", map[string]string{"format": "email", "maxLength": "254"}).
+		WithTagPreset("short", map[string]string{"maxLength": "20", "pattern": "@slug"}).
+		WithTagPreset("described", map[string]string{"description": "A name shown to others.", "maxLength": "30"})
+}
+
+func (self *presetsSuite) TestTagPresets(c *C) {
+	j := presetsGenerator().WithRoot(&ExampleJSONSignup{}).MustGenerate()
+
+	c.Assert(j.Properties["email"], DeepEquals, &Property{Type: "string", Format: "email", MaxLength: int64ptr(254)})
+	// the tags of the field take precedence
+	c.Assert(*j.Properties["backup"].MaxLength, Equals, int64(100))
+	// so do the presets named first
+	nickname := j.Properties["nickname"]
+	c.Assert(*nickname.MaxLength, Equals, int64(20))
+	c.Assert(nickname.Pattern, Equals, PatternSlug)
+	c.Assert(nickname.Description, Equals, "A name shown to others.")
+
+	_, err := presetsGenerator().WithRoot(&ExampleJSONUnknownPreset{}).Generate()
+	c.Assert(err, ErrorMatches, ".*property:Phone:unknown preset phone")
+}
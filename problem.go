@@ -0,0 +1,74 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ProblemMediaType is the media type of RFC 7807 problem details.
+const ProblemMediaType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details object, describing a request
+// whose body isn't valid under its schema.
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Violations is an extension member holding the violations of the schema.
+	Violations []ProblemViolation `json:"violations,omitempty"`
+}
+
+// ProblemViolation is a violation of the schema by a value of the request body.
+type ProblemViolation struct {
+	// Pointer is the JSON pointer of the value in the request body, empty for the body itself.
+	Pointer string `json:"pointer"`
+	Keyword string `json:"keyword"`
+	Message string `json:"message"`
+}
+
+// ToProblemDetails returns the problem details of a request body with these
+// violations, with the 400 Bad Request status. Type and Instance may be set
+// before returning it from an HTTP handler.
+func (e ValidationErrors) ToProblemDetails() *ProblemDetails {
+	p := &ProblemDetails{
+		Title:      http.StatusText(http.StatusBadRequest),
+		Status:     http.StatusBadRequest,
+		Violations: make([]ProblemViolation, len(e)),
+	}
+	switch len(e) {
+	case 1:
+		p.Detail = "The request body has 1 violation of its schema."
+	default:
+		p.Detail = fmt.Sprintf("The request body has %d violations of its schema.", len(e))
+	}
+	for i, err := range e {
+		p.Violations[i] = ProblemViolation{
+			Pointer: err.InstancePath,
+			Keyword: err.Keyword,
+			Message: err.Message,
+		}
+	}
+	return p
+}
+
+// ServeHTTP writes the problem details as an application/problem+json
+// response, with their status or 400 Bad Request if it isn't set.
+func (p *ProblemDetails) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	status := p.Status
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", ProblemMediaType)
+	w.WriteHeader(status)
+	w.Write(b)
+}
@@ -0,0 +1,56 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "gopkg.in/check.v1"
+)
+
+type problemSuite struct{}
+
+var _ = Suite(&problemSuite{})
+
+func (self *problemSuite) TestToProblemDetails(c *C) {
+	errs, err := validatedOrderSchema().Validate([]byte(`{"id": "o", "status": "pending"}`))
+	c.Assert(err, IsNil)
+
+	p := errs.ToProblemDetails()
+	c.Assert(p, DeepEquals, &ProblemDetails{
+		Title:  "Bad Request",
+		Status: 400,
+		Detail: "The request body has 2 violations of its schema.",
+		Violations: []ProblemViolation{
+			{Pointer: "/id", Keyword: "minLength", Message: "must be at least 3 characters long"},
+			{Pointer: "/status", Keyword: "enum", Message: `must be one of "open", "closed"`},
+		},
+	})
+
+	p.Instance = "/orders"
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("POST", "/orders", nil))
+	c.Assert(w.Code, Equals, http.StatusBadRequest)
+	c.Assert(w.Header().Get("Content-Type"), Equals, ProblemMediaType)
+	c.Assert(w.Body.String(), Equals, `{"title":"Bad Request","status":400,`+
+		`"detail":"The request body has 2 violations of its schema.","instance":"/orders",`+
+		`"violations":[{"pointer":"/id","keyword":"minLength","message":"must be at least 3 characters long"},`+
+		`{"pointer":"/status","keyword":"enum","message":"must be one of \"open\", \"closed\""}]}`)
+}
+
+func (self *problemSuite) TestProblemDetailsStatus(c *C) {
+	errs := ValidationErrors{{Keyword: "type", SchemaPath: "/type", Message: "must be of type object, not array"}}
+	p := errs.ToProblemDetails()
+	c.Assert(p.Detail, Equals, "The request body has 1 violation of its schema.")
+	c.Assert(p.Violations, DeepEquals, []ProblemViolation{{Pointer: "", Keyword: "type", Message: "must be of type object, not array"}})
+
+	p = &ProblemDetails{Title: "Unprocessable Entity"}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, nil)
+	c.Assert(w.Code, Equals, http.StatusBadRequest)
+	p.Status = http.StatusUnprocessableEntity
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, nil)
+	c.Assert(w.Code, Equals, http.StatusUnprocessableEntity)
+}
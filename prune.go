@@ -0,0 +1,59 @@
+package jsonschema
+
+import (
+	"sort"
+	"strings"
+)
+
+// PruneUnusedDefinitions removes the definitions which are not transitively
+// referenced from the root of the schema or from the definitions named by
+// roots, e.g. when a whole package of types was registered but the schema
+// only uses a few of them. It returns the names of the removed definitions,
+// sorted. Names in roots which are not definitions are ignored.
+func (d *JSONSchema) PruneUnusedDefinitions(roots ...string) []string {
+	used := d.referencedDefinitions(roots...)
+	var removed []string
+	for name := range d.Definitions {
+		if !used[name] {
+			removed = append(removed, name)
+			delete(d.Definitions, name)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// referencedDefinitions returns the names of the definitions transitively
+// referenced from the root of the schema and from the named definitions,
+// which are included themselves.
+func (d *JSONSchema) referencedDefinitions(roots ...string) map[string]bool {
+	used := map[string]bool{}
+	var pending []string
+	visit := func(p *Property) {
+		walkProperties(p, func(p *Property) {
+			if !strings.HasPrefix(p.Ref, definitionsPrefix) {
+				return
+			}
+			name := unescapePointer(strings.TrimPrefix(p.Ref, definitionsPrefix))
+			if _, ok := d.Definitions[name]; ok && !used[name] {
+				used[name] = true
+				pending = append(pending, name)
+			}
+		})
+	}
+
+	visit(&d.Property)
+	for _, name := range roots {
+		if _, ok := d.Definitions[name]; ok && !used[name] {
+			used[name] = true
+			pending = append(pending, name)
+		}
+	}
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		def := d.Definitions[name]
+		visit(&def)
+	}
+	return used
+}
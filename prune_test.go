@@ -0,0 +1,95 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type pruneSuite struct{}
+
+var _ = Suite(&pruneSuite{})
+
+type ExampleJSONPruneLeaf struct {
+	Value string `json:"value"`
+}
+
+type ExampleJSONPruneBranch struct {
+	Leaves []ExampleJSONPruneLeaf `json:"leaves"`
+}
+
+type ExampleJSONPruneRoot struct {
+	Branch *ExampleJSONPruneBranch `json:"branch"`
+}
+
+type ExampleJSONPruneUnused struct {
+	Leaf ExampleJSONPruneLeaf `json:"leaf"`
+}
+
+type ExampleJSONPruneOther struct {
+	Name string `json:"name"`
+}
+
+func pruneGenerator() *Generator {
+	return NewGenerator().WithDefinitions(map[string]interface{}{
+		"branch": ExampleJSONPruneBranch{},
+		"leaf":   ExampleJSONPruneLeaf{},
+		"unused": ExampleJSONPruneUnused{},
+		"other":  ExampleJSONPruneOther{},
+	}).WithDefinitionAlias("twig", "branch")
+}
+
+func (self *pruneSuite) TestPruneUnusedDefinitions(c *C) {
+	j := pruneGenerator().WithRoot(&ExampleJSONPruneRoot{}).MustGenerate()
+
+	removed := j.PruneUnusedDefinitions()
+	c.Assert(removed, DeepEquals, []string{"other", "twig", "unused"})
+	c.Assert(sortedDefinitionNames(j.Definitions), DeepEquals, []string{"branch", "leaf"})
+}
+
+func (self *pruneSuite) TestPruneUnusedDefinitionsWithRoots(c *C) {
+	j := pruneGenerator().WithRoot(&ExampleJSONPruneRoot{}).MustGenerate()
+
+	removed := j.PruneUnusedDefinitions("unused", "twig", "missing")
+	c.Assert(removed, DeepEquals, []string{"other"})
+	c.Assert(sortedDefinitionNames(j.Definitions), DeepEquals, []string{"branch", "leaf", "twig", "unused"})
+}
+
+func (self *pruneSuite) TestPruneAllDefinitions(c *C) {
+	j := pruneGenerator().WithRoot(&ExampleJSONPruneOther{}).MustGenerate()
+
+	// the root is the registered type itself
+	c.Assert(j.Ref, Equals, "#/definitions/other")
+	j.PruneUnusedDefinitions()
+	c.Assert(sortedDefinitionNames(j.Definitions), DeepEquals, []string{"other"})
+
+	type unregistered struct {
+		Name string
+	}
+	j = pruneGenerator().WithRoot(&unregistered{}).MustGenerate()
+	c.Assert(j.PruneUnusedDefinitions(), HasLen, 5)
+	c.Assert(j.Definitions, HasLen, 0)
+}
+
+func (self *pruneSuite) TestOnlyReferencedDefinitions(c *C) {
+	g := func() *Generator {
+		return NewGenerator(Options{OnlyReferencedDefinitions: true}).WithDefinitions(map[string]interface{}{
+			"branch": ExampleJSONPruneBranch{},
+			"leaf":   ExampleJSONPruneLeaf{},
+			"unused": ExampleJSONPruneUnused{},
+			"other":  ExampleJSONPruneOther{},
+		})
+	}
+
+	j := g().WithRoot(&ExampleJSONPruneRoot{}).MustGenerate()
+	c.Assert(sortedDefinitionNames(j.Definitions), DeepEquals, []string{"branch", "leaf"})
+
+	// without a root, all the definitions are emitted
+	j = g().MustGenerate()
+	c.Assert(j.Definitions, HasLen, 4)
+
+	set, err := g().GenerateSet(map[string]interface{}{
+		"root":  ExampleJSONPruneRoot{},
+		"other": ExampleJSONPruneOther{},
+	})
+	c.Assert(err, IsNil)
+	c.Assert(sortedDefinitionNames(set.Definitions), DeepEquals, []string{"branch", "leaf", "other"})
+}
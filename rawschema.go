@@ -0,0 +1,52 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+)
+
+// rawSchemaFromTags returns the schema given by the schema tag, a JSON
+// literal, or the schemaRef tag, the file:// URL of a JSON file, if any.
+func rawSchemaFromTags(tag *reflect.StructTag) (*Property, error) {
+	literal, hasLiteral := tag.Lookup("schema")
+	ref, hasRef := tag.Lookup("schemaRef")
+	if !hasLiteral && !hasRef {
+		return nil, nil
+	}
+	if hasLiteral && hasRef {
+		return nil, fmt.Errorf(`"schema" and "schemaRef" tags are exclusive`)
+	}
+
+	raw := []byte(literal)
+	if hasRef {
+		u, err := url.Parse(ref)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid "schemaRef" tag value %q: %s`, ref, err)
+		}
+		if u.Scheme != "file" {
+			return nil, fmt.Errorf(`invalid "schemaRef" tag value %q: only file:// URLs are supported`, ref)
+		}
+		path := u.Path
+		if u.Host != "" {
+			// file://relative/path.json
+			path = u.Host + u.Path
+		}
+		raw, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid "schemaRef" tag value %q: %s`, ref, err)
+		}
+	}
+
+	p := &Property{}
+	err := json.Unmarshal(raw, p)
+	if err != nil {
+		if hasRef {
+			return nil, fmt.Errorf(`invalid schema in %s: %s`, ref, err)
+		}
+		return nil, fmt.Errorf(`invalid "schema" tag value %q: %s`, literal, err)
+	}
+	return p, nil
+}
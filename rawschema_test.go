@@ -0,0 +1,52 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type rawSchemaSuite struct{}
+
+var _ = Suite(&rawSchemaSuite{})
+
+type ExampleJSONRawSchemas struct {
+	Value    interface{} `json:"value" schema:"{\"type\": [\"string\", \"number\"]}" required:"true"`
+	Amount   interface{} `json:"amount" schemaRef:"file://testdata/money.json" description:"ignored"`
+	Ignored  interface{} `json:"-" schema:"not json"`
+	Standard string      `json:"standard"`
+}
+
+func (self *rawSchemaSuite) TestRawSchemas(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONRawSchemas{}).MustGenerate()
+
+	c.Assert(j.Properties["value"], DeepEquals, &Property{Type: "string", Types: []string{"string", "number"}})
+	c.Assert(j.Properties["amount"], DeepEquals, &Property{
+		Type:        "string",
+		Pattern:     `^-?[0-9]+\.[0-9]{2}$`,
+		Description: "An amount of money",
+	})
+	c.Assert(j.Properties["standard"], DeepEquals, &Property{Type: "string"})
+	c.Assert(j.Required, DeepEquals, []string{"value"})
+}
+
+type ExampleJSONInvalidRawSchema struct {
+	Value interface{} `json:"value" schema:"{"`
+}
+
+type ExampleJSONMissingSchemaRef struct {
+	Value interface{} `json:"value" schemaRef:"file://testdata/missing.json"`
+}
+
+type ExampleJSONHTTPSchemaRef struct {
+	Value interface{} `json:"value" schemaRef:"https://example.com/value.json"`
+}
+
+func (self *rawSchemaSuite) TestRawSchemaErrors(c *C) {
+	_, err := NewGenerator().WithRoot(&ExampleJSONInvalidRawSchema{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Value:invalid "schema" tag value "\{": .*`)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONMissingSchemaRef{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Value:invalid "schemaRef" tag value "file://testdata/missing.json": .*no such file or directory`)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONHTTPSchemaRef{}).Generate()
+	c.Assert(err, ErrorMatches, `.*only file:// URLs are supported`)
+}
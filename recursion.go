@@ -0,0 +1,69 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lowerCamel returns the name of a type in lower camel case, e.g. "node"
+// for Node, without the type arguments of generic types.
+func lowerCamel(typeName string) string {
+	if i := strings.Index(typeName, "["); i >= 0 {
+		typeName = typeName[:i]
+	}
+	first, size := utf8.DecodeRuneInString(typeName)
+	return string(unicode.ToLower(first)) + typeName[size:]
+}
+
+// uniqueName returns base, numbered if taken, and takes it.
+func uniqueName(base string, taken map[string]bool) string {
+	name := base
+	for i := 2; taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	taken[name] = true
+	return name
+}
+
+// promote registers the struct t as a definition, named after it in lower
+// camel case or by the naming of WithAutoDefinitions, and returns the
+// reference to it.
+func (r *reader) promote(t goType) string {
+	if ref, ok := r.reference(t); ok {
+		return ref
+	}
+	if r.knownTypes == nil {
+		r.knownTypes = map[interface{}]string{}
+	}
+	base := lowerCamel(shortName(t))
+	if r.naming != nil {
+		base = r.naming(t)
+	}
+	name := uniqueName(base, r.definitionNames)
+	r.knownTypes[t.Key()] = name
+	r.promoted = append(r.promoted, t)
+	return definitionReference(name)
+}
+
+// definePromoted reads the definitions of the types promoted into m, which
+// may promote others.
+func (r *reader) definePromoted(m *Model) error {
+	for len(r.promoted) > 0 {
+		t := r.promoted[0]
+		r.promoted = r.promoted[1:]
+		name := r.knownTypes[t.Key()]
+		r.tracef("definition %s: %s, promoted", name, t)
+		n := newNode()
+		if err := r.readDefinition(n, t); err != nil {
+			return fmt.Errorf("error on type %s (%s): %s", t, name, err)
+		}
+		if m.Definitions == nil {
+			m.Definitions = map[string]*Node{}
+		}
+		m.Definitions[name] = n
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// SensitiveExtension is the extension keyword marking properties holding
+// sensitive data, which Redact masks.
+const SensitiveExtension = "x-sensitive"
+
+// RedactedValue replaces the values of sensitive properties in redacted documents.
+const RedactedValue = "[REDACTED]"
+
+// IsSensitive reports whether the property is marked as sensitive.
+func (p *Property) IsSensitive() bool {
+	sensitive, _ := p.Extensions[SensitiveExtension].(bool)
+	return sensitive
+}
+
+func (p *Property) addSensitiveFromTags(tag *reflect.StructTag) error {
+	raw, ok := tag.Lookup("sensitive")
+	if !ok {
+		return nil
+	}
+	sensitive, err := strconv.ParseBool(raw)
+	if err != nil {
+		return fmt.Errorf(`invalid "sensitive" tag value %q: %s`, raw, err)
+	}
+	if !sensitive {
+		return nil
+	}
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[SensitiveExtension] = true
+	return nil
+}
+
+// Redact returns a copy of the JSON document with the values of all the
+// properties marked as sensitive by the schema replaced by RedactedValue,
+// so that documents can be logged without leaking the data they describe.
+func Redact(schema *JSONSchema, document []byte) ([]byte, error) {
+	doc, err := decodeInstance(document)
+	if err != nil {
+		return nil, err
+	}
+	return encodeInstance(schema.redact(&schema.Property, doc))
+}
+
+func (d *JSONSchema) redact(p *Property, v interface{}) interface{} {
+	p = d.resolve(p)
+	if p == nil {
+		return v
+	}
+	if p.IsSensitive() {
+		return RedactedValue
+	}
+
+	for _, s := range p.AnyOf {
+		v = d.redact(s, v)
+	}
+	for _, s := range p.OneOf {
+		v = d.redact(s, v)
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			if s, ok := propertySchema(p, k); ok {
+				t[k] = d.redact(s, value)
+			}
+		}
+	case []interface{}:
+		if p.Items != nil {
+			for i, value := range t {
+				t[i] = d.redact(p.Items, value)
+			}
+		}
+	}
+	return v
+}
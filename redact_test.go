@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type redactSuite struct{}
+
+var _ = Suite(&redactSuite{})
+
+type ExampleJSONAccount struct {
+	Email    string            `json:"email" sensitive:"true"`
+	Name     string            `json:"name"`
+	Password *string           `json:"password" sensitive:"true"`
+	Cards    []ExampleJSONCard `json:"cards"`
+	Tokens   map[string]string `json:"tokens" sensitive:"true"`
+	Primary  *ExampleJSONCard  `json:"primary"`
+	Labels   map[string]string `json:"labels"`
+}
+
+type ExampleJSONCard struct {
+	Number string `json:"number" sensitive:"true"`
+	Brand  string `json:"brand"`
+}
+
+func (self *redactSuite) TestSensitiveTag(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONAccount{}).MustGenerate()
+
+	c.Assert(j.Properties["email"].IsSensitive(), Equals, true)
+	c.Assert(j.Properties["email"].Extensions, DeepEquals, map[string]interface{}{"x-sensitive": true})
+	c.Assert(j.Properties["name"].IsSensitive(), Equals, false)
+}
+
+func (self *redactSuite) TestRedact(c *C) {
+	document := []byte(`{
+		"email": "jane@example.com",
+		"name": "Jane",
+		"password": null,
+		"cards": [{"number": "4111111111111111", "brand": "visa"}],
+		"tokens": {"github": "abc"},
+		"primary": {"number": "4111111111111111", "brand": "visa", "extra": 1.50},
+		"labels": {"team": "core"}
+	}`)
+
+	for _, g := range []*Generator{
+		NewGenerator().WithRoot(&ExampleJSONAccount{}),
+		NewGenerator().WithRoot(&ExampleJSONAccount{}).WithDefinition("card", ExampleJSONCard{}),
+	} {
+		redacted, err := Redact(g.MustGenerate(), document)
+		c.Assert(err, IsNil)
+		c.Assert(string(redacted), Equals, `{"cards":[{"brand":"visa","number":"[REDACTED]"}],`+
+			`"email":"[REDACTED]","labels":{"team":"core"},"name":"Jane","password":"[REDACTED]",`+
+			`"primary":{"brand":"visa","extra":1.50,"number":"[REDACTED]"},"tokens":"[REDACTED]"}`)
+	}
+
+	_, err := Redact(NewGenerator().WithRoot(&ExampleJSONAccount{}).MustGenerate(), []byte("{"))
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,79 @@
+package jsonschema
+
+import (
+	"strings"
+)
+
+// walkProperties calls fn for p and every subschema nested in it.
+func walkProperties(p *Property, fn func(p *Property)) {
+	if p == nil {
+		return
+	}
+	fn(p)
+	walkProperties(p.Items, fn)
+	walkProperties(p.AdditionalPropertiesSchema, fn)
+	walkProperties(p.Not, fn)
+	for _, s := range p.DependentSchemas {
+		walkProperties(s, fn)
+	}
+	for _, s := range p.Properties {
+		walkProperties(s, fn)
+	}
+	for _, s := range p.Dependencies {
+		walkProperties(s, fn)
+	}
+	for _, s := range p.AnyOf {
+		walkProperties(s, fn)
+	}
+	for _, s := range p.OneOf {
+		walkProperties(s, fn)
+	}
+}
+
+// rewriteRefs replaces every $ref in p and its subschemas by the result of fn.
+func rewriteRefs(p *Property, fn func(ref string) string) {
+	walkProperties(p, func(p *Property) {
+		if p.Ref != "" {
+			p.Ref = fn(p.Ref)
+		}
+	})
+}
+
+// RewriteRefs replaces every $ref of the schema, in its root and its
+// definitions, by the result of fn, e.g. to point them to the components of
+// an OpenAPI document or to absolute URIs. The schema is modified in place.
+func RewriteRefs(schema *JSONSchema, fn func(old string) string) {
+	rewriteRefs(&schema.Property, fn)
+	for name, def := range schema.Definitions {
+		rewriteRefs(&def, fn)
+		schema.Definitions[name] = def
+	}
+}
+
+// resolve follows the $ref of p to the definition it points to, if any.
+func (d *JSONSchema) resolve(p *Property) *Property {
+	for i := 0; p != nil && p.Ref != "" && i <= len(d.Definitions); i++ {
+		if !strings.HasPrefix(p.Ref, "#/definitions/") {
+			return p
+		}
+		def, ok := d.Definitions[unescapePointer(strings.TrimPrefix(p.Ref, "#/definitions/"))]
+		if !ok {
+			return p
+		}
+		p = &def
+	}
+	return p
+}
+
+// propertySchema returns the schema of the named property of the object
+// described by p, including properties matched by the ".*" wildcard used for
+// maps and those described by additionalProperties.
+func propertySchema(p *Property, name string) (*Property, bool) {
+	if s, ok := p.Properties[name]; ok {
+		return s, true
+	}
+	if s, ok := p.Properties[".*"]; ok {
+		return s, true
+	}
+	return p.AdditionalPropertiesSchema, p.AdditionalPropertiesSchema != nil
+}
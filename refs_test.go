@@ -0,0 +1,30 @@
+package jsonschema
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type refsSuite struct{}
+
+var _ = Suite(&refsSuite{})
+
+func (self *refsSuite) TestRewriteRefs(c *C) {
+	j := NewGenerator().
+		WithRoot(&ExampleJSONDeeplyNested{}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		WithDefinitionAlias("legacyItem", "item").
+		MustGenerate()
+
+	RewriteRefs(j, func(old string) string {
+		return "https://example.com/schemas/" + strings.TrimPrefix(old, "#/definitions/") + ".json"
+	})
+
+	ref := "https://example.com/schemas/item.json"
+	c.Assert(j.Properties["matrix"].Items.Items.Ref, Equals, ref)
+	c.Assert(j.Properties["groups"].AdditionalPropertiesSchema.Items.Ref, Equals, ref)
+	c.Assert(j.Properties["pair"].Items.Ref, Equals, ref)
+	c.Assert(j.Definitions["legacyItem"].Ref, Equals, ref)
+	c.Assert(strings.Contains(j.String(), "#/definitions/"), Equals, false)
+}
@@ -0,0 +1,180 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// RegexDialect is the dialect of regular expressions of the pattern keywords
+// of the schemas generated.
+type RegexDialect int
+
+const (
+	// RE2 keeps patterns in the syntax of Go's regexp package.
+	RE2 RegexDialect = iota
+	// ECMA262 translates patterns to the syntax of JavaScript regular
+	// expressions with the u flag, used by validators such as AJV.
+	ECMA262
+)
+
+// posixClasses are the ECMA-262 equivalents of the ASCII classes of RE2.
+var posixClasses = map[string]string{
+	"alnum":  "0-9A-Za-z",
+	"alpha":  "A-Za-z",
+	"ascii":  `\x00-\x7F`,
+	"blank":  `\t `,
+	"cntrl":  `\x00-\x1F\x7F`,
+	"digit":  "0-9",
+	"lower":  "a-z",
+	"space":  `\t\n\v\f\r `,
+	"upper":  "A-Z",
+	"word":   "0-9A-Za-z_",
+	"xdigit": "0-9A-Fa-f",
+}
+
+// ecmaSyntaxCharacters may be escaped outside of classes in ECMA-262
+// patterns with the u flag.
+const ecmaSyntaxCharacters = `^$\.*+?()[]{}|/`
+
+// translatePatterns translates the patterns of the root and definitions of d
+// to the dialect, and returns the patterns which can't be translated.
+func translatePatterns(dialect RegexDialect, d *JSONSchema) []Inconsistency {
+	if dialect != ECMA262 {
+		return nil
+	}
+	var untranslatable []Inconsistency
+	translate := func(path string, p *Property) {
+		if p.Pattern == "" {
+			return
+		}
+		translated, err := toECMAPattern(p.Pattern)
+		if err != nil {
+			untranslatable = append(untranslatable, Inconsistency{
+				Path:    path,
+				Message: fmt.Sprintf("pattern %q can't be translated to ECMA-262: %s", p.Pattern, err),
+			})
+			return
+		}
+		p.Pattern = translated
+	}
+	walkPropertyPaths("", &d.Property, translate)
+	for _, name := range sortedDefinitionNames(d.Definitions) {
+		def := d.Definitions[name]
+		walkPropertyPaths("/definitions/"+escapePointer(name), &def, translate)
+		d.Definitions[name] = def
+	}
+	return untranslatable
+}
+
+// toECMAPattern translates a RE2 pattern to ECMA-262: \A and \z become
+// anchors, \Q...\E literals are escaped, named groups lose their P, one
+// letter Unicode classes get braces, ASCII classes are spelled out and
+// escaped punctuation which doesn't need escaping is unescaped. Flags and
+// \C have no equivalent.
+func toECMAPattern(pattern string) (string, error) {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); {
+		rest := pattern[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1:
+			next := rest[1]
+			switch {
+			case next == 'A' || next == 'z':
+				if inClass {
+					return "", fmt.Errorf(`\%c in a class`, next)
+				}
+				if next == 'A' {
+					b.WriteString("^")
+				} else {
+					b.WriteString("$")
+				}
+				i += 2
+			case next == 'Q':
+				literal, consumed := rest[2:], len(rest)
+				if end := strings.Index(literal, `\E`); end >= 0 {
+					literal, consumed = literal[:end], 2+end+2
+				}
+				i += consumed
+				for _, r := range literal {
+					if strings.ContainsRune(ecmaSyntaxCharacters, r) || inClass && r == '-' {
+						b.WriteByte('\\')
+					}
+					b.WriteRune(r)
+				}
+			case (next == 'p' || next == 'P') && len(rest) > 2 && rest[2] != '{':
+				fmt.Fprintf(&b, `\%c{%c}`, next, rest[2])
+				i += 3
+			case next == 'x' && len(rest) > 2 && rest[2] == '{':
+				end := strings.IndexByte(rest, '}')
+				if end < 0 {
+					return "", fmt.Errorf(`unterminated \x{`)
+				}
+				b.WriteString(`\u` + rest[2:end+1])
+				i += end + 1
+			case next == 'C':
+				return "", fmt.Errorf(`\C has no equivalent`)
+			case next < utf8.RuneSelf && isPunctuation(next) && !strings.ContainsRune(ecmaSyntaxCharacters, rune(next)) && !(inClass && next == '-'):
+				// RE2 allows escaping any punctuation, ECMA-262 doesn't
+				b.WriteByte(next)
+				i += 2
+			default:
+				_, size := utf8.DecodeRuneInString(rest[1:])
+				b.WriteString(rest[:1+size])
+				i += 1 + size
+			}
+		case inClass && strings.HasPrefix(rest, "[:"):
+			end := strings.Index(rest, ":]")
+			if end < 0 {
+				b.WriteString(`\[`)
+				i++
+				continue
+			}
+			name := rest[2:end]
+			class, ok := posixClasses[name]
+			if !ok {
+				return "", fmt.Errorf("the class [:%s:] has no equivalent", name)
+			}
+			b.WriteString(class)
+			i += end + 2
+		case rest[0] == '[' && !inClass:
+			inClass = true
+			b.WriteByte('[')
+			i++
+			if strings.HasPrefix(pattern[i:], "^") {
+				b.WriteByte('^')
+				i++
+			}
+			if strings.HasPrefix(pattern[i:], "]") {
+				// a leading ] is literal in RE2
+				b.WriteString(`\]`)
+				i++
+			}
+		case rest[0] == ']' && inClass:
+			inClass = false
+			b.WriteByte(']')
+			i++
+		case rest[0] == '(' && !inClass && strings.HasPrefix(rest, "(?"):
+			switch {
+			case strings.HasPrefix(rest, "(?P<"):
+				b.WriteString("(?<")
+				i += 4
+			case strings.HasPrefix(rest, "(?:"):
+				b.WriteString("(?:")
+				i += 3
+			default:
+				return "", fmt.Errorf("flags have no equivalent")
+			}
+		default:
+			_, size := utf8.DecodeRuneInString(rest)
+			b.WriteString(rest[:size])
+			i += size
+		}
+	}
+	return b.String(), nil
+}
+
+func isPunctuation(c byte) bool {
+	return c > ' ' && c < 0x7f && !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z')
+}
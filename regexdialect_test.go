@@ -0,0 +1,71 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type regexDialectSuite struct{}
+
+var _ = Suite(&regexDialectSuite{})
+
+type ExampleJSONProfile struct {
+	Handle string `json:"handle" pattern:"\\A\\@[[:alnum:]_]+\\z"`
+	Code   string `json:"code" pattern:"^(?P<prefix>[A-Z]{2})-\\d+$"`
+	Name   string `json:"name" pattern:"(?i)^[a-z]+$"`
+	ID     string `json:"id" pattern:"@uuid"`
+}
+
+func (self *regexDialectSuite) TestToECMAPattern(c *C) {
+	translations := map[string]string{
+		`^[a-z]+$`:        `^[a-z]+$`,
+		`\Aabc\z`:         `^abc$`,
+		`\Qa.b*c\E+`:      `a\.b\*c+`,
+		`\Qa.b`:           `a\.b`,
+		`(?P<year>\d{4})`: `(?<year>\d{4})`,
+		`(?:ab)+`:         `(?:ab)+`,
+		`\pL+\PN`:         `\p{L}+\P{N}`,
+		`\p{Greek}`:       `\p{Greek}`,
+		`\x{1F600}`:       `\u{1F600}`,
+		`[[:digit:]-]`:    `[0-9-]`,
+		`[^[:space:]]`:    `[^\t\n\v\f\r ]`,
+		`[]a]`:            `[\]a]`,
+		`\@\#\.\-`:        `@#\.-`,
+		`[\-\.]`:          `[\-\.]`,
+	}
+	for re2, ecma := range translations {
+		translated, err := toECMAPattern(re2)
+		c.Check(err, IsNil, Commentf("%s", re2))
+		c.Check(translated, Equals, ecma, Commentf("%s", re2))
+	}
+
+	for re2, message := range map[string]string{
+		`(?i)abc`:     "flags have no equivalent",
+		`(?s:a.b)`:    "flags have no equivalent",
+		`[[:punct:]]`: `the class \[:punct:\] has no equivalent`,
+		`a\Cb`:        `\\C has no equivalent`,
+	} {
+		_, err := toECMAPattern(re2)
+		c.Check(err, ErrorMatches, message, Commentf("%s", re2))
+	}
+}
+
+func (self *regexDialectSuite) TestPatternDialect(c *C) {
+	g := NewGenerator(Options{PatternDialect: ECMA262}).WithRoot(&ExampleJSONProfile{})
+	j := g.MustGenerate()
+
+	c.Assert(j.Properties["handle"].Pattern, Equals, `^@[0-9A-Za-z_]+$`)
+	c.Assert(j.Properties["code"].Pattern, Equals, `^(?<prefix>[A-Z]{2})-\d+$`)
+	c.Assert(j.Properties["id"].Pattern, Equals, PatternUUID)
+	// untranslatable patterns are kept and reported
+	c.Assert(j.Properties["name"].Pattern, Equals, `(?i)^[a-z]+$`)
+	c.Assert(g.Warnings(), DeepEquals, []Inconsistency{{
+		Path:    "/properties/name",
+		Message: `pattern "(?i)^[a-z]+$" can't be translated to ECMA-262: flags have no equivalent`,
+	}})
+
+	_, err := NewGenerator(Options{PatternDialect: ECMA262, Strict: true}).WithRoot(&ExampleJSONProfile{}).Generate()
+	c.Assert(err, ErrorMatches, `inconsistent schema: /properties/name: pattern .* can't be translated to ECMA-262: flags have no equivalent`)
+
+	j = NewGenerator().WithRoot(&ExampleJSONProfile{}).MustGenerate()
+	c.Assert(j.Properties["handle"].Pattern, Equals, `\A\@[[:alnum:]_]+\z`)
+}
@@ -0,0 +1,125 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RefLoader loads the documents of remote $refs, e.g. over HTTP with
+// HTTPRefLoader, by their absolute URI without fragment.
+type RefLoader interface {
+	Load(ctx context.Context, uri string) ([]byte, error)
+}
+
+// refDocumentSizeKey is the key of the context of the loads holding the
+// maximum size of the documents, so that loaders stop reading beyond it.
+type refDocumentSizeKey struct{}
+
+// maxRefDocumentSize returns the maximum size of the documents loaded with
+// ctx, or def if the validator loading them doesn't limit it.
+func maxRefDocumentSize(ctx context.Context, def int) int {
+	if limit, ok := ctx.Value(refDocumentSizeKey{}).(int); ok && limit > 0 {
+		return limit
+	}
+	return def
+}
+
+// NewValidatorWithLoader returns a validator for the schema as NewValidator
+// does, resolving the $refs to other documents, e.g.
+// "https://example.com/address.json#/definitions/street", with the documents
+// loaded by loader. Relative $refs are resolved against the $id of the
+// schema, and those of the documents loaded against their URI. The documents
+// are loaded once, here, and it stops with the error of ctx once it is done.
+func NewValidatorWithLoader(ctx context.Context, schema *JSONSchema, loader RefLoader, limits ...ValidatorLimits) (*Validator, error) {
+	return newValidator(ctx, schema, loader, limits)
+}
+
+// loadRemote loads the documents of the remote $refs of the schema of $id
+// base, and of the documents loaded, rewriting the $refs to absolute ones.
+func (v *Validator) loadRemote(ctx context.Context, base string, loader RefLoader) error {
+	v.remote = map[string]*Property{}
+	loaded := map[string]bool{}
+	var queue []string
+	rewrite := func(p *Property, base string, local bool) {
+		rewriteRefs(p, func(ref string) string {
+			if local && strings.HasPrefix(ref, "#") {
+				return ref
+			}
+			abs, doc, ok := absoluteRef(base, ref)
+			if !ok {
+				// reported as unresolvable
+				return ref
+			}
+			if !loaded[doc] {
+				loaded[doc] = true
+				queue = append(queue, doc)
+			}
+			return abs
+		})
+	}
+
+	limit := v.limits.MaxRefDocumentSize
+	if limit > 0 {
+		ctx = context.WithValue(ctx, refDocumentSizeKey{}, limit)
+	}
+
+	rewrite(v.root, base, true)
+	for _, name := range sortedPropertyNames(v.definitions) {
+		rewrite(v.definitions[name], base, true)
+	}
+	for len(queue) > 0 {
+		doc := queue[0]
+		queue = queue[1:]
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := loader.Load(ctx, doc)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("error loading %s: %s", doc, err)
+		}
+		if limit > 0 && len(data) > limit {
+			return fmt.Errorf("error loading %s: the document exceeds the maximum size of %d bytes", doc, limit)
+		}
+		var remote JSONSchema
+		if err := json.Unmarshal(data, &remote); err != nil {
+			return fmt.Errorf("invalid schema %s: %s", doc, err)
+		}
+		rewrite(&remote.Property, doc, false)
+		v.remote[doc+"#"] = &remote.Property
+		for name := range remote.Definitions {
+			def := remote.Definitions[name]
+			rewrite(&def, doc, false)
+			v.remote[doc+definitionsPrefix+escapePointer(name)] = &def
+		}
+	}
+	return nil
+}
+
+// absoluteRef returns the $ref ref resolved against the URI base, if any,
+// and the URI of its document, or false if it isn't absolute.
+func absoluteRef(base, ref string) (string, string, bool) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", false
+	}
+	if base != "" {
+		b, err := url.Parse(base)
+		if err != nil {
+			return "", "", false
+		}
+		u = b.ResolveReference(u)
+	}
+	if !u.IsAbs() {
+		return "", "", false
+	}
+	fragment := u.Fragment
+	u.Fragment, u.RawFragment = "", ""
+	doc := u.String()
+	return doc + "#" + fragment, doc, true
+}
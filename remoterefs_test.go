@@ -0,0 +1,93 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+type remoteRefsSuite struct{}
+
+var _ = Suite(&remoteRefsSuite{})
+
+// mapLoader loads the documents it holds, counting the loads.
+type mapLoader struct {
+	docs  map[string]string
+	loads int
+}
+
+func (l *mapLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	l.loads++
+	doc, ok := l.docs[uri]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return []byte(doc), nil
+}
+
+func (self *remoteRefsSuite) TestRemoteRefs(c *C) {
+	loader := &mapLoader{docs: map[string]string{
+		"https://example.com/schemas/address.json": `{
+			"type": "object",
+			"properties": {"street": {"$ref": "#/definitions/street"}, "country": {"$ref": "country.json"}},
+			"required": ["street"],
+			"definitions": {"street": {"type": "string", "minLength": 3}}
+		}`,
+		"https://example.com/schemas/country.json": `{"type": "string", "pattern": "^[A-Z]{2}$"}`,
+	}}
+	js := &JSONSchema{
+		ID: "https://example.com/schemas/customer.json",
+		Property: Property{
+			Type: "object",
+			Properties: map[string]*Property{
+				"address": {Ref: "address.json"},
+				"street":  {Ref: "https://example.com/schemas/address.json#/definitions/street"},
+				"name":    {Ref: "#/definitions/name"},
+			},
+		},
+		Definitions: map[string]Property{"name": {Type: "string"}},
+	}
+
+	_, err := NewValidator(js)
+	c.Assert(err, ErrorMatches, "unresolvable reference (address.json|https://example.com/schemas/address.json#/definitions/street)")
+
+	v, err := NewValidatorWithLoader(context.Background(), js, loader)
+	c.Assert(err, IsNil)
+	c.Assert(loader.loads, Equals, 2)
+
+	errs, err := v.Validate([]byte(`{"name": "Ann", "street": "Main St", "address": {"street": "Main St", "country": "FR"}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	errs, err = v.Validate([]byte(`{"name": 1, "street": "St", "address": {"country": "France"}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 4)
+	c.Assert(errs[0].Error(), Equals, "/address: missing required property street")
+	c.Assert(errs[1].Error(), Equals, "/address/country: must match the pattern ^[A-Z]{2}$")
+	c.Assert(errs[1].SchemaPath, Equals, "/properties/address/$ref/properties/country/$ref/pattern")
+}
+
+func (self *remoteRefsSuite) TestRemoteRefsErrors(c *C) {
+	loader := &mapLoader{docs: map[string]string{
+		"https://example.com/a.json": `{"type": "string"}`,
+	}}
+	ref := func(ref string) *JSONSchema {
+		return &JSONSchema{Property: Property{Ref: ref}}
+	}
+
+	_, err := NewValidatorWithLoader(context.Background(), ref("https://example.com/b.json"), loader)
+	c.Assert(err, ErrorMatches, "error loading https://example.com/b.json: not found")
+	_, err = NewValidatorWithLoader(context.Background(), ref("https://example.com/a.json#/definitions/missing"), loader)
+	c.Assert(err, ErrorMatches, "unresolvable reference https://example.com/a.json#/definitions/missing")
+	// relative without $id
+	_, err = NewValidatorWithLoader(context.Background(), ref("a.json"), loader)
+	c.Assert(err, ErrorMatches, "unresolvable reference a.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	loader.loads = 0
+	_, err = NewValidatorWithLoader(ctx, ref("https://example.com/a.json"), loader)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(loader.loads, Equals, 0)
+}
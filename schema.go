@@ -0,0 +1,361 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Schema is an immutable view of a generated JSONSchema.
+//
+// The maps and slices exported on JSONSchema and Property make it easy to
+// accidentally modify a schema which is shared with other code. A Schema
+// never exposes its internals: accessors return copies, and the With*
+// methods return a modified copy, leaving the receiver untouched.
+// Unmodified parts of the tree are shared between copies.
+type Schema struct {
+	js *JSONSchema
+}
+
+// GenerateSchema generates a schema for the provided interface and
+// returns it as an immutable Schema. The schema is a copy of the one
+// generated, which may share the values of examples, defaults and extensions
+// with the types described.
+func (g *Generator) GenerateSchema() (Schema, error) {
+	js, err := g.Generate()
+	if err != nil {
+		return Schema{}, err
+	}
+	return NewSchema(js), nil
+}
+
+// NewSchema returns an immutable Schema holding a copy of js.
+func NewSchema(js *JSONSchema) Schema {
+	return Schema{js: js.Clone()}
+}
+
+// JSONSchema returns a mutable deep copy of the schema.
+func (s Schema) JSONSchema() *JSONSchema {
+	return s.get().Clone()
+}
+
+// Property returns a copy of the named root property.
+func (s Schema) Property(name string) (*Property, bool) {
+	p, ok := s.get().Properties[name]
+	if !ok {
+		return nil, false
+	}
+	return p.Clone(), true
+}
+
+// Definition returns a copy of the named definition.
+func (s Schema) Definition(name string) (Property, bool) {
+	p, ok := s.get().Definitions[name]
+	if !ok {
+		return Property{}, false
+	}
+	return *p.Clone(), true
+}
+
+// String return the JSON encoding of the Schema as a string
+func (s Schema) String() string {
+	return s.get().String()
+}
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(*s.get())
+}
+
+// WithTitle returns a copy of the schema with the root title set.
+func (s Schema) WithTitle(title string) Schema {
+	return s.modify(func(js *JSONSchema) {
+		js.Title = title
+	})
+}
+
+// WithDescription returns a copy of the schema with the root description set.
+func (s Schema) WithDescription(description string) Schema {
+	return s.modify(func(js *JSONSchema) {
+		js.Description = description
+	})
+}
+
+// WithProperty returns a copy of the schema with the named root property
+// added or replaced by a copy of p.
+func (s Schema) WithProperty(name string, p *Property) Schema {
+	return s.modify(func(js *JSONSchema) {
+		js.Properties = copyProperties(js.Properties)
+		js.Properties[name] = p.Clone()
+	})
+}
+
+// WithoutProperty returns a copy of the schema with the named root property
+// removed, along with its entry in the required list.
+func (s Schema) WithoutProperty(name string) Schema {
+	return s.modify(func(js *JSONSchema) {
+		js.Properties = copyProperties(js.Properties)
+		delete(js.Properties, name)
+		required := make([]string, 0, len(js.Required))
+		for _, r := range js.Required {
+			if r != name {
+				required = append(required, r)
+			}
+		}
+		if len(required) == 0 {
+			required = nil
+		}
+		js.Required = required
+	})
+}
+
+// WithRequired returns a copy of the schema with the names added to the
+// root required list.
+func (s Schema) WithRequired(names ...string) Schema {
+	return s.modify(func(js *JSONSchema) {
+		required := append([]string{}, js.Required...)
+	outer:
+		for _, name := range names {
+			for _, r := range required {
+				if r == name {
+					continue outer
+				}
+			}
+			required = append(required, name)
+		}
+		js.Required = required
+	})
+}
+
+// WithDefinition returns a copy of the schema with the named definition
+// added or replaced by a copy of p.
+func (s Schema) WithDefinition(name string, p Property) Schema {
+	return s.modify(func(js *JSONSchema) {
+		definitions := make(map[string]Property, len(js.Definitions)+1)
+		for k, v := range js.Definitions {
+			definitions[k] = v
+		}
+		definitions[name] = *p.Clone()
+		js.Definitions = definitions
+	})
+}
+
+// WithoutDefinition returns a copy of the schema with the named definition removed.
+func (s Schema) WithoutDefinition(name string) Schema {
+	return s.modify(func(js *JSONSchema) {
+		if _, ok := js.Definitions[name]; !ok {
+			return
+		}
+		definitions := make(map[string]Property, len(js.Definitions))
+		for k, v := range js.Definitions {
+			if k != name {
+				definitions[k] = v
+			}
+		}
+		js.Definitions = definitions
+	})
+}
+
+// WithExtension returns a copy of the schema with the extension keyword set at the root.
+func (s Schema) WithExtension(key string, value interface{}) Schema {
+	return s.modify(func(js *JSONSchema) {
+		extensions := make(map[string]interface{}, len(js.Extensions)+1)
+		for k, v := range js.Extensions {
+			extensions[k] = v
+		}
+		extensions[key] = cloneValue(value)
+		js.Extensions = extensions
+	})
+}
+
+func (s Schema) get() *JSONSchema {
+	if s.js == nil {
+		return &JSONSchema{}
+	}
+	return s.js
+}
+
+// modify applies fn to a shallow copy of the schema. fn must replace,
+// rather than mutate, any map or slice it changes.
+func (s Schema) modify(fn func(js *JSONSchema)) Schema {
+	js := *s.get()
+	fn(&js)
+	return Schema{js: &js}
+}
+
+// Clone returns a deep copy of the schema.
+func (d *JSONSchema) Clone() *JSONSchema {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	c.Property = *d.Property.Clone()
+	if d.Definitions != nil {
+		c.Definitions = make(map[string]Property, len(d.Definitions))
+		for k, v := range d.Definitions {
+			c.Definitions[k] = *v.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the property.
+func (p *Property) Clone() *Property {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Items = p.Items.Clone()
+	c.AdditionalPropertiesSchema = p.AdditionalPropertiesSchema.Clone()
+	c.Not = p.Not.Clone()
+	c.DependentSchemas = cloneProperties(p.DependentSchemas)
+	if p.DependentRequired != nil {
+		c.DependentRequired = make(map[string][]string, len(p.DependentRequired))
+		for k, v := range p.DependentRequired {
+			c.DependentRequired[k] = append([]string{}, v...)
+		}
+	}
+	c.Properties = cloneProperties(p.Properties)
+	c.Dependencies = cloneProperties(p.Dependencies)
+	c.AnyOf = clonePropertySlice(p.AnyOf)
+	c.OneOf = clonePropertySlice(p.OneOf)
+	if p.Types != nil {
+		c.Types = append([]string{}, p.Types...)
+	}
+	if p.Required != nil {
+		c.Required = append([]string{}, p.Required...)
+	}
+	if p.Enum != nil {
+		c.Enum = cloneValue(p.Enum).([]interface{})
+	}
+	if p.Extensions != nil {
+		c.Extensions = cloneValue(p.Extensions).(map[string]interface{})
+	}
+	c.Const = cloneValue(p.Const)
+	c.Default = cloneValue(p.Default)
+	if p.Examples != nil {
+		c.Examples = cloneValue(p.Examples).([]interface{})
+	}
+	c.MultipleOf = cloneFloat64(p.MultipleOf)
+	c.Maximum = cloneFloat64(p.Maximum)
+	c.Minimum = cloneFloat64(p.Minimum)
+	c.ExclusiveMaximum = cloneFloat64(p.ExclusiveMaximum)
+	c.ExclusiveMinimum = cloneFloat64(p.ExclusiveMinimum)
+	c.MaxLength = cloneInt64(p.MaxLength)
+	c.MinLength = cloneInt64(p.MinLength)
+	c.MinItems = cloneInt64(p.MinItems)
+	c.MaxItems = cloneInt64(p.MaxItems)
+	return &c
+}
+
+func copyProperties(m map[string]*Property) map[string]*Property {
+	c := make(map[string]*Property, len(m)+1)
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func cloneProperties(m map[string]*Property) map[string]*Property {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]*Property, len(m))
+	for k, v := range m {
+		c[k] = v.Clone()
+	}
+	return c
+}
+
+func clonePropertySlice(s []*Property) []*Property {
+	if s == nil {
+		return nil
+	}
+	c := make([]*Property, len(s))
+	for i, v := range s {
+		c[i] = v.Clone()
+	}
+	return c
+}
+
+// cloneValue deep copies the maps, slices, arrays and pointers of v, e.g.
+// those produced by decoding JSON into an interface{} or the map[string]int64
+// of the x-flags extension; other values are returned as is.
+func cloneValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			c[k] = cloneValue(v)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(t))
+		for i, v := range t {
+			c[i] = cloneValue(v)
+		}
+		return c
+	}
+	return cloneReflectValue(reflect.ValueOf(v)).Interface()
+}
+
+// cloneReflectValue deep copies the maps, slices, arrays and pointers of v,
+// and the values held by its interfaces.
+func cloneReflectValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), cloneReflectValue(iter.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneReflectValue(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneReflectValue(v.Index(i)))
+		}
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(cloneReflectValue(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cloneReflectValue(v.Elem()))
+		return c
+	}
+	return v
+}
+
+func cloneFloat64(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	return float64ptr(*f)
+}
+
+func cloneInt64(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	return int64ptr(*i)
+}
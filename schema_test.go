@@ -0,0 +1,90 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type schemaSuite struct{}
+
+var _ = Suite(&schemaSuite{})
+
+func (self *schemaSuite) TestClone(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONBasicWithTag{}).MustGenerate()
+
+	k := j.Clone()
+	c.Assert(k, DeepEquals, j)
+
+	k.Properties["string"].Enum = append(k.Properties["string"].Enum, "x")
+	*k.Properties["float"].Minimum = 100
+	delete(k.Properties, "test")
+
+	c.Assert(j.Properties["string"].Enum, IsNil)
+	c.Assert(*j.Properties["float"].Minimum, Equals, 1.5)
+	c.Assert(j.Properties["test"], NotNil)
+}
+
+func (self *schemaSuite) TestCloneExtensions(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONPermissions{}).MustGenerate()
+	j.Properties["mode"].Extensions["x-owners"] = []string{"ops"}
+
+	k := j.Clone()
+	k.Properties["mode"].Extensions[FlagsExtension].(map[string]int64)["delete"] = 8
+	k.Properties["mode"].Extensions["x-owners"].([]string)[0] = "dev"
+
+	flags, _ := j.Properties["mode"].Flags()
+	c.Assert(flags, DeepEquals, map[string]int64{"read": 1, "write": 2, "admin": 4})
+	c.Assert(j.Properties["mode"].Extensions["x-owners"], DeepEquals, []string{"ops"})
+
+	flags["delete"] = 8
+	flags, _ = j.Properties["mode"].Flags()
+	c.Assert(flags, DeepEquals, map[string]int64{"read": 1, "write": 2, "admin": 4})
+
+	s := NewSchema(j)
+	j.Properties["mode"].Extensions[FlagsExtension].(map[string]int64)["delete"] = 8
+	p, _ := s.Property("mode")
+	flags, _ = p.Flags()
+	c.Assert(flags, DeepEquals, map[string]int64{"read": 1, "write": 2, "admin": 4})
+}
+
+func (self *schemaSuite) TestSchemaCopyOnWrite(c *C) {
+	s, err := NewGenerator().WithRoot(&ExampleJSONBasicSlices{}).GenerateSchema()
+	c.Assert(err, IsNil)
+
+	modified := s.
+		WithTitle("Slices").
+		WithProperty("Extra", &Property{Type: "string"}).
+		WithRequired("Extra").
+		WithoutProperty("Slice").
+		WithExtension("x-owner", "team")
+
+	c.Assert(s.JSONSchema(), DeepEquals, NewGenerator().WithRoot(&ExampleJSONBasicSlices{}).MustGenerate())
+
+	js := modified.JSONSchema()
+	c.Assert(js.Title, Equals, "Slices")
+	c.Assert(js.Required, DeepEquals, []string{"SliceOfInterface", "Extra"})
+	c.Assert(js.Properties["Extra"], DeepEquals, &Property{Type: "string"})
+	_, ok := js.Properties["Slice"]
+	c.Assert(ok, Equals, false)
+	c.Assert(js.Extensions, DeepEquals, map[string]interface{}{"x-owner": "team"})
+}
+
+func (self *schemaSuite) TestSchemaAccessorsReturnCopies(c *C) {
+	s := NewSchema(NewGenerator().WithRoot(&ExampleJSONBasicSlices{}).MustGenerate()).
+		WithDefinition("item", Property{Type: "string"})
+
+	p, ok := s.Property("Slice")
+	c.Assert(ok, Equals, true)
+	p.Items.Type = "integer"
+
+	d, ok := s.Definition("item")
+	c.Assert(ok, Equals, true)
+	d.Type = "integer"
+
+	p, _ = s.Property("Slice")
+	c.Assert(p.Items.Type, Equals, "string")
+	d, _ = s.Definition("item")
+	c.Assert(d.Type, Equals, "string")
+
+	_, ok = s.WithoutDefinition("item").Definition("item")
+	c.Assert(ok, Equals, false)
+}
@@ -0,0 +1,232 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SchemaSet is a set of root schemas generated together, e.g. the request
+// and response bodies of a service, sharing the same definitions.
+type SchemaSet struct {
+	Schema string
+	// Roots holds the root schemas by name. Their references point to Definitions.
+	Roots       map[string]Property
+	Definitions map[string]Property
+	// omitSchema leaves $schema out of the documents of the set
+	omitSchema bool
+	// reproducible serializes the documents of the set in their canonical form
+	reproducible bool
+}
+
+// GenerateSet generates a schema for each of the roots, by name, with the
+// definitions of the generator. The root of the generator is ignored.
+func (g *Generator) GenerateSet(roots map[string]interface{}) (*SchemaSet, error) {
+	generator := *g
+	generator.root = nil
+	generator.rootUnion = nil
+	// large enums are minified once the roots share the definitions
+	generator.options.MaxEnumSize = 0
+	js, err := generator.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	set := &SchemaSet{
+		Schema:       js.Schema,
+		Roots:        make(map[string]Property, len(roots)),
+		Definitions:  js.Definitions,
+		omitSchema:   js.omitSchema,
+		reproducible: js.reproducible,
+	}
+	warnings := generator.warnings
+	names := make([]string, 0, len(roots))
+	for name := range roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		generator.root = roots[name]
+		js, err := generator.generate()
+		if err != nil {
+			return nil, fmt.Errorf("root %s: %s", name, err)
+		}
+		set.Roots[name] = js.Property
+		for _, w := range generator.warnings {
+			// the definitions were checked with the definitions only
+			if !strings.HasPrefix(w.Path, "/definitions/") {
+				w.Path = "/roots/" + escapePointer(name) + w.Path
+				warnings = append(warnings, w)
+			}
+		}
+	}
+	g.warnings = warnings
+	logWarnings(g.logger, warnings)
+
+	minified := make([]*Property, len(names))
+	for i, name := range names {
+		root := set.Roots[name]
+		minified[i] = &root
+	}
+	set.Definitions = minifyEnums(g.options, set.Definitions, minified...)
+	for i, name := range names {
+		set.Roots[name] = *minified[i]
+	}
+
+	if g.options.OnlyReferencedDefinitions {
+		// the definitions are shared, so those referenced by any root are kept
+		used := map[string]bool{}
+		for _, root := range set.Roots {
+			js := &JSONSchema{Property: root, Definitions: set.Definitions}
+			for name := range js.referencedDefinitions() {
+				used[name] = true
+			}
+		}
+		for name := range set.Definitions {
+			if !used[name] {
+				delete(set.Definitions, name)
+			}
+		}
+	}
+	return set, nil
+}
+
+// Root returns the schema of the named root, including all the definitions of the set.
+func (s *SchemaSet) Root(name string) (*JSONSchema, bool) {
+	root, ok := s.Roots[name]
+	if !ok {
+		return nil, false
+	}
+	js := &JSONSchema{Schema: s.Schema, Property: root, Definitions: s.Definitions, omitSchema: s.omitSchema, reproducible: s.reproducible}
+	return js.Clone(), true
+}
+
+// Prefix returns a copy of the set with prefix prepended to the names of its
+// roots and definitions, and references rewritten accordingly, so that sets
+// generated independently, e.g. by several services, can be merged.
+func (s *SchemaSet) Prefix(prefix string) *SchemaSet {
+	rename := func(ref string) string {
+		if !strings.HasPrefix(ref, definitionsPrefix) {
+			return ref
+		}
+		return definitionReference(escapePointer(prefix + unescapePointer(strings.TrimPrefix(ref, definitionsPrefix))))
+	}
+
+	c := &SchemaSet{
+		Schema:       s.Schema,
+		Roots:        make(map[string]Property, len(s.Roots)),
+		Definitions:  make(map[string]Property, len(s.Definitions)),
+		omitSchema:   s.omitSchema,
+		reproducible: s.reproducible,
+	}
+	for name, root := range s.Roots {
+		p := root.Clone()
+		rewriteRefs(p, rename)
+		c.Roots[prefix+name] = *p
+	}
+	for name, def := range s.Definitions {
+		p := def.Clone()
+		rewriteRefs(p, rename)
+		c.Definitions[prefix+name] = *p
+	}
+	return c
+}
+
+// MergeSchemaSets returns a set holding the roots and definitions of all the
+// sets. Roots or definitions with the same name are an error, unless the
+// definitions are identical.
+func MergeSchemaSets(sets ...*SchemaSet) (*SchemaSet, error) {
+	merged := &SchemaSet{
+		Roots:       map[string]Property{},
+		Definitions: map[string]Property{},
+	}
+	for _, s := range sets {
+		if merged.Schema == "" {
+			merged.Schema, merged.omitSchema, merged.reproducible = s.Schema, s.omitSchema, s.reproducible
+		}
+		for _, name := range sortedDefinitionNames(s.Roots) {
+			if _, ok := merged.Roots[name]; ok {
+				return nil, fmt.Errorf("root %s is defined in several sets", name)
+			}
+			root := s.Roots[name]
+			merged.Roots[name] = *root.Clone()
+		}
+		for _, name := range sortedDefinitionNames(s.Definitions) {
+			def := s.Definitions[name]
+			if existing, ok := merged.Definitions[name]; ok {
+				if len(Diff(&JSONSchema{Property: existing}, &JSONSchema{Property: def})) > 0 {
+					return nil, fmt.Errorf("definition %s differs between sets", name)
+				}
+				continue
+			}
+			merged.Definitions[name] = *def.Clone()
+		}
+	}
+	return merged, nil
+}
+
+// Names returns the names of the roots, sorted.
+func (s *SchemaSet) Names() []string {
+	names := make([]string, 0, len(s.Roots))
+	for name := range s.Roots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CommonFile is the name of the file written by WriteSplit holding the
+// definitions shared by the roots.
+const CommonFile = "common.json"
+
+// WriteSplit writes each root of the set to its own file in dir, named after
+// the root with a .json extension, and the definitions to CommonFile. The
+// references of the roots point to CommonFile, e.g. "common.json#/definitions/name",
+// while those between definitions remain local to it.
+func (s *SchemaSet) WriteSplit(dir string) error {
+	for _, name := range s.Names() {
+		if name+".json" == CommonFile || name == "" || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("root %s can't be written to its own file", name)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	common := &JSONSchema{Schema: s.Schema, Definitions: s.Definitions, omitSchema: s.omitSchema, reproducible: s.reproducible}
+	if err := writeSchemaFile(filepath.Join(dir, CommonFile), common); err != nil {
+		return err
+	}
+
+	prefix := definitionsPrefix
+	if isModernDraft(s.Schema) {
+		prefix = defsPrefix
+	}
+	for _, name := range s.Names() {
+		root, _ := s.Root(name)
+		root.Definitions = nil
+		RewriteRefs(root, func(ref string) string {
+			if strings.HasPrefix(ref, definitionsPrefix) {
+				return CommonFile + prefix + strings.TrimPrefix(ref, definitionsPrefix)
+			}
+			return ref
+		})
+		if err := writeSchemaFile(filepath.Join(dir, name+".json"), root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSchemaFile(path string, js *JSONSchema) error {
+	b, err := json.MarshalIndent(js, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type schemaSetSuite struct{}
+
+var _ = Suite(&schemaSetSuite{})
+
+type ExampleJSONInvoice struct {
+	Number string               `json:"number"`
+	Lines  []ExampleJSONMapItem `json:"lines"`
+}
+
+type ExampleJSONShipment struct {
+	Items map[string]ExampleJSONMapItem `json:"items"`
+}
+
+func (self *schemaSetSuite) TestGenerateSet(c *C) {
+	set, err := NewGenerator().
+		WithDefinition("item", ExampleJSONMapItem{}).
+		GenerateSet(map[string]interface{}{
+			"invoice": ExampleJSONInvoice{},
+			"lines":   []ExampleJSONMapItem{},
+		})
+	c.Assert(err, IsNil)
+
+	c.Assert(set.Names(), DeepEquals, []string{"invoice", "lines"})
+	c.Assert(set.Roots["lines"], DeepEquals, Property{Type: "array", Items: &Property{Ref: "#/definitions/item"}})
+	c.Assert(set.Definitions["item"].Properties["name"], DeepEquals, &Property{Type: "string"})
+
+	js, ok := set.Root("invoice")
+	c.Assert(ok, Equals, true)
+	c.Assert(js.Schema, Equals, DEFAULT_SCHEMA)
+	c.Assert(js.Properties["lines"].Items.Ref, Equals, "#/definitions/item")
+	c.Assert(js.Definitions, HasLen, 1)
+}
+
+func (self *schemaSetSuite) TestPrefixAndMerge(c *C) {
+	billing, err := NewGenerator().
+		WithDefinition("item", ExampleJSONMapItem{}).
+		GenerateSet(map[string]interface{}{"invoice": ExampleJSONInvoice{}})
+	c.Assert(err, IsNil)
+	shipping, err := NewGenerator().
+		WithDefinition("item", ExampleJSONMapItem{}).
+		WithDefinition("shipment", ExampleJSONShipment{}).
+		GenerateSet(map[string]interface{}{"shipment": ExampleJSONShipment{}})
+	c.Assert(err, IsNil)
+
+	prefixed := billing.Prefix("billing.")
+	c.Assert(billing.Roots["invoice"].Properties["lines"].Items.Ref, Equals, "#/definitions/item")
+	c.Assert(prefixed.Roots["billing.invoice"].Properties["lines"].Items.Ref, Equals, "#/definitions/billing.item")
+
+	merged, err := MergeSchemaSets(prefixed, shipping.Prefix("shipping."))
+	c.Assert(err, IsNil)
+	c.Assert(merged.Names(), DeepEquals, []string{"billing.invoice", "shipping.shipment"})
+	c.Assert(sortedDefinitionNames(merged.Definitions), DeepEquals, []string{"billing.item", "shipping.item", "shipping.shipment"})
+	c.Assert(merged.Roots["shipping.shipment"].Ref, Equals, "#/definitions/shipping.shipment")
+	c.Assert(merged.Definitions["shipping.shipment"].Properties["items"].AdditionalPropertiesSchema.Ref, Equals, "#/definitions/shipping.item")
+
+	// identical definitions are shared
+	merged, err = MergeSchemaSets(billing, shipping)
+	c.Assert(err, IsNil)
+	c.Assert(merged.Definitions, HasLen, 2)
+
+	_, err = MergeSchemaSets(billing, billing)
+	c.Assert(err, ErrorMatches, "root invoice is defined in several sets")
+
+	other, err := NewGenerator().
+		WithDefinition("item", ExampleJSONInvoice{}).
+		GenerateSet(map[string]interface{}{})
+	c.Assert(err, IsNil)
+	_, err = MergeSchemaSets(billing, other)
+	c.Assert(err, ErrorMatches, "definition item differs between sets")
+}
+
+func (self *schemaSetSuite) TestWriteSplit(c *C) {
+	set, err := NewGenerator().
+		WithDefinition("item", ExampleJSONMapItem{}).
+		WithDefinition("shipment", ExampleJSONShipment{}).
+		GenerateSet(map[string]interface{}{
+			"invoice":  ExampleJSONInvoice{},
+			"shipment": ExampleJSONShipment{},
+		})
+	c.Assert(err, IsNil)
+
+	dir := c.MkDir()
+	c.Assert(set.WriteSplit(dir), IsNil)
+
+	read := func(name string) *JSONSchema {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		c.Assert(err, IsNil)
+		js := &JSONSchema{}
+		c.Assert(json.Unmarshal(b, js), IsNil)
+		return js
+	}
+
+	common := read(CommonFile)
+	c.Assert(sortedDefinitionNames(common.Definitions), DeepEquals, []string{"item", "shipment"})
+	c.Assert(common.Definitions["shipment"].Properties["items"].AdditionalPropertiesSchema.Ref, Equals, "#/definitions/item")
+	c.Assert(common.Type, Equals, "")
+
+	invoice := read("invoice.json")
+	c.Assert(invoice.Schema, Equals, DEFAULT_SCHEMA)
+	c.Assert(invoice.Definitions, HasLen, 0)
+	c.Assert(invoice.Properties["lines"].Items.Ref, Equals, "common.json#/definitions/item")
+	c.Assert(read("shipment.json").Ref, Equals, "common.json#/definitions/shipment")
+
+	// the set itself is left untouched
+	c.Assert(set.Roots["invoice"].Properties["lines"].Items.Ref, Equals, "#/definitions/item")
+}
+
+func (self *schemaSetSuite) TestWriteSplitDefs(c *C) {
+	set, err := NewGenerator(Options{Schema: Draft202012Schema}).
+		WithDefinition("item", ExampleJSONMapItem{}).
+		GenerateSet(map[string]interface{}{"invoice": ExampleJSONInvoice{}})
+	c.Assert(err, IsNil)
+
+	dir := c.MkDir()
+	c.Assert(set.WriteSplit(dir), IsNil)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "invoice.json"))
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `(?s).*"\$ref": "common.json#/\$defs/item".*`)
+	b, err = ioutil.ReadFile(filepath.Join(dir, CommonFile))
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `(?s).*"\$defs": \{.*`)
+}
+
+func (self *schemaSetSuite) TestWriteSplitInvalidNames(c *C) {
+	for _, name := range []string{"common", "a/b", ""} {
+		set := &SchemaSet{Roots: map[string]Property{name: {Type: "object"}}}
+		c.Assert(set.WriteSplit(c.MkDir()), ErrorMatches, "root .* can't be written to its own file")
+	}
+}
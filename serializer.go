@@ -0,0 +1,315 @@
+package jsonschema
+
+import (
+	"reflect"
+)
+
+// serializer writes the nodes of a Model as the keywords of a draft of JSON
+// Schema.
+type serializer struct {
+	// typeArrays spells the nullable primitives and the unions of types as
+	// type arrays rather than anyOf, for NullableStyle TypeArray.
+	typeArrays bool
+	// dependentKeywords spells the constraints of fields on the others with
+	// dependentRequired and dependentSchemas, as the drafts since 2019-09
+	// do, rather than with dependencies.
+	dependentKeywords bool
+	// booleanBounds spells the exclusive bounds as the booleans qualifying
+	// minimum and maximum of draft-04.
+	booleanBounds bool
+}
+
+// newSerializer returns the serializer of the draft and the nullable style
+// of the options.
+func newSerializer(options Options) serializer {
+	return serializer{
+		typeArrays:        options.NullableStyle == TypeArray,
+		dependentKeywords: isModernDraft(options.Schema),
+		booleanBounds:     options.Draft == Draft04,
+	}
+}
+
+// document returns the schema of the root and the definitions of m.
+func (s serializer) document(m *Model) *JSONSchema {
+	d := &JSONSchema{}
+	if m.Root != nil {
+		d.Property = *s.schema(m.Root)
+	}
+	if m.Definitions != nil {
+		d.Definitions = make(map[string]Property, len(m.Definitions))
+		for name, def := range m.Definitions {
+			d.Definitions[name] = *s.schema(def)
+		}
+	}
+	return d
+}
+
+// schema returns the schema of the values described by n.
+func (s serializer) schema(n *Node) *Property {
+	p := s.values(n)
+	if n.Nullable && !n.verbatim {
+		p = s.nullable(p, n)
+	}
+	return p
+}
+
+// values returns the schema of the values of n other than null.
+func (s serializer) values(n *Node) *Property {
+	p := &Property{}
+	if n.Schema != nil {
+		*p = *n.Schema
+	}
+	if n.verbatim {
+		return p
+	}
+
+	switch n.Kind {
+	case KindRef:
+		p.Ref = n.Ref
+	case KindNull, KindBoolean, KindInteger, KindNumber, KindString:
+		p.Type, p.Types = string(n.Kind), nil
+	case KindArray:
+		p.Type, p.Types, p.Items = "array", nil, nil
+		if n.Elem != nil {
+			p.Items = s.schema(n.Elem)
+		}
+	case KindMap, KindObject:
+		p.Type, p.Types = "object", nil
+		p.Properties, p.AdditionalPropertiesSchema = nil, nil
+		if n.Kind == KindObject {
+			p.Properties = make(map[string]*Property, len(n.Fields))
+			for _, f := range n.Fields {
+				p.Properties[f.Name] = s.schema(f.Node)
+			}
+			p.Required = requiredFields(n)
+		}
+		s.setValues(p, n)
+	case KindUnion:
+		p.Type, p.Types = "", nil
+		variants := make([]*Property, len(n.Variants))
+		for i, variant := range n.Variants {
+			variants[i] = s.schema(variant)
+		}
+		switch {
+		case n.Exclusive:
+			p.OneOf = variants
+		case s.typeArrays && bareTypes(n.Variants):
+			p.AnyOf = nil
+			for _, variant := range variants {
+				p.Types = append(p.Types, variant.Type)
+			}
+		default:
+			p.AnyOf = variants
+		}
+	}
+
+	if s.dependentKeywords && len(p.Dependencies) > 0 {
+		splitDependencies(p)
+	}
+	return p
+}
+
+// setValues describes the values of the map n, or the properties of the
+// object n which aren't its fields, into p. Without Elem, they are left to
+// the additionalProperties keywords of the schema of n.
+func (s serializer) setValues(p *Property, n *Node) {
+	if n.Elem == nil {
+		return
+	}
+	p.AdditionalProperties, p.NoAdditionalProperties = false, false
+	if n.patternValues {
+		if p.Properties == nil {
+			p.Properties = map[string]*Property{}
+		}
+		p.Properties[".*"] = s.schema(n.Elem)
+		return
+	}
+	p.AdditionalPropertiesSchema = s.schema(n.Elem)
+}
+
+// nullable returns the schema allowing null along the values described by p,
+// those of n.
+func (s serializer) nullable(p *Property, n *Node) *Property {
+	switch {
+	case p.Ref != "":
+		// the siblings of $ref are ignored before draft 2019-09
+		nullable := *p
+		nullable.Ref = ""
+		nullable.AnyOf = []*Property{{Ref: p.Ref}, {Type: "null"}}
+		nullable.Enum, nullable.Const = nullableValues(p)
+		return &nullable
+	case n.Kind == KindUnion:
+		switch {
+		case len(p.Types) > 0:
+			p.Types = append(append([]string{}, p.Types...), "null")
+		case len(p.OneOf) > 0:
+			p.OneOf = append(append([]*Property{}, p.OneOf...), &Property{Type: "null"})
+		default:
+			p.AnyOf = append(append([]*Property{}, p.AnyOf...), &Property{Type: "null"})
+		}
+		return p
+	case s.typeArrays && n.primitive():
+		p.Types = []string{p.Type, "null"}
+		p.Enum, p.Const = nullableValues(p)
+		return p
+	}
+
+	// the values are described by a branch, along with the keywords which
+	// would reject null, the others apply to the values of their type only
+	branch := &Property{
+		Type:                       p.Type,
+		Items:                      p.Items,
+		Properties:                 p.Properties,
+		Required:                   p.Required,
+		AdditionalProperties:       p.AdditionalProperties,
+		AdditionalPropertiesSchema: p.AdditionalPropertiesSchema,
+		NoAdditionalProperties:     p.NoAdditionalProperties,
+		Enum:                       p.Enum,
+		Const:                      p.Const,
+	}
+	nullable := *p
+	nullable.Type, nullable.Items, nullable.Properties, nullable.Required = "", nil, nil, nil
+	nullable.AdditionalProperties, nullable.AdditionalPropertiesSchema, nullable.NoAdditionalProperties = false, nil, false
+	nullable.Enum, nullable.Const = nil, nil
+	if allow, ok := p.Extensions[AllowNaNExtension]; ok {
+		branch.Extensions = map[string]interface{}{AllowNaNExtension: allow}
+		nullable.Extensions = copyExtensions(p.Extensions)
+		delete(nullable.Extensions, AllowNaNExtension)
+		if len(nullable.Extensions) == 0 {
+			nullable.Extensions = nil
+		}
+	}
+	nullable.AnyOf = []*Property{branch, {Type: "null"}}
+	return &nullable
+}
+
+// rewriteKeywords spells the keywords of d, and of all its subschemas, as the
+// draft of the serializer does, once the schema is checked: the exclusive
+// bounds of draft-04 can't be compared with the others.
+func (s serializer) rewriteKeywords(d *JSONSchema) {
+	if !s.booleanBounds {
+		return
+	}
+	walkProperties(&d.Property, booleanExclusiveBounds)
+	for name, def := range d.Definitions {
+		walkProperties(&def, booleanExclusiveBounds)
+		d.Definitions[name] = def
+	}
+}
+
+// requiredFields returns the names of the required fields of the object n,
+// in the order of the schema n was derived from, if any, then of its fields.
+func requiredFields(n *Node) []string {
+	var required []string
+	if n.Schema != nil {
+		for _, name := range n.Schema.Required {
+			if f := n.field(name); f == nil || f.Required {
+				required = append(required, name)
+			}
+		}
+	}
+	for _, f := range n.Fields {
+		if f.Required && !containsString(required, f.Name) {
+			required = append(required, f.Name)
+		}
+	}
+	return required
+}
+
+// bareTypes reports whether the variants only name types, so that they may
+// be spelled as a type array.
+func bareTypes(variants []*Node) bool {
+	for _, v := range variants {
+		named := v.schemaType() != "" && v.Kind != KindObject
+		if !named || v.Nullable || v.verbatim || v.Elem != nil || !isEmptySchema(withoutType(v.Schema)) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitDependencies spells the dependencies of p as dependentRequired, for the
+// properties they require, and dependentSchemas, for the other keywords.
+func splitDependencies(p *Property) {
+	dependencies := p.Dependencies
+	p.Dependencies = nil
+	p.DependentRequired = copyDependentRequired(p.DependentRequired)
+	p.DependentSchemas = copyDependencies(p.DependentSchemas)
+	for _, name := range sortedPropertyNames(dependencies) {
+		d := *dependencies[name]
+		if len(d.Required) > 0 {
+			if p.DependentRequired == nil {
+				p.DependentRequired = map[string][]string{}
+			}
+			p.DependentRequired[name] = append(p.DependentRequired[name], d.Required...)
+			d.Required = nil
+		}
+		if !isEmptySchema(&d) {
+			if p.DependentSchemas == nil {
+				p.DependentSchemas = map[string]*Property{}
+			}
+			p.DependentSchemas[name] = &d
+		}
+	}
+}
+
+// nullableValues returns the enum of p allowing null along its values, if p
+// has an enum or a const.
+func nullableValues(p *Property) ([]interface{}, interface{}) {
+	if p.Enum == nil && p.Const == nil {
+		return nil, nil
+	}
+	enum := p.Enum
+	if p.Const != nil {
+		enum = []interface{}{p.Const}
+	}
+	for _, v := range enum {
+		if v == nil {
+			return enum, nil
+		}
+	}
+	return append(append([]interface{}{}, enum...), nil), nil
+}
+
+func isEmptySchema(p *Property) bool {
+	return p == nil || reflect.DeepEqual(*p, Property{})
+}
+
+func withoutType(p *Property) *Property {
+	if p == nil {
+		return nil
+	}
+	bare := *p
+	bare.Type = ""
+	return &bare
+}
+
+func copyExtensions(extensions map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(extensions)+1)
+	for k, v := range extensions {
+		copied[k] = v
+	}
+	return copied
+}
+
+func copyDependencies(dependencies map[string]*Property) map[string]*Property {
+	if dependencies == nil {
+		return nil
+	}
+	copied := make(map[string]*Property, len(dependencies))
+	for name, d := range dependencies {
+		copied[name] = d
+	}
+	return copied
+}
+
+func copyDependentRequired(required map[string][]string) map[string][]string {
+	if required == nil {
+		return nil
+	}
+	copied := make(map[string][]string, len(required))
+	for name, names := range required {
+		copied[name] = names
+	}
+	return copied
+}
@@ -0,0 +1,76 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// signatureHeader is the protected header of the signatures of schemas.
+var signatureHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA"}`))
+
+// Sign signs the canonical form of the schema with the ed25519 key, so that
+// consumers can trust the schema published to a registry wasn't tampered
+// with. The signature is a JWS with a detached payload (RFC 7515, appendix
+// F), to be published along with the schema and checked with VerifySchema.
+func (d *JSONSchema) Sign(key ed25519.PrivateKey) (string, error) {
+	payload, err := d.Canonical()
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(key, signingInput(signatureHeader, payload))
+	return signatureHeader + ".." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// VerifySchema parses the schema document once its signature, as returned by
+// Sign, is verified with the ed25519 key. The signature covers the canonical
+// form of the document, so it survives reformatting but not a change of any
+// keyword, including those the schema isn't parsed into.
+func VerifySchema(document []byte, signature string, key ed25519.PublicKey) (*JSONSchema, error) {
+	parts := strings.Split(signature, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return nil, fmt.Errorf("invalid signature: not a JWS with a detached payload")
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %s", err)
+	}
+	var header struct {
+		Alg  string   `json:"alg"`
+		Crit []string `json:"crit"`
+	}
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, fmt.Errorf("invalid signature: %s", err)
+	}
+	if header.Alg != "EdDSA" || len(header.Crit) > 0 {
+		return nil, fmt.Errorf("invalid signature: unsupported header %s", rawHeader)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %s", err)
+	}
+
+	payload, err := canonicalJSON(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %s", err)
+	}
+	if !ed25519.Verify(key, signingInput(parts[0], payload), sig) {
+		return nil, fmt.Errorf("the signature doesn't match the schema")
+	}
+
+	d := &JSONSchema{}
+	if err := json.Unmarshal(document, d); err != nil {
+		return nil, fmt.Errorf("invalid schema: %s", err)
+	}
+	return d, nil
+}
+
+// signingInput returns the JWS signing input of the payload under the
+// encoded protected header.
+func signingInput(header string, payload []byte) []byte {
+	return []byte(header + "." + base64.RawURLEncoding.EncodeToString(payload))
+}
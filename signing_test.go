@@ -0,0 +1,50 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type signingSuite struct{}
+
+var _ = Suite(&signingSuite{})
+
+func (self *signingSuite) TestSignAndVerify(c *C) {
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	js := NewGenerator().WithRoot(&ExampleJSONContract{}).MustGenerate()
+
+	signature, err := js.Sign(key)
+	c.Assert(err, IsNil)
+	c.Assert(signature, Matches, `eyJhbGciOiJFZERTQSJ9\.\.[A-Za-z0-9_-]+`)
+
+	// the signature covers the canonical form, not the formatting
+	published := js.String()
+	verified, err := VerifySchema([]byte(published), signature, key.Public().(ed25519.PublicKey))
+	c.Assert(err, IsNil)
+	c.Assert(verified.Properties["id"].MaxLength, DeepEquals, js.Properties["id"].MaxLength)
+
+	tampered := strings.Replace(published, `"maxLength": 36`, `"maxLength": 3600`, 1)
+	c.Assert(tampered, Not(Equals), published)
+	_, err = VerifySchema([]byte(tampered), signature, key.Public().(ed25519.PublicKey))
+	c.Assert(err, ErrorMatches, "the signature doesn't match the schema")
+
+	// keywords the schema isn't parsed into are covered too
+	extended := strings.Replace(published, `"type": "object"`, `"type": "object", "x-owner": "mallory"`, 1)
+	c.Assert(extended, Not(Equals), published)
+	_, err = VerifySchema([]byte(extended), signature, key.Public().(ed25519.PublicKey))
+	c.Assert(err, ErrorMatches, "the signature doesn't match the schema")
+
+	other := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{2}, ed25519.SeedSize))
+	_, err = VerifySchema([]byte(published), signature, other.Public().(ed25519.PublicKey))
+	c.Assert(err, ErrorMatches, "the signature doesn't match the schema")
+
+	_, err = VerifySchema([]byte(published), "not a signature", key.Public().(ed25519.PublicKey))
+	c.Assert(err, ErrorMatches, "invalid signature: not a JWS with a detached payload")
+	_, err = VerifySchema([]byte(published), "eyJhbGciOiJIUzI1NiJ9..c2ln", key.Public().(ed25519.PublicKey))
+	c.Assert(err, ErrorMatches, `invalid signature: unsupported header {"alg":"HS256"}`)
+}
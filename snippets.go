@@ -0,0 +1,41 @@
+package jsonschema
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// WithSnippets registers text which descriptions may interpolate by name,
+// e.g. `description:"{{.iso4217}} currency code"`, so that long shared
+// descriptions are written once. Snippets registered by several calls are merged.
+func (g *Generator) WithSnippets(snippets map[string]string) *Generator {
+	if g.snippets == nil {
+		g.snippets = map[string]string{}
+	}
+	for name, text := range snippets {
+		g.snippets[name] = text
+	}
+	return g
+}
+
+// interpolate replaces the snippets referenced by the description. It is an
+// error to reference a snippet which wasn't registered.
+func (r *reader) interpolate(description string) (string, error) {
+	if !strings.Contains(description, "{{") {
+		return description, nil
+	}
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(description)
+	if err != nil {
+		return "", err
+	}
+	snippets := r.snippets
+	if snippets == nil {
+		snippets = map[string]string{}
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, snippets); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
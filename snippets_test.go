@@ -0,0 +1,50 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type snippetsSuite struct{}
+
+var _ = Suite(&snippetsSuite{})
+
+type ExampleJSONPrice struct {
+	Currency string `json:"currency" description:"{{.iso4217}} currency code"`
+	Amount   int64  `json:"amount" description:"Amount in {{.minorUnits}}"`
+	Note     string `json:"note" description:"Plain description"`
+}
+
+var exampleSnippets = map[string]string{
+	"iso4217":    "ISO 4217",
+	"minorUnits": "the minor units of the currency, e.g. cents",
+}
+
+func (self *snippetsSuite) TestSnippets(c *C) {
+	j, err := NewGenerator().WithSnippets(exampleSnippets).WithRoot(&ExampleJSONPrice{}).Generate()
+	c.Assert(err, IsNil)
+
+	c.Assert(j.Properties["currency"].Description, Equals, "ISO 4217 currency code")
+	c.Assert(j.Properties["amount"].Description, Equals, "Amount in the minor units of the currency, e.g. cents")
+	c.Assert(j.Properties["note"].Description, Equals, "Plain description")
+}
+
+func (self *snippetsSuite) TestSnippetsMerge(c *C) {
+	j, err := NewGenerator().
+		WithSnippets(map[string]string{"iso4217": "ISO 4217"}).
+		WithSnippets(map[string]string{"minorUnits": "cents"}).
+		WithRoot(&ExampleJSONPrice{}).
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.Properties["amount"].Description, Equals, "Amount in cents")
+}
+
+func (self *snippetsSuite) TestSnippetsMissing(c *C) {
+	_, err := NewGenerator().WithSnippets(map[string]string{"iso4217": "ISO 4217"}).WithRoot(&ExampleJSONPrice{}).Generate()
+	c.Assert(err, ErrorMatches, ".*property:Amount:description:.*minorUnits.*")
+
+	type invalid struct {
+		Name string `description:"{{.name"`
+	}
+	_, err = NewGenerator().WithRoot(&invalid{}).Generate()
+	c.Assert(err, ErrorMatches, ".*property:Name:description:.*")
+}
@@ -0,0 +1,414 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SourceGenerator generates schemas like Generator, from the static type
+// information of packages read from source rather than by reflection. No
+// instance of the types is needed, so types of internal packages or of files
+// excluded by build constraints may be described, and the doc comments of
+// types and fields are the descriptions of those without description tag.
+//
+// Types are named by the import path of their package and their name, e.g.
+// "example.com/shop.Order", or by their name alone when it is unique among
+// the packages loaded. The ModifySchema methods of the types can't be called
+// from source, so they have no effect.
+type SourceGenerator struct {
+	pattern      string
+	tags         []string
+	root         string
+	rootUnion    []string
+	definitions  map[string]string
+	aliases      map[string]string
+	deprecations map[string]time.Time
+	snippets     map[string]string
+	presets      map[string]map[string]string
+	typeTags     map[string]map[string]string
+	policies     []Policy
+	options      Options
+	logger       *slog.Logger
+	warnings     []Inconsistency
+}
+
+// NewSourceGenerator returns a generator of schemas for the types of the
+// packages matching pattern, as the go command matches it: a directory or an
+// import path, followed by "/..." to include the packages below it.
+func NewSourceGenerator(pattern string, options ...Options) *SourceGenerator {
+	return &SourceGenerator{pattern: pattern, options: NewGenerator(options...).options}
+}
+
+// WithLogger logs the warnings and the trace of the generation to logger,
+// as Generator.WithLogger does.
+func (g *SourceGenerator) WithLogger(logger *slog.Logger) *SourceGenerator {
+	g.logger = logger
+	return g
+}
+
+// WithBuildTags sets build tags when reading the packages, so that the types
+// of files guarded by build constraints may be described.
+func (g *SourceGenerator) WithBuildTags(tags ...string) *SourceGenerator {
+	g.tags = append(g.tags, tags...)
+	return g
+}
+
+// WithRoot describes the type named typeName at the root of the schema.
+func (g *SourceGenerator) WithRoot(typeName string) *SourceGenerator {
+	g.root = typeName
+	g.rootUnion = nil
+	return g
+}
+
+// WithRootUnion describes at the root of the schema the values of any one of
+// the types named, as Generator.WithRootUnion does. It replaces the root set
+// with WithRoot.
+func (g *SourceGenerator) WithRootUnion(typeNames ...string) *SourceGenerator {
+	g.root = ""
+	g.rootUnion = typeNames
+	return g
+}
+
+// WithDefinition registers the type named typeName under name in the
+// definitions of the schema.
+func (g *SourceGenerator) WithDefinition(name, typeName string) *SourceGenerator {
+	if g.definitions == nil {
+		g.definitions = map[string]string{}
+	}
+	g.definitions[name] = typeName
+	return g
+}
+
+// WithDefinitionAlias registers alias as an additional name for the
+// canonical definition, as Generator.WithDefinitionAlias does.
+func (g *SourceGenerator) WithDefinitionAlias(alias, canonical string) *SourceGenerator {
+	if g.aliases == nil {
+		g.aliases = map[string]string{}
+	}
+	g.aliases[alias] = canonical
+	return g
+}
+
+// WithDeprecatedDefinition marks the named definition as deprecated, with an
+// optional sunset date, as Generator.WithDeprecatedDefinition does.
+func (g *SourceGenerator) WithDeprecatedDefinition(name string, sunset time.Time) *SourceGenerator {
+	if g.deprecations == nil {
+		g.deprecations = map[string]time.Time{}
+	}
+	g.deprecations[name] = sunset
+	return g
+}
+
+// WithSnippets registers text which descriptions may interpolate by name, as
+// Generator.WithSnippets does.
+func (g *SourceGenerator) WithSnippets(snippets map[string]string) *SourceGenerator {
+	if g.snippets == nil {
+		g.snippets = map[string]string{}
+	}
+	for name, text := range snippets {
+		g.snippets[name] = text
+	}
+	return g
+}
+
+// WithTagPreset registers tags which the preset tag expands to, as
+// Generator.WithTagPreset does.
+func (g *SourceGenerator) WithTagPreset(name string, tags map[string]string) *SourceGenerator {
+	if g.presets == nil {
+		g.presets = map[string]map[string]string{}
+	}
+	g.presets[name] = tags
+	return g
+}
+
+// WithTypeTags sets tags on every field of the type named typeName, or of a
+// pointer to it, as Generator.WithTypeTags does.
+func (g *SourceGenerator) WithTypeTags(typeName string, tags map[string]string) *SourceGenerator {
+	if g.typeTags == nil {
+		g.typeTags = map[string]map[string]string{}
+	}
+	g.typeTags[typeName] = tags
+	return g
+}
+
+// WithPolicy applies the policies to the schemas generated, as
+// Generator.WithPolicy does.
+func (g *SourceGenerator) WithPolicy(policies ...Policy) *SourceGenerator {
+	g.policies = append(g.policies, policies...)
+	return g
+}
+
+// Warnings returns the inconsistencies found in the schema last generated.
+func (g *SourceGenerator) Warnings() []Inconsistency {
+	return g.warnings
+}
+
+// Generate reads the packages and generates the schema of the types registered.
+func (g *SourceGenerator) Generate() (*JSONSchema, error) {
+	if err := g.options.checkDraft(); err != nil {
+		return nil, err
+	}
+	pkgs, err := loadPackages(g.pattern, g.tags...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages match %s", g.pattern)
+	}
+	info := &sourceInfo{fset: pkgs[0].Fset, docs: sourceDocs(pkgs)}
+	typeTags := make(map[string]map[string]string, len(g.typeTags))
+	for name, tags := range g.typeTags {
+		t, err := lookupType(pkgs, info, name)
+		if err != nil {
+			return nil, err
+		}
+		typeTags[t.Name()] = tags
+	}
+
+	ts := typeSet{aliases: g.aliases, deprecations: g.deprecations}
+	if g.definitions != nil {
+		ts.definitions = make(map[string]goType, len(g.definitions))
+		for name, typeName := range g.definitions {
+			t, err := lookupType(pkgs, info, typeName)
+			if err != nil {
+				return nil, err
+			}
+			ts.definitions[name] = t
+		}
+	}
+	if g.root != "" {
+		if ts.root, err = lookupType(pkgs, info, g.root); err != nil {
+			return nil, err
+		}
+	}
+	for _, typeName := range g.rootUnion {
+		t, err := lookupType(pkgs, info, typeName)
+		if err != nil {
+			return nil, err
+		}
+		ts.rootUnion = append(ts.rootUnion, t)
+	}
+
+	r := &reader{
+		options:         g.options,
+		snippets:        g.snippets,
+		presets:         g.presets,
+		typeTags:        typeTags,
+		logger:          g.logger,
+		visiting:        map[interface{}]int{},
+		definitionNames: map[string]bool{},
+	}
+	m, err := r.readModel(ts)
+	if err != nil {
+		return nil, err
+	}
+	d, warnings, err := buildDocument(m, r, g.policies, ts.root != nil || len(ts.rootUnion) > 0)
+	g.warnings = warnings
+	if err != nil {
+		return nil, err
+	}
+	logWarnings(g.logger, g.warnings)
+	return d, nil
+}
+
+// lookupType returns the type named name in pkgs, qualified by the import path
+// of its package or alone when no other package has a type of that name.
+func lookupType(pkgs []*loadedPackage, info *sourceInfo, name string) (goType, error) {
+	path, typeName := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		path, typeName = name[:i], name[i+1:]
+	}
+	var found []types.Type
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || path != "" && pkg.Path != path {
+			continue
+		}
+		if obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName); ok {
+			found = append(found, obj.Type())
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unknown type %s", name)
+	case 1:
+		return sourceType{found[0], info}, nil
+	}
+	return nil, fmt.Errorf("type %s is ambiguous, qualify it with the import path of its package", name)
+}
+
+// sourceDocs returns the doc comments of the types and fields declared in
+// pkgs, by the position of their names. Positions rather than objects are
+// used as the packages imported by others are type-checked again.
+func sourceDocs(pkgs []*loadedPackage) map[string]string {
+	docs := map[string]string{}
+	add := func(fset *token.FileSet, pos token.Pos, groups ...*ast.CommentGroup) {
+		for _, group := range groups {
+			if text := strings.TrimSpace(group.Text()); text != "" {
+				docs[fset.Position(pos).String()] = text
+				return
+			}
+		}
+	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.GenDecl:
+					if n.Tok == token.TYPE && len(n.Specs) == 1 && n.Doc != nil {
+						spec := n.Specs[0].(*ast.TypeSpec)
+						add(pkg.Fset, spec.Name.Pos(), spec.Doc, n.Doc)
+					}
+				case *ast.TypeSpec:
+					add(pkg.Fset, n.Name.Pos(), n.Doc, n.Comment)
+				case *ast.Field:
+					for _, name := range n.Names {
+						add(pkg.Fset, name.Pos(), n.Doc, n.Comment)
+					}
+					if len(n.Names) == 0 {
+						add(pkg.Fset, embeddedName(n.Type).Pos(), n.Doc, n.Comment)
+					}
+				}
+				return true
+			})
+		}
+	}
+	return docs
+}
+
+// embeddedName returns the identifier naming an embedded field.
+func embeddedName(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	}
+	return expr
+}
+
+// sourceInfo holds what the types read from source share.
+type sourceInfo struct {
+	// fset is the file set shared by the packages
+	fset *token.FileSet
+	docs map[string]string
+}
+
+// doc returns the doc comment of the type or field obj.
+func (s *sourceInfo) doc(obj types.Object) string {
+	return s.docs[s.fset.Position(obj.Pos()).String()]
+}
+
+// sourceType is a goType read from source.
+type sourceType struct {
+	types.Type
+	info *sourceInfo
+}
+
+// sourceKinds maps basic types to the kinds of their values.
+var sourceKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:    reflect.Bool,
+	types.Int:     reflect.Int,
+	types.Int8:    reflect.Int8,
+	types.Int16:   reflect.Int16,
+	types.Int32:   reflect.Int32,
+	types.Int64:   reflect.Int64,
+	types.Uint:    reflect.Uint,
+	types.Uint8:   reflect.Uint8,
+	types.Uint16:  reflect.Uint16,
+	types.Uint32:  reflect.Uint32,
+	types.Uint64:  reflect.Uint64,
+	types.Uintptr: reflect.Uintptr,
+	types.Float32: reflect.Float32,
+	types.Float64: reflect.Float64,
+	types.String:  reflect.String,
+}
+
+func (t sourceType) Kind() reflect.Kind {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return sourceKinds[u.Kind()]
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Array:
+		return reflect.Array
+	case *types.Map:
+		return reflect.Map
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Pointer:
+		return reflect.Ptr
+	case *types.Interface:
+		return reflect.Interface
+	}
+	return reflect.Invalid
+}
+
+func (t sourceType) Name() string {
+	if _, ok := t.Type.(*types.Named); !ok {
+		return ""
+	}
+	return types.TypeString(t.Type, nil)
+}
+
+func (t sourceType) String() string {
+	return types.TypeString(t.Type, nil)
+}
+
+func (t sourceType) Elem() goType {
+	var elem types.Type
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		elem = u.Elem()
+	case *types.Slice:
+		elem = u.Elem()
+	case *types.Array:
+		elem = u.Elem()
+	case *types.Map:
+		elem = u.Elem()
+	}
+	return sourceType{elem, t.info}
+}
+
+func (t sourceType) NumField() int {
+	return t.Underlying().(*types.Struct).NumFields()
+}
+
+func (t sourceType) Field(i int) goField {
+	s := t.Underlying().(*types.Struct)
+	field := s.Field(i)
+	return goField{
+		Name:     field.Name(),
+		Exported: field.Exported(),
+		Tag:      reflect.StructTag(s.Tag(i)),
+		Type:     sourceType{field.Type(), t.info},
+		Doc:      t.info.doc(field),
+	}
+}
+
+func (t sourceType) Doc() string {
+	named, ok := t.Type.(*types.Named)
+	if !ok {
+		return ""
+	}
+	return t.info.doc(named.Obj())
+}
+
+func (t sourceType) Instance() interface{} {
+	return nil
+}
+
+func (t sourceType) Key() interface{} {
+	return types.TypeString(t.Type, nil)
+}
@@ -0,0 +1,165 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type sourceSuite struct{}
+
+var _ = Suite(&sourceSuite{})
+
+// the types of testdata/source, for comparison with reflection
+type ExampleSourceOrder struct {
+	ID       string                 `json:"id" required:"true" minLength:"8"`
+	Customer *ExampleSourceCustomer `json:"customer"`
+	Lines    []ExampleSourceLine    `json:"lines" description:"The lines of the order."`
+	Created  time.Time              `json:"created"`
+	Note     *string                `json:"note,omitempty"`
+	Labels   map[string]string      `json:"labels"`
+	Data     []byte                 `json:"data" bytesFormat:"base64"`
+	internal int
+}
+
+type ExampleSourceCustomer struct {
+	Name  string `json:"name" required:"true"`
+	Email string `json:"email" pattern:"^.+@.+$"`
+}
+
+type ExampleSourceLine struct {
+	SKU      string  `json:"sku"`
+	Quantity uint8   `json:"quantity" min:"1"`
+	Price    float64 `json:"price"`
+}
+
+func (self *sourceSuite) TestSourceGenerator(c *C) {
+	options := Options{IntegerBounds: true}
+	j, err := NewSourceGenerator("./testdata/source", options).
+		WithRoot("Order").
+		WithDefinition("Customer", "github.com/naveego/go-json-schema/testdata/source.Customer").
+		Generate()
+	c.Assert(err, IsNil)
+
+	// doc comments describe the types and fields without description tag
+	c.Assert(j.Description, Equals, "Order is an order of a customer.")
+	c.Assert(j.Properties["id"].Description, Equals, "ID identifies the order.")
+	c.Assert(j.Properties["note"].Description, Equals, "Note is a note of the customer.")
+	c.Assert(j.Properties["lines"].Description, Equals, "The lines of the order.")
+	c.Assert(j.Definitions["Customer"].Description, Equals, "Customer is a customer of the shop.")
+
+	j.Description = ""
+	j.Properties["id"].Description = ""
+	j.Properties["note"].Description = ""
+	customer := j.Definitions["Customer"]
+	customer.Description = ""
+	j.Definitions["Customer"] = customer
+
+	reflected := NewGenerator(options).
+		WithRoot(&ExampleSourceOrder{}).
+		WithDefinition("Customer", ExampleSourceCustomer{}).
+		MustGenerate()
+	c.Assert(j.String(), Equals, reflected.String())
+}
+
+func (self *sourceSuite) TestSourceBuildTags(c *C) {
+	_, err := NewSourceGenerator("./testdata/source").WithRoot("Refund").Generate()
+	c.Assert(err, ErrorMatches, "unknown type Refund")
+
+	j, err := NewSourceGenerator("./testdata/source").WithBuildTags("admin").WithRoot("Refund").Generate()
+	c.Assert(err, IsNil)
+	c.Assert(sortedPropertyNames(j.Properties), DeepEquals, []string{"amount", "order"})
+	c.Assert(*j.Properties["amount"].ExclusiveMinimum, Equals, 0.0)
+}
+
+func (self *sourceSuite) TestSourceUnrollRecursion(c *C) {
+	j, err := NewSourceGenerator("./testdata/source", Options{UnrollRecursion: 1}).
+		WithRoot("Category").
+		WithDefinition("category", "Category").
+		Generate()
+	c.Assert(err, IsNil)
+	parent := j.Definitions["category"].Properties["parent"]
+	c.Assert(parent.Properties["name"].Type, Equals, "string")
+	c.Assert(parent.Properties["parent"], DeepEquals, &Property{Type: "object"})
+}
+
+func (self *sourceSuite) TestSourceOneOf(c *C) {
+	j, err := NewSourceGenerator("./testdata/source").
+		WithRoot("Event").
+		WithDefinition("customer", "Customer").
+		WithDefinition("line", "Line").
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.Properties["subject"].OneOf, DeepEquals, []*Property{
+		{Ref: "#/definitions/customer"},
+		{Ref: "#/definitions/line"},
+	})
+}
+
+func (self *sourceSuite) TestSourceReferencePrimitives(c *C) {
+	j, err := NewSourceGenerator("./testdata/source", Options{ReferencePrimitives: true}).
+		WithRoot("Stock").
+		WithDefinition("sku", "SKU").
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.Definitions["sku"].Type, Equals, "string")
+	c.Assert(j.Properties["sku"].Ref, Equals, "#/definitions/sku")
+	c.Assert(j.Properties["related"].Items, DeepEquals, &Property{Ref: "#/definitions/sku"})
+}
+
+func (self *sourceSuite) TestSourceDefineRecursiveTypes(c *C) {
+	j, err := NewSourceGenerator("./testdata/source", Options{DefineRecursiveTypes: true}).
+		WithRoot("Category").
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.Ref, Equals, "#/definitions/category")
+	c.Assert(j.Definitions["category"].Properties["parent"].Ref, Equals, "#/definitions/category")
+}
+
+func (self *sourceSuite) TestSourceDefinitions(c *C) {
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	j, err := NewSourceGenerator("./testdata/source").
+		WithRootUnion("Customer", "Line").
+		WithDefinitionAlias("client", "Customer").
+		WithDeprecatedDefinition("Line", sunset).
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.OneOf, DeepEquals, []*Property{
+		{Ref: "#/definitions/Customer"},
+		{Ref: "#/definitions/Line"},
+	})
+	c.Assert(j.Definitions["client"], DeepEquals, Property{Ref: "#/definitions/Customer"})
+	c.Assert(j.Definitions["Line"].Deprecated, Equals, true)
+	c.Assert(j.Definitions["Line"].Extensions[SunsetExtension], Equals, "2030-01-01")
+
+	_, err = NewSourceGenerator("./testdata/source").
+		WithRoot("Order").
+		WithDefinitionAlias("client", "Customer").
+		Generate()
+	c.Assert(err, ErrorMatches, "alias client refers to unknown definition Customer")
+}
+
+func (self *sourceSuite) TestSourceTypeTags(c *C) {
+	j, err := NewSourceGenerator("./testdata/source").
+		WithTypeTags("SKU", map[string]string{"pattern": "^[A-Z0-9-]+$"}).
+		WithRoot("Stock").
+		Generate()
+	c.Assert(err, IsNil)
+	c.Assert(j.Properties["sku"].Pattern, Equals, "^[A-Z0-9-]+$")
+
+	_, err = NewSourceGenerator("./testdata/source").
+		WithTypeTags("Unknown", map[string]string{"pattern": "^[A-Z0-9-]+$"}).
+		WithRoot("Stock").
+		Generate()
+	c.Assert(err, NotNil)
+}
+
+func (self *sourceSuite) TestSourceErrors(c *C) {
+	_, err := NewSourceGenerator("./testdata/source/...").WithRoot("Order").Generate()
+	c.Assert(err, ErrorMatches, "type Order is ambiguous, qualify it with the import path of its package")
+
+	_, err = NewSourceGenerator("./testdata/vet").WithRoot("Order").Generate()
+	c.Assert(err, ErrorMatches, `.*property:Created:"bytesFormat" tag on time.Time, which is not a byte slice`)
+}
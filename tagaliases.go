@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// resolveTagAliases returns tag with the tags named by Options.TagAliases
+// added under their canonical names, unless they are already set.
+func (r *reader) resolveTagAliases(tag reflect.StructTag) reflect.StructTag {
+	if len(r.options.TagAliases) == 0 {
+		return tag
+	}
+	resolved := tag
+	for _, kv := range structTagPairs(tag) {
+		name, ok := r.canonicalTagName(kv[0])
+		if !ok {
+			continue
+		}
+		if _, set := resolved.Lookup(name); set {
+			continue
+		}
+		resolved = reflect.StructTag(strings.TrimSpace(string(resolved) + " " + name + ":" + strconv.Quote(kv[1])))
+	}
+	return resolved
+}
+
+// canonicalTagName returns the name of the tag aliased by name. Aliases
+// ending with "*" match any tag starting with the rest of the alias, and the
+// "*" of the canonical name, if any, stands for the remainder of the tag name.
+func (r *reader) canonicalTagName(name string) (string, bool) {
+	if canonical, ok := r.options.TagAliases[name]; ok {
+		return canonical, true
+	}
+	aliases := make([]string, 0, len(r.options.TagAliases))
+	for alias := range r.options.TagAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		prefix := strings.TrimSuffix(alias, "*")
+		if prefix == alias || !strings.HasPrefix(name, prefix) || name == prefix {
+			continue
+		}
+		canonical := r.options.TagAliases[alias]
+		return strings.Replace(canonical, "*", strings.TrimPrefix(name, prefix), 1), true
+	}
+	return "", false
+}
+
+// structTagPairs returns the key and value of each tag of a struct tag in
+// the conventional format, in order.
+func structTagPairs(tag reflect.StructTag) [][2]string {
+	var pairs [][2]string
+	s := string(tag)
+	for s != "" {
+		// same parsing as reflect.StructTag.Lookup
+		i := 0
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		s = s[i:]
+		if s == "" {
+			break
+		}
+		i = 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			break
+		}
+		name := s[:i]
+		s = s[i+1:]
+
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		quoted := s[:i+1]
+		s = s[i+1:]
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+		pairs = append(pairs, [2]string{name, value})
+	}
+	return pairs
+}
+
+// tags read by the generator
+var generatorTags = map[string]bool{
+	"json": true, "required": true, "title": true, "description": true,
+	"markdownDescription": true, "extensions": true, "deprecated": true,
+	SunsetExtension: true, "sensitive": true, "classification": true,
+	"example": true, "schema": true, "schemaRef": true, "requiredWith": true,
+	"mutuallyExclusiveWith": true, "minLength": true, "maxLength": true,
+	"pattern": true, "enum": true, "const": true, "min": true, "max": true,
+	"exclusiveMin": true, "exclusiveMax": true, "multipleOf": true,
+	"bytesFormat": true, "values": true, "valuesType": true, "allowNaN": true,
+	"jsonapi": true, "links": true, "format": true, "preset": true,
+	"minItems": true, "maxItems": true, "oneOf": true, "types": true,
+	"flags": true, "timeFormat": true, "timeEncoding": true, "utc": true,
+}
+
+// tags of the validators of each type of values
+var (
+	stringTags = []string{"minLength", "maxLength", "pattern", "enum", "format"}
+	numberTags = []string{"min", "max", "exclusiveMin", "exclusiveMax", "multipleOf"}
+	arrayTags  = []string{"minItems", "maxItems"}
+)
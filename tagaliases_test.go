@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"reflect"
+
+	. "gopkg.in/check.v1"
+)
+
+type tagAliasesSuite struct{}
+
+var _ = Suite(&tagAliasesSuite{})
+
+type ExampleJSONAliasedTags struct {
+	meta  string `schema-title:"Person" schema-description:"A person."`
+	Name  string `json:"name" schema-title:"Name" schema-description:"The full name." schema-minLength:"1" schema-required:"true"`
+	Email string `json:"email" title:"E-mail" schema-title:"Email"`
+}
+
+func (self *tagAliasesSuite) TestTagAliases(c *C) {
+	j := NewGenerator(Options{TagAliases: map[string]string{
+		"schema-title":       "title",
+		"schema-description": "description",
+	}}).WithRoot(&ExampleJSONAliasedTags{}).MustGenerate()
+
+	c.Assert(j.Title, Equals, "Person")
+	c.Assert(j.Description, Equals, "A person.")
+	c.Assert(j.Properties["name"].Title, Equals, "Name")
+	c.Assert(j.Properties["name"].Description, Equals, "The full name.")
+	c.Assert(j.Properties["name"].MinLength, IsNil)
+	// tags take precedence over their aliases
+	c.Assert(j.Properties["email"].Title, Equals, "E-mail")
+
+	j = NewGenerator().WithRoot(&ExampleJSONAliasedTags{}).MustGenerate()
+	c.Assert(j.Properties["name"].Title, Equals, "")
+}
+
+func (self *tagAliasesSuite) TestNamespacedTagAliases(c *C) {
+	j := NewGenerator(Options{TagAliases: map[string]string{
+		"schema-*": "*",
+	}}).WithRoot(&ExampleJSONAliasedTags{}).MustGenerate()
+
+	c.Assert(j.Title, Equals, "Person")
+	c.Assert(j.Properties["name"].Title, Equals, "Name")
+	c.Assert(*j.Properties["name"].MinLength, Equals, int64(1))
+	c.Assert(j.Required, DeepEquals, []string{"name"})
+	c.Assert(j.Properties["email"].Title, Equals, "E-mail")
+}
+
+func (self *tagAliasesSuite) TestStructTagPairs(c *C) {
+	c.Assert(structTagPairs(reflect.StructTag(`json:"a,omitempty"  title:"Say \"hi\"" bad`)), DeepEquals, [][2]string{
+		{"json", "a,omitempty"},
+		{"title", `Say "hi"`},
+	})
+}
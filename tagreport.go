@@ -0,0 +1,242 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TagSuggestion is a change to the struct tags of a field which brings the
+// schema generated for a struct closer to a hand-written schema.
+type TagSuggestion struct {
+	// Field is the path of the Go field, e.g. "Domain.NestedItem.Value".
+	// Suggestions for the struct itself, such as its title, apply to an
+	// unexported field of the struct and have the path of the struct.
+	Field string
+	// Property is the JSON pointer of the property in the schema.
+	Property string
+	// Tag is the name of the tag to set or remove. It is empty for
+	// differences which cannot be fixed with tags, explained by Message.
+	Tag string
+	// Value is the value to set the tag to.
+	Value string
+	// Remove is set when the tag should be removed.
+	Remove  bool
+	Message string
+}
+
+func (s TagSuggestion) String() string {
+	switch {
+	case s.Tag == "":
+		return fmt.Sprintf("%s: %s", s.Field, s.Message)
+	case s.Remove:
+		return fmt.Sprintf("%s: remove tag %s", s.Field, s.Tag)
+	}
+	return fmt.Sprintf("%s: set tag %s:%q", s.Field, s.Tag, s.Value)
+}
+
+// SuggestTags compares the schema generated for instance with a hand-written
+// schema, and reports which tags to add, change or remove on which fields to
+// make the generated schema match it, easing the migration of schema-first
+// projects to generated schemas.
+func SuggestTags(instance interface{}, schema []byte) ([]TagSuggestion, error) {
+	var expected JSONSchema
+	if err := json.Unmarshal(schema, &expected); err != nil {
+		return nil, fmt.Errorf("invalid schema: %s", err)
+	}
+	generated, err := NewGenerator().WithRoot(instance).Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(instance)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	r := &tagReporter{expected: &expected, visiting: map[reflect.Type]bool{}}
+	r.compare(t.Name(), "", t, &generated.Property, &expected.Property)
+	return r.suggestions, nil
+}
+
+type tagReporter struct {
+	expected    *JSONSchema
+	visiting    map[reflect.Type]bool
+	suggestions []TagSuggestion
+}
+
+// tagKeywords maps tags to the keyword they set, formatted like the tag value.
+var tagKeywords = []struct {
+	tag   string
+	value func(p *Property) string
+}{
+	{"title", func(p *Property) string { return p.Title }},
+	{"description", func(p *Property) string { return p.Description }},
+	{"deprecated", func(p *Property) string { return formatTagBool(p.Deprecated) }},
+	{"minLength", func(p *Property) string { return formatTagInt(p.MinLength) }},
+	{"maxLength", func(p *Property) string { return formatTagInt(p.MaxLength) }},
+	{"minItems", func(p *Property) string { return formatTagInt(p.MinItems) }},
+	{"maxItems", func(p *Property) string { return formatTagInt(p.MaxItems) }},
+	{"pattern", func(p *Property) string { return p.Pattern }},
+	{"enum", func(p *Property) string { return joinEnum(p.Enum) }},
+	{"multipleOf", func(p *Property) string { return formatTagFloat(p.MultipleOf) }},
+	{"min", func(p *Property) string { return formatTagFloat(p.Minimum) }},
+	{"max", func(p *Property) string { return formatTagFloat(p.Maximum) }},
+	{"exclusiveMin", func(p *Property) string { return formatTagFloat(p.ExclusiveMinimum) }},
+	{"exclusiveMax", func(p *Property) string { return formatTagFloat(p.ExclusiveMaximum) }},
+	{"const", func(p *Property) string {
+		if p.Const == nil {
+			return ""
+		}
+		return fmt.Sprint(p.Const)
+	}},
+}
+
+func formatTagBool(b bool) string {
+	if !b {
+		return ""
+	}
+	return "true"
+}
+
+func formatTagInt(i *int64) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.FormatInt(*i, 10)
+}
+
+func formatTagFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func (r *tagReporter) add(s TagSuggestion) {
+	if s.Property == "" {
+		s.Property = "/"
+	}
+	r.suggestions = append(r.suggestions, s)
+}
+
+// compare compares the keywords of the generated and expected schemas of a
+// value of type t, then recurses into the fields of structs.
+func (r *tagReporter) compare(field, pointer string, t reflect.Type, generated, expected *Property) {
+	expected = r.expected.resolve(expected)
+
+	if gt, et := effectiveType(generated), effectiveType(expected); gt != "" && et != "" && gt != et {
+		r.add(TagSuggestion{Field: field, Property: pointer,
+			Message: fmt.Sprintf("type %s is generated, but the schema expects %s", gt, et)})
+		return
+	}
+
+	for _, k := range tagKeywords {
+		g, e := k.value(generated), k.value(expected)
+		if g == e {
+			continue
+		}
+		if e == "" {
+			r.add(TagSuggestion{Field: field, Property: pointer, Tag: k.tag, Remove: true})
+		} else {
+			r.add(TagSuggestion{Field: field, Property: pointer, Tag: k.tag, Value: e})
+		}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if generated.Items != nil && expected.Items != nil {
+			r.compare(field, pointer+"/items", t.Elem(), generated.Items, expected.Items)
+		}
+	case reflect.Struct:
+		if r.visiting[t] || generated.Properties == nil {
+			return
+		}
+		r.visiting[t] = true
+		defer delete(r.visiting, t)
+		r.compareFields(field, pointer, t, generated, expected)
+	}
+}
+
+func (r *tagReporter) compareFields(field, pointer string, t reflect.Type, generated, expected *Property) {
+	matched := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _ := parseTag(f.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fieldPath := field + "." + f.Name
+		gp := generated.Properties[name]
+		if gp == nil {
+			gp = &Property{}
+		}
+		generatedRequired := containsString(generated.Required, name)
+
+		ep, ok := expected.Properties[name]
+		if !ok {
+			// the property may have been renamed
+			for _, candidate := range sortedPropertyNames(expected.Properties) {
+				if strings.EqualFold(candidate, name) && generated.Properties[candidate] == nil {
+					r.add(TagSuggestion{Field: fieldPath, Property: pointer + "/properties/" + escapePointer(name),
+						Tag: "json", Value: candidate})
+					name, ep, ok = candidate, expected.Properties[candidate], true
+					break
+				}
+			}
+		}
+		if !ok {
+			r.add(TagSuggestion{Field: fieldPath, Property: pointer + "/properties/" + escapePointer(name),
+				Message: "the schema has no such property"})
+			continue
+		}
+		matched[name] = true
+
+		propertyPointer := pointer + "/properties/" + escapePointer(name)
+		expectedRequired := containsString(expected.Required, name)
+		switch {
+		case expectedRequired && !generatedRequired:
+			r.add(TagSuggestion{Field: fieldPath, Property: propertyPointer, Tag: "required", Value: "true"})
+		case !expectedRequired && generatedRequired:
+			r.add(TagSuggestion{Field: fieldPath, Property: propertyPointer, Tag: "required", Remove: true})
+		}
+
+		r.compare(fieldPath, propertyPointer, f.Type, gp, ep)
+	}
+
+	var missing []string
+	for name := range expected.Properties {
+		if !matched[name] && name != ".*" {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		r.add(TagSuggestion{Field: field, Property: pointer + "/properties/" + escapePointer(name),
+			Message: fmt.Sprintf("no field for property %s", name)})
+	}
+}
+
+// effectiveType returns the type of p, ignoring the null branch emitted for pointers.
+func effectiveType(p *Property) string {
+	if p.Type != "" {
+		return p.Type
+	}
+	for _, s := range p.AnyOf {
+		if s.Type != "" && s.Type != "null" {
+			return s.Type
+		}
+	}
+	return ""
+}
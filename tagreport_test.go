@@ -0,0 +1,71 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type tagReportSuite struct{}
+
+var _ = Suite(&tagReportSuite{})
+
+type ExampleJSONLegacyUser struct {
+	Name    string             `json:"name" maxLength:"10" required:"true"`
+	Email   string             `json:"Email"`
+	Age     int                `json:"age"`
+	Score   string             `json:"score"`
+	Address ExampleJSONAddress `json:"address"`
+	Extra   bool               `json:"extra"`
+}
+
+func (self *tagReportSuite) TestSuggestTags(c *C) {
+	schema := []byte(`{
+		"type": "object",
+		"title": "User",
+		"properties": {
+			"name": {"type": "string", "maxLength": 20, "pattern": "^[a-z]+$"},
+			"email": {"type": "string", "description": "Contact address."},
+			"age": {"type": "integer", "minimum": 0, "maximum": 150},
+			"score": {"type": "number"},
+			"address": {"$ref": "#/definitions/address"},
+			"nickname": {"type": "string"}
+		},
+		"required": ["email"],
+		"definitions": {
+			"address": {
+				"type": "object",
+				"properties": {
+					"street": {"type": "string", "minLength": 1},
+					"zip": {"type": "string"}
+				}
+			}
+		}
+	}`)
+
+	suggestions, err := SuggestTags(&ExampleJSONLegacyUser{}, schema)
+	c.Assert(err, IsNil)
+
+	var lines []string
+	for _, s := range suggestions {
+		lines = append(lines, s.String())
+	}
+	c.Assert(lines, DeepEquals, []string{
+		`ExampleJSONLegacyUser: set tag title:"User"`,
+		`ExampleJSONLegacyUser.Name: remove tag required`,
+		`ExampleJSONLegacyUser.Name: set tag maxLength:"20"`,
+		`ExampleJSONLegacyUser.Name: set tag pattern:"^[a-z]+$"`,
+		`ExampleJSONLegacyUser.Email: set tag json:"email"`,
+		`ExampleJSONLegacyUser.Email: set tag required:"true"`,
+		`ExampleJSONLegacyUser.Email: set tag description:"Contact address."`,
+		`ExampleJSONLegacyUser.Age: set tag min:"0"`,
+		`ExampleJSONLegacyUser.Age: set tag max:"150"`,
+		`ExampleJSONLegacyUser.Score: type string is generated, but the schema expects number`,
+		`ExampleJSONLegacyUser.Address.Street: set tag minLength:"1"`,
+		`ExampleJSONLegacyUser.Extra: the schema has no such property`,
+		`ExampleJSONLegacyUser: no field for property nickname`,
+	})
+	c.Assert(suggestions[4].Property, Equals, "/properties/Email")
+	c.Assert(suggestions[5].Property, Equals, "/properties/email")
+
+	_, err = SuggestTags(&ExampleJSONLegacyUser{}, []byte("{"))
+	c.Assert(err, ErrorMatches, "invalid schema: .*")
+}
@@ -0,0 +1,9 @@
+//go:build admin
+
+package shop
+
+// Refund is a refund of an order, only built into the admin tool.
+type Refund struct {
+	Order  Order   `json:"order"`
+	Amount float64 `json:"amount" exclusiveMin:"0"`
+}
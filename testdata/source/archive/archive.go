@@ -0,0 +1,6 @@
+package archive
+
+// Order is an order archived, named like the orders of the shop.
+type Order struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,50 @@
+// Package shop declares types described from source.
+package shop
+
+import "time"
+
+// Order is an order of a customer.
+type Order struct {
+	// ID identifies the order.
+	ID       string            `json:"id" required:"true" minLength:"8"`
+	Customer *Customer         `json:"customer"`
+	Lines    []Line            `json:"lines" description:"The lines of the order."`
+	Created  time.Time         `json:"created"`
+	Note     *string           `json:"note,omitempty"` // Note is a note of the customer.
+	Labels   map[string]string `json:"labels"`
+	Data     []byte            `json:"data" bytesFormat:"base64"`
+	internal int
+}
+
+// Customer is a customer of the shop.
+type Customer struct {
+	Name  string `json:"name" required:"true"`
+	Email string `json:"email" pattern:"^.+@.+$"`
+}
+
+type Line struct {
+	SKU      string  `json:"sku"`
+	Quantity uint8   `json:"quantity" min:"1"`
+	Price    float64 `json:"price"`
+}
+
+// Category is a category of products, within its parent.
+type Category struct {
+	Name   string    `json:"name"`
+	Parent *Category `json:"parent"`
+}
+
+// Event is an event about a customer or a line.
+type Event struct {
+	Subject interface{} `json:"subject" oneOf:"customer|line"`
+}
+
+// SKU identifies a product.
+type SKU string
+
+// Stock is the stock of a product, with related products.
+type Stock struct {
+	SKU      SKU   `json:"sku"`
+	Related  []SKU `json:"related"`
+	Quantity int   `json:"quantity"`
+}
@@ -0,0 +1,33 @@
+package vet
+
+import "time"
+
+type Order struct {
+	meta     string    `title:"Order" minLength:"1"`
+	ID       string    `json:"id" minLenght:"8" maxLength:"eight"`
+	Quantity int       `json:"quantity" min:"10" max:"1" pattern:"^[0-9]+$"`
+	Comment  string    `json:"comment,omitempty" required:"true" Description:"A comment."`
+	Card     string    `json:"card" requiredWith:"iban"`
+	Created  time.Time `json:"created" example:"yesterday" bytesFormat:"hex"`
+	Tags     []string  `json:"tags" yaml:"tags" deprecated:"yes"`
+	Lines    []struct {
+		SKU string `json:"sku" enum:"a|b" const:"c"`
+	} `json:"lines"`
+	Phone   string    `json:"phone" pattern:"@phone"`
+	Payload string    `json:"payload" oneOf:"card"`
+	Ref     any       `json:"ref" types:"string|text"`
+	Mode    int       `json:"mode" flags:"read"`
+	Shipped string    `json:"shipped" timeFormat:"date"`
+	Paid    time.Time `json:"paid" timeEncoding:"epoch"`
+	Closed  time.Time `json:"closed" utc:"yes"`
+}
+
+type Valid struct {
+	Name  string            `json:"name" minLength:"1" maxLength:"10" required:"true"`
+	Data  []byte            `json:"data" bytesFormat:"hex"`
+	Price float64           `json:"price" min:"0" example:"9.99"`
+	Extra map[string]string `json:"extra" valuesType:"string"`
+	Slug  string            `json:"slug" pattern:"@slug"`
+	Ref   any               `json:"ref" types:"string|integer"`
+	Born  time.Time         `json:"born" timeFormat:"2006-01-02"`
+}
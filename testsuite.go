@@ -0,0 +1,141 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// SuiteResult is the outcome of a test of the JSON-Schema-Test-Suite.
+type SuiteResult struct {
+	// Keyword is the keyword tested, after the name of the file of the test,
+	// e.g. "minLength".
+	Keyword string
+	// Case and Test are the descriptions of the schema and of the document
+	// validated.
+	Case string
+	Test string
+	// Valid is whether the document is valid according to the suite.
+	Valid bool
+	// Passed is whether the validator agrees with the suite.
+	Passed bool
+	// Skipped is the reason why the test wasn't run, e.g. a schema which
+	// can't be parsed into a JSONSchema.
+	Skipped string
+}
+
+// SuiteReport holds the results of the tests of a draft of the
+// JSON-Schema-Test-Suite, in the order of the suite.
+type SuiteReport struct {
+	Draft   string
+	Results []SuiteResult
+}
+
+// Count returns the numbers of tests passed, failed and skipped.
+func (r *SuiteReport) Count() (passed, failed, skipped int) {
+	for _, result := range r.Results {
+		switch {
+		case result.Skipped != "":
+			skipped++
+		case result.Passed:
+			passed++
+		default:
+			failed++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// Failures returns the results of the tests failed.
+func (r *SuiteReport) Failures() []SuiteResult {
+	var failures []SuiteResult
+	for _, result := range r.Results {
+		if result.Skipped == "" && !result.Passed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+func (r SuiteResult) String() string {
+	return fmt.Sprintf("%s: %s: %s", r.Keyword, r.Case, r.Test)
+}
+
+// suiteCase is a schema of the JSON-Schema-Test-Suite, with the documents
+// validated against it.
+type suiteCase struct {
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+	Tests       []struct {
+		Description string          `json:"description"`
+		Data        json.RawMessage `json:"data"`
+		Valid       bool            `json:"valid"`
+	} `json:"tests"`
+}
+
+// RunTestSuite parses the schemas of the tests of a draft of the official
+// JSON-Schema-Test-Suite and validates their documents, to report which
+// keywords are supported. fsys holds the tests directory of the suite, and
+// draft names one of its directories, e.g. "draft7" or "draft2020-12". The
+// optional tests aren't run.
+//
+//	report, err := jsonschema.RunTestSuite(os.DirFS("JSON-Schema-Test-Suite/tests"), "draft7")
+func RunTestSuite(fsys fs.FS, draft string) (*SuiteReport, error) {
+	files, err := fs.Glob(fsys, path.Join(draft, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no tests of %s", draft)
+	}
+	report := &SuiteReport{Draft: draft}
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var cases []suiteCase
+		if err := json.Unmarshal(b, &cases); err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		keyword := strings.TrimSuffix(path.Base(file), ".json")
+		for _, c := range cases {
+			report.Results = append(report.Results, runSuiteCase(keyword, c)...)
+		}
+	}
+	return report, nil
+}
+
+// runSuiteCase validates the documents of a case of the suite.
+func runSuiteCase(keyword string, c suiteCase) []SuiteResult {
+	results := make([]SuiteResult, len(c.Tests))
+	for i, test := range c.Tests {
+		results[i] = SuiteResult{Keyword: keyword, Case: c.Description, Test: test.Description, Valid: test.Valid}
+	}
+	skip := func(reason string) []SuiteResult {
+		for i := range results {
+			results[i].Skipped = reason
+		}
+		return results
+	}
+
+	var schema JSONSchema
+	if err := json.Unmarshal(c.Schema, &schema); err != nil {
+		return skip(fmt.Sprintf("unsupported schema: %s", err))
+	}
+	v, err := NewValidator(&schema)
+	if err != nil {
+		return skip(fmt.Sprintf("unsupported schema: %s", err))
+	}
+	for i, test := range c.Tests {
+		errs, err := v.Validate(test.Data)
+		if err != nil {
+			results[i].Skipped = err.Error()
+			continue
+		}
+		results[i].Passed = (len(errs) == 0) == test.Valid
+	}
+	return results
+}
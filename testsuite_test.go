@@ -0,0 +1,84 @@
+package jsonschema
+
+import (
+	"os"
+
+	. "gopkg.in/check.v1"
+)
+
+type testSuiteSuite struct{}
+
+var _ = Suite(&testSuiteSuite{})
+
+func (self *testSuiteSuite) TestRunTestSuite(c *C) {
+	report, err := RunTestSuite(os.DirFS("testdata/suite"), "draft7")
+	c.Assert(err, IsNil)
+	c.Assert(report.Draft, Equals, "draft7")
+	passed, failed, skipped := report.Count()
+	c.Assert([]int{passed, failed, skipped}, DeepEquals, []int{19, 1, 1})
+	c.Assert(report.Failures(), DeepEquals, []SuiteResult{
+		{Keyword: "uniqueItems", Case: "uniqueItems validation", Test: "non-unique array is invalid", Valid: false},
+	})
+
+	_, err = RunTestSuite(os.DirFS("testdata/suite"), "draft4")
+	c.Assert(err, ErrorMatches, "no tests of draft4")
+}
+
+func (self *testSuiteSuite) TestConformanceReport(c *C) {
+	report, err := ConformanceReport(Draft07)
+	c.Assert(err, IsNil)
+	support := map[string]KeywordSupport{}
+	for _, k := range report {
+		support[k.Keyword] = k
+	}
+	c.Assert(support["minLength"], DeepEquals, KeywordSupport{Keyword: "minLength", Generated: true, Validated: true})
+	c.Assert(support["multipleOf"].Validated, Equals, true)
+	c.Assert(support["dependencies"].Validated, Equals, true)
+	c.Assert(support["uniqueItems"].Validated, Equals, false)
+	// the siblings of $ref apply, against the tests of draft-07
+	c.Assert(support["$ref"].Validated, Equals, false)
+	// the definitions are only tested against the meta-schema
+	c.Assert(support["definitions"].Validated, Equals, false)
+	_, ok := support["dependentRequired"]
+	c.Assert(ok, Equals, false)
+
+	report, err = ConformanceReport(Draft04)
+	c.Assert(err, IsNil)
+	for _, k := range report {
+		if k.Keyword == "exclusiveMinimum" {
+			c.Assert(k.Validated, Equals, true)
+		}
+	}
+
+	report, err = ConformanceReport(Draft202012)
+	c.Assert(err, IsNil)
+	c.Assert(report[0].Keyword, Equals, "$defs")
+	for _, k := range report {
+		switch k.Keyword {
+		case "dependentRequired", "dependentSchemas", "const", "enum":
+			c.Check(k.Validated, Equals, k.Keyword != "const", Commentf("%s", k.Keyword))
+		case "prefixItems":
+			c.Check(k.Validated, Equals, false)
+		}
+	}
+	_, err = ConformanceReport("draft-99")
+	c.Assert(err, ErrorMatches, `unknown draft "draft-99"`)
+}
+
+// TestOfficialTestSuite runs the JSON-Schema-Test-Suite checked out at
+// $JSON_SCHEMA_TEST_SUITE, logging the tests failed.
+func (self *testSuiteSuite) TestOfficialTestSuite(c *C) {
+	dir := os.Getenv("JSON_SCHEMA_TEST_SUITE")
+	if dir == "" {
+		c.Skip("JSON_SCHEMA_TEST_SUITE isn't set")
+	}
+	for _, draft := range []string{"draft4", "draft6", "draft7", "draft2019-09", "draft2020-12"} {
+		report, err := RunTestSuite(os.DirFS(dir+"/tests"), draft)
+		c.Assert(err, IsNil)
+		passed, failed, skipped := report.Count()
+		c.Logf("%s: %d passed, %d failed, %d skipped", draft, passed, failed, skipped)
+		for _, failure := range report.Failures() {
+			c.Logf("%s: %s", draft, failure)
+		}
+	}
+}
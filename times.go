@@ -0,0 +1,231 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TimeEncodingExtension is the extension keyword naming the encoding of the
+// times represented as integer epochs.
+const TimeEncodingExtension = "x-time-encoding"
+
+// TimeEncoding is the representation of the times marshaled by a type.
+type TimeEncoding string
+
+const (
+	// TimeRFC3339 represents times as RFC 3339 date-time strings, like
+	// encoding/json does.
+	TimeRFC3339 TimeEncoding = "rfc3339"
+	// TimeUnix represents times as integer numbers of seconds since the
+	// Unix epoch.
+	TimeUnix TimeEncoding = "unix"
+	// TimeUnixMilli represents times as integer numbers of milliseconds
+	// since the Unix epoch.
+	TimeUnixMilli TimeEncoding = "unixMilli"
+)
+
+// setTimeEncoding describes the times of n in the encoding.
+func (n *Node) setTimeEncoding(encoding TimeEncoding) error {
+	p := n.Schema
+	switch encoding {
+	case TimeRFC3339:
+		n.Kind, p.Format = KindString, "date-time"
+		delete(p.Extensions, TimeEncodingExtension)
+		if len(p.Extensions) == 0 {
+			p.Extensions = nil
+		}
+	case TimeUnix, TimeUnixMilli:
+		n.Kind, p.Format, p.Pattern = KindInteger, "", ""
+		if p.Extensions == nil {
+			p.Extensions = map[string]interface{}{}
+		}
+		p.Extensions[TimeEncodingExtension] = string(encoding)
+	default:
+		return fmt.Errorf("unknown time encoding %q", encoding)
+	}
+	n.verbatim = false
+	return nil
+}
+
+// isTimeProperty reports whether p describes times, in any encoding.
+func isTimeProperty(p *Property) bool {
+	_, ok := p.Extensions[TimeEncodingExtension]
+	return p.Format == "date-time" || ok
+}
+
+// readTime describes the times of a date-time node in the encoding set by
+// Options.TimeEncoding, in UTC if Options.RequireUTC is set.
+func (r *reader) readTime(n *Node) error {
+	if n.Schema.Format != "date-time" {
+		return nil
+	}
+	if r.options.TimeEncoding != "" {
+		if err := n.setTimeEncoding(r.options.TimeEncoding); err != nil {
+			return err
+		}
+	}
+	if r.options.RequireUTC && n.Schema.Format == "date-time" {
+		n.Schema.Pattern = PatternRFC3339UTC
+	}
+	return nil
+}
+
+// addUTCFromTags requires the date-times of a property to be in UTC, or not,
+// by the utc tag, e.g. `utc:"true"`.
+func (p *Property) addUTCFromTags(tag *reflect.StructTag) error {
+	raw, ok := tag.Lookup("utc")
+	if !ok {
+		return nil
+	}
+	utc, err := strconv.ParseBool(raw)
+	if err != nil {
+		return fmt.Errorf(`invalid "utc" tag value %q: %s`, raw, err)
+	}
+	if p.Format != "date-time" {
+		return fmt.Errorf(`"utc" tag on a property which isn't a date-time`)
+	}
+	if utc {
+		p.Pattern = PatternRFC3339UTC
+	} else if p.Pattern == PatternRFC3339UTC {
+		p.Pattern = ""
+	}
+	return nil
+}
+
+// addTimeEncodingFromTags describes the times of a property by the
+// timeEncoding tag, for types marshaling them as Unix epochs.
+func (n *Node) addTimeEncodingFromTags(tag *reflect.StructTag) error {
+	encoding, ok := tag.Lookup("timeEncoding")
+	if !ok {
+		return nil
+	}
+	if !isTimeProperty(n.Schema) {
+		return fmt.Errorf(`"timeEncoding" tag on a property which isn't a time`)
+	}
+	if err := n.setTimeEncoding(TimeEncoding(encoding)); err != nil {
+		return fmt.Errorf(`invalid "timeEncoding" tag value: %s`, err)
+	}
+	return nil
+}
+
+// The elements of the Go time layouts, longest first, with the patterns of
+// the values they format.
+var layoutElements = []struct {
+	element, pattern string
+}{
+	{"January", "[A-Z][a-z]+"},
+	{"Monday", "[A-Z][a-z]+"},
+	{"Z07:00:00", "(Z|[+-][0-9]{2}:[0-9]{2}:[0-9]{2})"},
+	{"-07:00:00", "[+-][0-9]{2}:[0-9]{2}:[0-9]{2}"},
+	{"Z070000", "(Z|[+-][0-9]{6})"},
+	{"-070000", "[+-][0-9]{6}"},
+	{"Z07:00", "(Z|[+-][0-9]{2}:[0-9]{2})"},
+	{"-07:00", "[+-][0-9]{2}:[0-9]{2}"},
+	{"Z0700", "(Z|[+-][0-9]{4})"},
+	{"-0700", "[+-][0-9]{4}"},
+	{"2006", "[0-9]{4}"},
+	{"Z07", "(Z|[+-][0-9]{2})"},
+	{"-07", "[+-][0-9]{2}"},
+	{"Jan", "[A-Z][a-z]{2}"},
+	{"Mon", "[A-Z][a-z]{2}"},
+	{"MST", "[A-Z]{3,5}"},
+	{"002", "[0-9]{3}"},
+	{"__2", "[ 0-9]{2}[0-9]"},
+	{"_2", "[ 0-9][0-9]"},
+	{"01", "[0-9]{2}"},
+	{"02", "[0-9]{2}"},
+	{"03", "[0-9]{2}"},
+	{"04", "[0-9]{2}"},
+	{"05", "[0-9]{2}"},
+	{"06", "[0-9]{2}"},
+	{"15", "[0-9]{2}"},
+	{"PM", "(AM|PM)"},
+	{"pm", "(am|pm)"},
+	{"1", "[0-9]{1,2}"},
+	{"2", "[0-9]{1,2}"},
+	{"3", "[0-9]{1,2}"},
+	{"4", "[0-9]{1,2}"},
+	{"5", "[0-9]{1,2}"},
+}
+
+// fractionalSeconds matches the fractional seconds of a layout: a fixed
+// number of digits with zeros, at most that number with nines.
+var fractionalSeconds = regexp.MustCompile(`^[.,](0+|9+)`)
+
+// layoutPattern returns the pattern of the values formatted by the Go time
+// layout.
+func layoutPattern(layout string) (string, error) {
+	var pattern strings.Builder
+	elements := 0
+	for len(layout) > 0 {
+		if m := fractionalSeconds.FindString(layout); m != "" && !startsWithDigit(layout[len(m):]) {
+			separator := regexp.QuoteMeta(m[:1])
+			if m[1] == '0' {
+				fmt.Fprintf(&pattern, "%s[0-9]{%d}", separator, len(m)-1)
+			} else {
+				fmt.Fprintf(&pattern, "(%s[0-9]{1,%d})?", separator, len(m)-1)
+			}
+			layout = layout[len(m):]
+			elements++
+			continue
+		}
+		matched := false
+		for _, e := range layoutElements {
+			if strings.HasPrefix(layout, e.element) {
+				pattern.WriteString(e.pattern)
+				layout = layout[len(e.element):]
+				elements++
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			pattern.WriteString(regexp.QuoteMeta(layout[:1]))
+			layout = layout[1:]
+		}
+	}
+	if elements == 0 {
+		return "", fmt.Errorf("no element of a time layout")
+	}
+	return "^" + pattern.String() + "$", nil
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// setTimeFormat describes the strings formatting times with the format:
+// "date-time", "date", "time", or a Go time layout, e.g. "02/01/2006".
+func (p *Property) setTimeFormat(format string) error {
+	switch format {
+	case "date-time", "date", "time":
+		p.Format, p.Pattern = format, ""
+		return nil
+	}
+	pattern, err := layoutPattern(format)
+	if err != nil {
+		return err
+	}
+	p.Format, p.Pattern = "", pattern
+	return nil
+}
+
+// addTimeFormatFromTags describes the format of the times of a date-time
+// property by the timeFormat tag, for types marshaling them as dates, times
+// of day or in a layout of their own.
+func (p *Property) addTimeFormatFromTags(tag *reflect.StructTag) error {
+	format, ok := tag.Lookup("timeFormat")
+	if !ok {
+		return nil
+	}
+	if p.Format != "date-time" {
+		return fmt.Errorf(`"timeFormat" tag on a property which isn't a date-time`)
+	}
+	if err := p.setTimeFormat(format); err != nil {
+		return fmt.Errorf(`invalid "timeFormat" tag value %q: %s`, format, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package jsonschema
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type timesSuite struct{}
+
+var _ = Suite(&timesSuite{})
+
+type ExampleJSONReservation struct {
+	Created  time.Time  `json:"created"`
+	Arrival  time.Time  `json:"arrival" timeFormat:"date"`
+	CheckIn  *time.Time `json:"checkIn" timeFormat:"time"`
+	Departed time.Time  `json:"departed" timeFormat:"02/01/2006 15:04:05.000 Z07:00"`
+}
+
+type ExampleJSONInvalidTimeFormat struct {
+	Arrival string `json:"arrival" timeFormat:"date"`
+}
+
+func (self *timesSuite) TestTimeFormatTag(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONReservation{}).MustGenerate()
+	c.Assert(j.Properties["created"].Format, Equals, "date-time")
+	c.Assert(j.Properties["arrival"].Format, Equals, "date")
+	c.Assert(j.Properties["checkIn"].Format, Equals, "time")
+	departed := j.Properties["departed"]
+	c.Assert(departed.Format, Equals, "")
+	c.Assert(departed.Pattern, Equals, `^[0-9]{2}/[0-9]{2}/[0-9]{4} [0-9]{2}:[0-9]{2}:[0-9]{2}\.[0-9]{3} (Z|[+-][0-9]{2}:[0-9]{2})$`)
+
+	errs, err := j.Validate([]byte(`{"departed":"31/12/2024 23:59:58.123 +01:00"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	errs, err = j.Validate([]byte(`{"departed":"2024-12-31T23:59:58Z"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONInvalidTimeFormat{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Arrival:"timeFormat" tag on a property which isn't a date-time`)
+}
+
+type ExampleJSONSettlement struct {
+	Paid     time.Time            `json:"paid" timeEncoding:"unix"`
+	Settled  time.Time            `json:"settled" timeEncoding:"unixMilli"`
+	Created  time.Time            `json:"created" timeEncoding:"rfc3339"`
+	Reminded []time.Time          `json:"reminded"`
+	Refunds  map[string]time.Time `json:"refunds"`
+}
+
+type ExampleJSONInvalidTimeEncoding struct {
+	Paid int64 `json:"paid" timeEncoding:"unix"`
+}
+
+func (self *timesSuite) TestTimeEncodingTag(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONSettlement{}).MustGenerate()
+	c.Assert(j.Properties["paid"], DeepEquals, &Property{
+		Type:       "integer",
+		Extensions: map[string]interface{}{TimeEncodingExtension: "unix"},
+	})
+	c.Assert(j.Properties["settled"].Extensions[TimeEncodingExtension], Equals, "unixMilli")
+	c.Assert(j.Properties["created"], DeepEquals, &Property{Type: "string", Format: "date-time"})
+	c.Assert(j.Properties["reminded"].Items, DeepEquals, &Property{Type: "string", Format: "date-time"})
+
+	j = NewGenerator(Options{TimeEncoding: TimeUnix}).WithRoot(&ExampleJSONSettlement{}).MustGenerate()
+	c.Assert(j.Properties["settled"].Extensions[TimeEncodingExtension], Equals, "unixMilli")
+	c.Assert(j.Properties["created"], DeepEquals, &Property{Type: "string", Format: "date-time"})
+	c.Assert(j.Properties["reminded"].Items.Type, Equals, "integer")
+	c.Assert(j.Properties["refunds"].Properties[".*"].Type, Equals, "integer")
+	errs, err := j.Validate([]byte(`{"reminded":[1700000000]}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	_, err = NewGenerator().WithRoot(&ExampleJSONInvalidTimeEncoding{}).Generate()
+	c.Assert(err, ErrorMatches, `.*property:Paid:"timeEncoding" tag on a property which isn't a time`)
+	_, err = NewGenerator(Options{TimeEncoding: "epoch"}).WithRoot(&ExampleJSONSettlement{}).Generate()
+	c.Assert(err, ErrorMatches, `.*unknown time encoding "epoch"`)
+}
+
+type ExampleJSONDelivery struct {
+	Shipped   time.Time  `json:"shipped"`
+	Delivered *time.Time `json:"delivered" utc:"true"`
+	Local     time.Time  `json:"local" utc:"false"`
+	Day       time.Time  `json:"day" timeFormat:"date"`
+}
+
+func (self *timesSuite) TestUTC(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONDelivery{}).MustGenerate()
+	c.Assert(j.Properties["shipped"].Pattern, Equals, "")
+	c.Assert(j.Properties["delivered"], DeepEquals, &Property{Type: "string", Format: "date-time", Pattern: PatternRFC3339UTC})
+
+	j = NewGenerator(Options{RequireUTC: true}).WithRoot(&ExampleJSONDelivery{}).MustGenerate()
+	c.Assert(j.Properties["shipped"].Pattern, Equals, PatternRFC3339UTC)
+	c.Assert(j.Properties["local"].Pattern, Equals, "")
+	c.Assert(j.Properties["day"], DeepEquals, &Property{Type: "string", Format: "date"})
+
+	errs, err := j.Validate([]byte(`{"shipped":"2024-12-31T23:59:58.5Z","local":"2024-12-31T23:59:58+01:00"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	errs, err = j.Validate([]byte(`{"shipped":"2024-12-31T23:59:58+01:00"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Keyword, Equals, "pattern")
+}
+
+func (self *timesSuite) TestLayoutPattern(c *C) {
+	pattern, err := layoutPattern("Jan _2 3:04PM")
+	c.Assert(err, IsNil)
+	c.Assert(pattern, Equals, `^[A-Z][a-z]{2} [ 0-9][0-9] [0-9]{1,2}:[0-9]{2}(AM|PM)$`)
+	pattern, err = layoutPattern("15:04:05.999")
+	c.Assert(err, IsNil)
+	c.Assert(pattern, Equals, `^[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]{1,3})?$`)
+	_, err = layoutPattern("today")
+	c.Assert(err, ErrorMatches, "no element of a time layout")
+}
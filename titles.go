@@ -0,0 +1,47 @@
+package jsonschema
+
+import (
+	"strings"
+	"unicode"
+)
+
+// humanize turns a property name into a title, e.g. "firstName" and
+// "first_name" into "First Name", keeping acronyms such as "HTTPServer"
+// together: "HTTP Server".
+func humanize(name string) string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(word) > 0:
+			previous := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// a new word starts after a lower case letter or a digit,
+			// or with the last capital of an acronym: HTTPServer
+			if !unicode.IsUpper(previous) || nextLower {
+				flush()
+			}
+		case unicode.IsDigit(r) && len(word) > 0 && !unicode.IsDigit(word[len(word)-1]):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
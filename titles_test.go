@@ -0,0 +1,44 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type titlesSuite struct{}
+
+var _ = Suite(&titlesSuite{})
+
+func (self *titlesSuite) TestHumanize(c *C) {
+	for name, title := range map[string]string{
+		"firstName":    "First Name",
+		"first_name":   "First Name",
+		"FirstName":    "First Name",
+		"ID":           "ID",
+		"userID":       "User ID",
+		"HTTPServer":   "HTTP Server",
+		"address2":     "Address 2",
+		"x-request-id": "X Request Id",
+		"already Nice": "Already Nice",
+		"é":            "É",
+	} {
+		c.Check(humanize(name), Equals, title, Commentf("%s", name))
+	}
+}
+
+type ExampleJSONTitled struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName" title:"Surname"`
+	UserID    int
+}
+
+func (self *titlesSuite) TestHumanizeTitles(c *C) {
+	j := NewGenerator(Options{HumanizeTitles: true}).WithRoot(&ExampleJSONTitled{}).MustGenerate()
+
+	c.Assert(j.Properties["firstName"].Title, Equals, "First Name")
+	c.Assert(j.Properties["lastName"].Title, Equals, "Surname")
+	c.Assert(j.Properties["UserID"].Title, Equals, "User ID")
+	c.Assert(j.Title, Equals, "")
+
+	j = NewGenerator().WithRoot(&ExampleJSONTitled{}).MustGenerate()
+	c.Assert(j.Properties["firstName"].Title, Equals, "")
+}
@@ -0,0 +1,109 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tracing reports whether the decisions of the generator are traced, to
+// Options.Trace or the logger.
+func (r *reader) tracing() bool {
+	return r.options.Trace != nil || debugging(r.logger)
+}
+
+// tracef writes a line explaining a decision of the generator to
+// Options.Trace, and logs it at the debug level, if set.
+func (r *reader) tracef(format string, args ...interface{}) {
+	if !r.tracing() {
+		return
+	}
+	line := fmt.Sprintf(format, args...)
+	if r.options.Trace != nil {
+		fmt.Fprintln(r.options.Trace, line)
+	}
+	if debugging(r.logger) {
+		r.logger.Debug(line)
+	}
+}
+
+// traceField explains how the field of the struct named structName, of the
+// Go type fieldType, was described into n: the type mapping or reference
+// chosen, the tags applied and those skipped as they have no effect on the
+// schema of the field.
+func (r *reader) traceField(structName, fieldName, fieldType string, tag reflect.StructTag, n *Node) {
+	if !r.tracing() {
+		return
+	}
+	p := r.schema(n)
+	path := structName + "." + fieldName
+	r.tracef("%s: %s for %s", path, traceSchema(p), fieldType)
+
+	var applied []string
+	for _, kv := range structTagPairs(tag) {
+		name := kv[0]
+		if name == "json" || name == r.options.JSONTagName || !generatorTags[name] {
+			continue
+		}
+		if skipped, ok := skippedTag(name, p); ok {
+			r.tracef("%s: %q tag skipped, it has no effect on %s", path, name, skipped)
+			continue
+		}
+		applied = append(applied, name)
+	}
+	if len(applied) > 0 {
+		r.tracef("%s: tags %s applied", path, strings.Join(applied, ", "))
+	}
+}
+
+// skippedTag returns the description of p when the validator tag name has no
+// effect on it, as its keyword doesn't apply to its type.
+func skippedTag(name string, p *Property) (string, bool) {
+	types := map[string][]string{}
+	for _, tag := range stringTags {
+		types[tag] = []string{"string"}
+	}
+	for _, tag := range numberTags {
+		types[tag] = []string{"number", "integer"}
+	}
+	for _, tag := range arrayTags {
+		types[tag] = []string{"array"}
+	}
+	want, ok := types[name]
+	if !ok {
+		return "", false
+	}
+	for _, t := range want {
+		if p.Type == t {
+			return "", false
+		}
+	}
+	return traceSchema(p), true
+}
+
+// traceSchema describes the type of p in a trace.
+func traceSchema(p *Property) string {
+	switch {
+	case p.Ref != "":
+		return "$ref " + p.Ref
+	case len(p.OneOf) > 0:
+		refs := make([]string, len(p.OneOf))
+		for i, branch := range p.OneOf {
+			refs[i] = traceSchema(branch)
+		}
+		return "oneOf " + strings.Join(refs, " | ")
+	case len(p.Types) > 0:
+		return "type " + strings.Join(p.Types, "|")
+	case len(p.AnyOf) > 0:
+		branches := make([]string, len(p.AnyOf))
+		for i, branch := range p.AnyOf {
+			branches[i] = traceSchema(branch)
+		}
+		return "anyOf " + strings.Join(branches, " | ")
+	case p.Type != "" && p.Format != "":
+		return "type " + p.Type + ", format " + p.Format
+	case p.Type != "":
+		return "type " + p.Type
+	}
+	return "any value"
+}
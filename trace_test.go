@@ -0,0 +1,52 @@
+package jsonschema
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type traceSuite struct{}
+
+var _ = Suite(&traceSuite{})
+
+type ExampleJSONTraced struct {
+	Name     string             `json:"name" minLength:"1" min:"3"`
+	Tags     []string           `json:"tags" maxLength:"10"`
+	Address  ExampleJSONAddress `json:"address" description:"Where to ship"`
+	Internal string             `json:"-"`
+}
+
+func (self *traceSuite) TestTrace(c *C) {
+	var trace bytes.Buffer
+	NewGenerator(Options{Trace: &trace}).
+		WithRoot(&ExampleJSONTraced{}).
+		WithDefinition("address", ExampleJSONAddress{}).
+		MustGenerate()
+
+	c.Assert(strings.Split(strings.TrimSpace(trace.String()), "\n"), DeepEquals, []string{
+		"definition address: jsonschema.ExampleJSONAddress",
+		"jsonschema.ExampleJSONAddress.Street: type string for string",
+		"jsonschema.ExampleJSONAddress.Zip: type string for string",
+		"jsonschema.ExampleJSONAddress.Zip: tags classification applied",
+		"root: *jsonschema.ExampleJSONTraced",
+		"jsonschema.ExampleJSONTraced.Name: type string for string",
+		`jsonschema.ExampleJSONTraced.Name: "min" tag skipped, it has no effect on type string`,
+		"jsonschema.ExampleJSONTraced.Name: tags minLength applied",
+		"jsonschema.ExampleJSONTraced.Tags: type array for []string",
+		`jsonschema.ExampleJSONTraced.Tags: "maxLength" tag skipped, it has no effect on type array`,
+		"jsonschema.ExampleJSONTraced.Address: $ref #/definitions/address for jsonschema.ExampleJSONAddress",
+		"jsonschema.ExampleJSONTraced.Address: tags description applied",
+		"jsonschema.ExampleJSONTraced.Internal: skipped, named -",
+	})
+}
+
+func (self *traceSuite) TestTraceRecursion(c *C) {
+	var trace bytes.Buffer
+	NewGenerator(Options{Trace: &trace, UnrollRecursion: 1}).
+		WithRoot(&ExampleJSONTree{}).
+		MustGenerate()
+
+	c.Assert(trace.String(), Matches, `(?s).*jsonschema.ExampleJSONTree: recursive, described as any object\n.*`)
+}
@@ -0,0 +1,217 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// typeScriptEmitter emits TypeScript declarations: an interface per object
+// definition, a type alias per other definition and a declaration for the
+// root, named after its title or Root.
+type typeScriptEmitter struct{}
+
+func (e typeScriptEmitter) Emit(model *Property) ([]byte, error) {
+	return e.EmitDocument(&JSONSchema{Property: *model})
+}
+
+func (e typeScriptEmitter) EmitDocument(schema *JSONSchema) ([]byte, error) {
+	m := schema.Model()
+	c := &typeScriptConverter{names: map[string]string{}}
+	taken := map[string]bool{}
+	for _, name := range sortedDefinitionNames(schema.Definitions) {
+		c.names[name] = typeScriptName(name, taken)
+	}
+
+	var buf bytes.Buffer
+	for _, name := range sortedDefinitionNames(schema.Definitions) {
+		if err := c.declare(&buf, c.names[name], m.Definitions[name]); err != nil {
+			return nil, fmt.Errorf("definition %s: %s", name, err)
+		}
+	}
+	if root := m.Root; root.Kind != KindRef || root.Title != "" {
+		if err := c.declare(&buf, typeScriptName(firstNonEmpty(root.Title, "Root"), taken), root); err != nil {
+			return nil, err
+		}
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+var (
+	typeScriptInvalidName = regexp.MustCompile(`[^A-Za-z0-9_$]`)
+	typeScriptIdentifier  = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// typeScriptName returns a type name derived from name, unique among taken.
+func typeScriptName(name string, taken map[string]bool) string {
+	s := strings.Replace(humanize(name), " ", "", -1)
+	s = typeScriptInvalidName.ReplaceAllString(s, "_")
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		s = "_" + s
+	}
+	s = strings.ToUpper(s[:1]) + s[1:]
+	unique := s
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", s, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+type typeScriptConverter struct {
+	// names holds the type names of the definitions
+	names map[string]string
+}
+
+// declare writes the declaration of the type name described by n.
+func (c *typeScriptConverter) declare(buf *bytes.Buffer, name string, n *Node) error {
+	writeJSDoc(buf, "", n)
+	if n.Kind == KindObject && !n.Nullable && len(n.Values) == 0 {
+		body, err := c.objectBody(n, "")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "export interface %s %s\n\n", name, body)
+		return nil
+	}
+	t, err := c.typeOf(n, "")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "export type %s = %s;\n\n", name, t)
+	return nil
+}
+
+// typeOf returns the type expression of n, whose lines after the first are
+// indented with indent.
+func (c *typeScriptConverter) typeOf(n *Node, indent string) (string, error) {
+	t, err := c.typeOfKind(n, indent)
+	if err != nil {
+		return "", err
+	}
+	if n.Nullable {
+		t += " | null"
+	}
+	return t, nil
+}
+
+func (c *typeScriptConverter) typeOfKind(n *Node, indent string) (string, error) {
+	if len(n.Values) > 0 {
+		literals := make([]string, len(n.Values))
+		for i, v := range n.Values {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			literals[i] = string(b)
+		}
+		return strings.Join(literals, " | "), nil
+	}
+
+	switch n.Kind {
+	case KindRef:
+		if n.Definition == "" {
+			return "", fmt.Errorf("reference %s can't be described in TypeScript", n.Ref)
+		}
+		name, ok := c.names[n.Definition]
+		if !ok {
+			return "", fmt.Errorf("unresolvable reference %s", n.Ref)
+		}
+		return name, nil
+	case KindUnion:
+		types := make([]string, len(n.Variants))
+		for i, variant := range n.Variants {
+			var err error
+			if types[i], err = c.typeOf(variant, indent); err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(types, " | "), nil
+	case KindString, KindBoolean, KindNull:
+		return string(n.Kind), nil
+	case KindInteger, KindNumber:
+		return "number", nil
+	case KindArray:
+		if n.Elem == nil {
+			return "unknown[]", nil
+		}
+		items, err := c.typeOf(n.Elem, indent)
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(items, " | ") {
+			items = "(" + items + ")"
+		}
+		return items + "[]", nil
+	case KindMap:
+		if n.Elem == nil {
+			return "Record<string, unknown>", nil
+		}
+		values, err := c.typeOf(n.Elem, indent)
+		if err != nil {
+			return "", err
+		}
+		return "Record<string, " + values + ">", nil
+	case KindObject:
+		return c.objectBody(n, indent)
+	}
+	return "unknown", nil
+}
+
+// objectBody returns the body of an object type, between braces.
+func (c *typeScriptConverter) objectBody(n *Node, indent string) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	inner := indent + "  "
+	for _, field := range n.Fields {
+		t, err := c.typeOf(field.Node, inner)
+		if err != nil {
+			return "", fmt.Errorf("property %s: %s", field.Name, err)
+		}
+		writeJSDoc(&buf, inner, field.Node)
+		key := field.Name
+		if !typeScriptIdentifier.MatchString(key) {
+			b, _ := json.Marshal(key)
+			key = string(b)
+		}
+		optional := "?"
+		if field.Required {
+			optional = ""
+		}
+		fmt.Fprintf(&buf, "%s%s%s: %s;\n", inner, key, optional, t)
+	}
+	if n.Elem != nil {
+		t, err := c.typeOf(n.Elem, inner)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "%s[key: string]: %s;\n", inner, t)
+	}
+	buf.WriteString(indent + "}")
+	return buf.String(), nil
+}
+
+// writeJSDoc writes the description of n and whether it's deprecated as a
+// JSDoc comment.
+func writeJSDoc(buf *bytes.Buffer, indent string, n *Node) {
+	var lines []string
+	if n.Description != "" {
+		lines = strings.Split(strings.Replace(n.Description, "*/", "*\\/", -1), "\n")
+	}
+	if n.Deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	switch len(lines) {
+	case 0:
+	case 1:
+		fmt.Fprintf(buf, "%s/** %s */\n", indent, lines[0])
+	default:
+		fmt.Fprintf(buf, "%s/**\n", indent)
+		for _, line := range lines {
+			fmt.Fprintf(buf, "%s * %s\n", indent, line)
+		}
+		fmt.Fprintf(buf, "%s */\n", indent)
+	}
+}
@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	"reflect"
+)
+
+// WithTypeTags sets tags on every field of the type of v, or of a pointer
+// to it, as if the fields were tagged with them, e.g.
+//
+//	g.WithTypeTags(billing.IBAN(""), map[string]string{"pattern": "^[A-Z]{2}[0-9]{2}[A-Z0-9]+$", "maxLength": "34"})
+//
+// so that the validators of a named scalar type are registered once. v may
+// be an instance of the type or its reflect.Type. The tags set on the field
+// and by its presets take precedence, and the tags apply to the definition
+// of the type, if any. Types of the program can rather implement
+// SchemaModifier.
+func (g *Generator) WithTypeTags(v interface{}, tags map[string]string) *Generator {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	if g.typeTags == nil {
+		g.typeTags = map[string]map[string]string{}
+	}
+	g.typeTags[typeName(t)] = tags
+	return g
+}
+
+// typeName returns the name of the named type t qualified by the import
+// path of its package, as types.TypeString does, or "" if t isn't named.
+func typeName(t reflect.Type) string {
+	if t.Name() == "" {
+		return ""
+	}
+	if t.PkgPath() == "" {
+		return t.Name()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// addTypeTags returns tag with the tags registered for the type named name
+// added, unless they are already set.
+func (r *reader) addTypeTags(tag reflect.StructTag, name string) reflect.StructTag {
+	tags, ok := r.typeTags[name]
+	if !ok || name == "" {
+		return tag
+	}
+	return addTags(tag, tags)
+}
+
+// fieldTypeName returns the name of the type of the values of a field of
+// type t, through pointers.
+func fieldTypeName(t goType) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,37 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type typeTagsSuite struct{}
+
+var _ = Suite(&typeTagsSuite{})
+
+type ExampleJSONEmail string
+
+type ExampleJSONContact struct {
+	Email ExampleJSONEmail `json:"email"`
+	Work  ExampleJSONEmail `json:"work" maxLength:"100"`
+}
+
+func (self *typeTagsSuite) TestTypeTags(c *C) {
+	j := NewGenerator().
+		WithTypeTags(ExampleJSONEmail(""), map[string]string{"format": "email", "maxLength": "254"}).
+		WithRoot(&ExampleJSONContact{}).
+		MustGenerate()
+	c.Assert(j.Properties["email"].Format, Equals, "email")
+	c.Assert(*j.Properties["email"].MaxLength, Equals, int64(254))
+	// the tags of the field take precedence
+	c.Assert(j.Properties["work"].Format, Equals, "email")
+	c.Assert(*j.Properties["work"].MaxLength, Equals, int64(100))
+
+	j = NewGenerator(Options{ReferencePrimitives: true}).
+		WithTypeTags(ExampleJSONEmail(""), map[string]string{"format": "email", "maxLength": "254"}).
+		WithDefinition("email", ExampleJSONEmail("")).
+		WithRoot(&ExampleJSONContact{}).
+		MustGenerate()
+	maxLength := int64(254)
+	c.Assert(j.Definitions["email"], DeepEquals, Property{Type: "string", Format: "email", MaxLength: &maxLength})
+	c.Assert(j.Properties["email"], DeepEquals, &Property{Ref: "#/definitions/email"})
+}
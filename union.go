@@ -0,0 +1,67 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithRootUnion describes at the root of the schema the values of any one of
+// the types of roots, as a oneOf of their schemas, e.g. for endpoints which
+// accept alternative payloads. The struct types which aren't registered as
+// definitions are registered under the name of their type, so that the
+// branches reference the definitions. roots may be instances of the types or
+// their reflect.Type. It replaces the root set with WithRoot.
+func (g *Generator) WithRootUnion(roots ...interface{}) *Generator {
+	g.root = nil
+	g.rootUnion = roots
+	return g
+}
+
+// unionDefinitions returns the definitions, along with the struct types of
+// the root union which aren't registered, under the name of their type.
+func unionDefinitions(definitions map[string]goType, rootUnion []goType) (map[string]goType, error) {
+	if len(rootUnion) == 0 {
+		return definitions, nil
+	}
+	union := make(map[string]goType, len(definitions)+len(rootUnion))
+	registered := map[interface{}]bool{}
+	for name, d := range definitions {
+		union[name] = d
+		registered[pointedType(d).Key()] = true
+	}
+	for _, t := range rootUnion {
+		t = pointedType(t)
+		if t.Kind() != reflect.Struct || t.Name() == "" || registered[t.Key()] {
+			continue
+		}
+		name := shortName(t)
+		if other, ok := union[name]; ok {
+			return nil, fmt.Errorf("type %s of the root union conflicts with the definition %s of type %s", t, name, other)
+		}
+		union[name] = t
+		registered[t.Key()] = true
+	}
+	return union, nil
+}
+
+// readRootUnion reads the root union into n, an exclusive union of the nodes
+// of its types.
+func (r *reader) readRootUnion(n *Node, roots []goType) error {
+	n.Kind, n.Exclusive = KindUnion, true
+	for _, t := range roots {
+		variant := newNode()
+		if err := r.read(variant, t); err != nil {
+			return fmt.Errorf("error on root type %s: %s", t, err)
+		}
+		n.Variants = append(n.Variants, variant)
+	}
+	return nil
+}
+
+// pointedType returns the type pointers of type t point to, or t.
+func pointedType(t goType) goType {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,47 @@
+package jsonschema
+
+import (
+	"reflect"
+
+	. "gopkg.in/check.v1"
+)
+
+type unionSuite struct{}
+
+var _ = Suite(&unionSuite{})
+
+type ExampleUnionCard struct {
+	Number string `json:"number" required:"true"`
+}
+
+type ExampleUnionTransfer struct {
+	IBAN string `json:"iban" required:"true"`
+}
+
+func (self *unionSuite) TestRootUnion(c *C) {
+	j := NewGenerator().
+		WithDefinition("card", ExampleUnionCard{}).
+		WithRootUnion(&ExampleUnionCard{}, reflect.TypeOf(ExampleUnionTransfer{}), "").
+		MustGenerate()
+
+	c.Assert(j.OneOf, DeepEquals, []*Property{
+		{Ref: "#/definitions/card"},
+		{Ref: "#/definitions/ExampleUnionTransfer"},
+		{Type: "string"},
+	})
+	c.Assert(j.Type, Equals, "")
+	c.Assert(sortedDefinitionNames(j.Definitions), DeepEquals, []string{"ExampleUnionTransfer", "card"})
+	c.Assert(j.Definitions["ExampleUnionTransfer"].Required, DeepEquals, []string{"iban"})
+
+	j = NewGenerator().WithRootUnion(ExampleUnionCard{}).WithRoot(ExampleUnionTransfer{}).MustGenerate()
+	c.Assert(j.OneOf, IsNil)
+	c.Assert(j.Definitions, IsNil)
+}
+
+func (self *unionSuite) TestRootUnionConflict(c *C) {
+	_, err := NewGenerator().
+		WithDefinition("ExampleUnionCard", ExampleUnionTransfer{}).
+		WithRootUnion(ExampleUnionCard{}).
+		Generate()
+	c.Assert(err, ErrorMatches, "type jsonschema.ExampleUnionCard of the root union conflicts with the definition ExampleUnionCard of type jsonschema.ExampleUnionTransfer")
+}
@@ -0,0 +1,706 @@
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError is a violation of a schema by a document.
+type ValidationError struct {
+	// InstancePath is the JSON pointer of the invalid value in the document,
+	// empty for the document itself.
+	InstancePath string
+	// SchemaPath is the JSON pointer of the keyword which isn't satisfied,
+	// through the $refs followed, e.g. "/properties/child/$ref/required".
+	SchemaPath string
+	Keyword    string
+	Message    string
+}
+
+func (e ValidationError) Error() string {
+	if e.InstancePath == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.InstancePath, e.Message)
+}
+
+// ValidationErrors are the violations of a schema by a document.
+type ValidationErrors []ValidationError
+
+// Validator validates JSON documents against a schema. The schema is checked
+// and its patterns compiled once, so validators should be reused. A Validator
+// is safe for concurrent use.
+type Validator struct {
+	root        *Property
+	definitions map[string]*Property
+	// remote holds the schemas of the remote $refs, by absolute reference
+	remote   map[string]*Property
+	patterns map[string]*regexp.Regexp
+	limits   ValidatorLimits
+	metrics  ValidationMetrics
+	formats  bool
+}
+
+// ValidatorLimits bounds the resources used by a Validator, to validate
+// against schemas from untrusted sources, e.g. parsed from third-party
+// documents. Zero values are unlimited.
+type ValidatorLimits struct {
+	// MaxPatternComplexity is the maximum number of instructions of the
+	// compiled program of a pattern, which grows with repetitions like
+	// (a{30}){30}.
+	MaxPatternComplexity int
+	// MaxRefDepth is the maximum number of $refs followed in a row.
+	MaxRefDepth int
+	// MaxDocumentSize is the maximum size of the documents validated, in bytes.
+	MaxDocumentSize int
+	// MaxRefDocumentSize is the maximum size of the documents of remote $refs
+	// loaded, in bytes. HTTPRefLoader stops reading the documents beyond it,
+	// or beyond 10 MiB if it isn't set.
+	MaxRefDocumentSize int
+}
+
+// ValidationMetrics receives measures of the validations of a Validator, e.g.
+// to export them as Prometheus metrics from services validating documents in
+// the request path. Its methods are called concurrently when the validator is
+// used concurrently.
+type ValidationMetrics interface {
+	// ObserveValidation is called once for each document validated, with
+	// the duration of its validation and whether it is valid. Documents which
+	// can't be decoded or exceed the limits of the validator aren't observed.
+	ObserveValidation(duration time.Duration, valid bool)
+	// CountFailure is called once for each violation, with its keyword.
+	CountFailure(keyword string)
+}
+
+// NewValidator returns a validator for the schema, or an error if the schema
+// can't be used for validation, e.g. because of an invalid pattern or a $ref
+// to a missing definition, or exceeds the limits given. Later changes to the
+// schema don't affect the validator.
+func NewValidator(schema *JSONSchema, limits ...ValidatorLimits) (*Validator, error) {
+	return newValidator(context.Background(), schema, nil, limits)
+}
+
+func newValidator(ctx context.Context, schema *JSONSchema, loader RefLoader, limits []ValidatorLimits) (*Validator, error) {
+	schema = schema.Clone()
+	v := &Validator{
+		root:        &schema.Property,
+		definitions: make(map[string]*Property, len(schema.Definitions)),
+		patterns:    map[string]*regexp.Regexp{},
+	}
+	if len(limits) > 0 {
+		v.limits = limits[0]
+	}
+	for name := range schema.Definitions {
+		def := schema.Definitions[name]
+		v.definitions[name] = &def
+	}
+	if loader != nil {
+		if err := v.loadRemote(ctx, schema.ID, loader); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	check := func(p *Property) {
+		if err != nil {
+			return
+		}
+		if p.Ref != "" && v.resolve(p.Ref) == nil {
+			err = fmt.Errorf("unresolvable reference %s", p.Ref)
+			return
+		}
+		if _, ok := v.patterns[p.Pattern]; p.Pattern != "" && !ok {
+			if err = v.checkPatternComplexity(p.Pattern); err != nil {
+				return
+			}
+			if re, ok := compiledPatterns.Load(p.Pattern); ok {
+				// compiled when generating a schema
+				v.patterns[p.Pattern] = re.(*regexp.Regexp)
+				return
+			}
+			var re *regexp.Regexp
+			re, err = regexp.Compile(p.Pattern)
+			if err != nil {
+				err = fmt.Errorf("invalid pattern %q: %s", p.Pattern, err)
+				return
+			}
+			v.patterns[p.Pattern] = re
+		}
+	}
+	walkProperties(v.root, check)
+	for _, name := range sortedPropertyNames(v.definitions) {
+		walkProperties(v.definitions[name], check)
+	}
+	for _, ref := range sortedPropertyNames(v.remote) {
+		walkProperties(v.remote[ref], check)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// WithMetrics reports the measures of the validations to metrics. It must be
+// called before the validator is used.
+func (v *Validator) WithMetrics(metrics ValidationMetrics) *Validator {
+	v.metrics = metrics
+	return v
+}
+
+// WithFormatAssertion checks the strings of the formats emitted by the
+// generator, e.g. date-time, date, time, email, uuid, ipv4, ipv6 and uri,
+// which are otherwise annotations, as the specification allows. Other
+// formats aren't checked. It must be called before the validator is used.
+func (v *Validator) WithFormatAssertion() *Validator {
+	v.formats = true
+	return v
+}
+
+// Validate validates the JSON document data against the schema. It returns
+// the violations of the schema, and an error if data isn't a JSON document or
+// the schema can't be used for validation. Use NewValidator to validate
+// several documents against the same schema.
+func (d *JSONSchema) Validate(data []byte) (ValidationErrors, error) {
+	v, err := NewValidator(d)
+	if err != nil {
+		return nil, err
+	}
+	return v.Validate(data)
+}
+
+// Validate validates the JSON document data. It returns the violations of
+// the schema, and an error if data isn't a JSON document.
+func (v *Validator) Validate(data []byte) (ValidationErrors, error) {
+	return v.ValidateContext(context.Background(), data)
+}
+
+// ValidateContext validates the JSON document data as Validate does, and
+// stops with the error of ctx once it is canceled or its deadline exceeded,
+// so that validating a large document in a request can't outlive it.
+func (v *Validator) ValidateContext(ctx context.Context, data []byte) (ValidationErrors, error) {
+	doc, err := v.decode(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	s, err := v.run(ctx, v.root, doc, "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.errors, nil
+}
+
+// contextCheckInterval is the number of subschemas evaluated between checks
+// of the context of a validation.
+const contextCheckInterval = 256
+
+// run validates the decoded document doc, at instancePath, against p, at
+// schemaPath, recording the evaluations of the subschemas under node if set.
+// It returns an error if a limit of the validator is exceeded or ctx is done.
+func (v *Validator) run(ctx context.Context, p *Property, doc interface{}, instancePath, schemaPath string, node *outputNode) (*validation, error) {
+	start := time.Now()
+	s := &validation{validator: v, refs: map[string]bool{}, node: node, bounds: &validationBounds{ctx: ctx}}
+	s.validate(p, doc, instancePath, schemaPath)
+	if s.bounds.err != nil {
+		return nil, s.bounds.err
+	}
+	if v.metrics != nil {
+		v.metrics.ObserveValidation(time.Since(start), len(s.errors) == 0)
+		for _, e := range s.errors {
+			v.metrics.CountFailure(e.Keyword)
+		}
+	}
+	return s, nil
+}
+
+// decode decodes a document validated, within the limits of the validator,
+// unless ctx is already done. The NaN, Infinity and -Infinity tokens are
+// decoded as numbers, which only the properties allowing them accept.
+func (v *Validator) decode(ctx context.Context, data []byte) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if limit := v.limits.MaxDocumentSize; limit > 0 && len(data) > limit {
+		return nil, fmt.Errorf("the document exceeds the maximum size of %d bytes", limit)
+	}
+	return decodeNonFinite(data)
+}
+
+// checkPatternComplexity returns an error if the pattern exceeds the maximum
+// complexity of the limits of the validator.
+func (v *Validator) checkPatternComplexity(pattern string) error {
+	limit := v.limits.MaxPatternComplexity
+	if limit <= 0 {
+		return nil
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	if len(prog.Inst) > limit {
+		return fmt.Errorf("pattern %q exceeds the maximum complexity of %d", pattern, limit)
+	}
+	return nil
+}
+
+// decodeDocument decodes a JSON document, keeping numbers as json.Number so
+// that large integers are not rounded.
+func decodeDocument(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %s", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON document: data after the top-level value")
+	}
+	return doc, nil
+}
+
+// resolve returns the schema a $ref points to, or nil if it can't be resolved.
+func (v *Validator) resolve(ref string) *Property {
+	if ref == "#" {
+		return v.root
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return v.remote[ref]
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		// anchors aren't supported
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	for i, token := range tokens {
+		unescaped, err := url.PathUnescape(token)
+		if err != nil {
+			return nil
+		}
+		tokens[i] = unescapePointer(unescaped)
+	}
+	if tokens[0] == "definitions" && len(tokens) > 1 {
+		return subschema(v.definitions[tokens[1]], tokens[2:])
+	}
+	return subschema(v.root, tokens)
+}
+
+// subschema returns the schema at the reference tokens of a JSON pointer from
+// p, or nil if there is none.
+func subschema(p *Property, tokens []string) *Property {
+	for len(tokens) > 0 && p != nil {
+		keyword := tokens[0]
+		tokens = tokens[1:]
+		switch keyword {
+		case "items":
+			p = p.Items
+			continue
+		case "additionalProperties":
+			p = p.AdditionalPropertiesSchema
+			continue
+		case "not":
+			p = p.Not
+			continue
+		}
+		if len(tokens) == 0 {
+			return nil
+		}
+		name := tokens[0]
+		tokens = tokens[1:]
+		switch keyword {
+		case "properties":
+			p = p.Properties[name]
+		case "dependencies":
+			p = p.Dependencies[name]
+		case "dependentSchemas":
+			p = p.DependentSchemas[name]
+		case "anyOf", "oneOf":
+			branches := p.AnyOf
+			if keyword == "oneOf" {
+				branches = p.OneOf
+			}
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(branches) {
+				return nil
+			}
+			p = branches[i]
+		default:
+			return nil
+		}
+	}
+	return p
+}
+
+// validation holds the state of the validation of a document.
+type validation struct {
+	validator *Validator
+	errors    ValidationErrors
+	// refs holds the $refs being followed for each instance path, to stop
+	// at references which loop without consuming the document
+	refs map[string]bool
+	// node is the evaluation of the current subschema, when the output
+	// formats need the evaluations of all the subschemas
+	node *outputNode
+	// bounds is shared with the validations of branches
+	bounds *validationBounds
+}
+
+// validationBounds tracks the resources used by a validation, within the
+// limits of the validator and the lifetime of its context.
+type validationBounds struct {
+	ctx context.Context
+	// steps counts the subschemas evaluated
+	steps    int
+	refDepth int
+	// err is the limit exceeded, which stops the validation
+	err error
+}
+
+func (s *validation) add(instancePath, schemaPath, keyword, format string, args ...interface{}) {
+	s.errors = append(s.errors, ValidationError{
+		InstancePath: instancePath,
+		SchemaPath:   schemaPath + "/" + keyword,
+		Keyword:      keyword,
+		Message:      fmt.Sprintf(format, args...),
+	})
+	if s.node != nil {
+		e := s.errors[len(s.errors)-1]
+		s.node.errors = append(s.node.errors, e)
+	}
+}
+
+// matches reports whether value is valid under p, without reporting the violations.
+func (s *validation) matches(p *Property, value interface{}, instancePath, schemaPath string) bool {
+	branch := &validation{validator: s.validator, refs: s.refs, node: s.node, bounds: s.bounds}
+	branch.validate(p, value, instancePath, schemaPath)
+	if s.node != nil {
+		s.node.children[len(s.node.children)-1].branch = true
+	}
+	return len(branch.errors) == 0
+}
+
+func (s *validation) validate(p *Property, value interface{}, instancePath, schemaPath string) {
+	if s.node != nil {
+		parent := s.node
+		s.node = &outputNode{keywordLocation: schemaPath, instanceLocation: instancePath}
+		parent.children = append(parent.children, s.node)
+		defer func() { s.node = parent }()
+	}
+
+	if s.bounds.err != nil {
+		return
+	}
+	if s.bounds.steps++; s.bounds.steps%contextCheckInterval == 0 {
+		if err := s.bounds.ctx.Err(); err != nil {
+			s.bounds.err = err
+			return
+		}
+	}
+	if p.Ref != "" {
+		key := p.Ref + "|" + instancePath
+		if !s.refs[key] {
+			if limit := s.validator.limits.MaxRefDepth; limit > 0 && s.bounds.refDepth >= limit {
+				s.bounds.err = fmt.Errorf("the maximum $ref depth of %d is exceeded at %s", limit, schemaPath+"/$ref")
+				return
+			}
+			s.refs[key] = true
+			s.bounds.refDepth++
+			s.validate(s.validator.resolve(p.Ref), value, instancePath, schemaPath+"/$ref")
+			s.bounds.refDepth--
+			delete(s.refs, key)
+		}
+	}
+
+	if !s.validateType(p, value, instancePath, schemaPath) {
+		// the other keywords would only repeat the violation
+		return
+	}
+	if len(p.Enum) > 0 && !inEnum(p.Enum, value) {
+		s.add(instancePath, schemaPath, "enum", "must be one of %s", quoteList(p.Enum))
+	}
+	if p.Const != nil && !jsonEqual(p.Const, value) {
+		b, _ := json.Marshal(p.Const)
+		s.add(instancePath, schemaPath, "const", "must be %s", b)
+	}
+
+	switch value := value.(type) {
+	case json.Number:
+		s.validateNumber(p, value, instancePath, schemaPath)
+	case string:
+		s.validateString(p, value, instancePath, schemaPath)
+	case []interface{}:
+		if p.MinItems != nil && int64(len(value)) < *p.MinItems {
+			s.add(instancePath, schemaPath, "minItems", "must have at least %d items", *p.MinItems)
+		}
+		if p.MaxItems != nil && int64(len(value)) > *p.MaxItems {
+			s.add(instancePath, schemaPath, "maxItems", "must have at most %d items", *p.MaxItems)
+		}
+		if p.Items != nil {
+			for i, item := range value {
+				s.validate(p.Items, item, fmt.Sprintf("%s/%d", instancePath, i), schemaPath+"/items")
+			}
+		}
+	case map[string]interface{}:
+		s.validateObject(p, value, instancePath, schemaPath)
+	}
+
+	s.validateComposition(p, value, instancePath, schemaPath)
+}
+
+// validateType reports whether value is of one of the types of p.
+func (s *validation) validateType(p *Property, value interface{}, instancePath, schemaPath string) bool {
+	types := p.Types
+	if len(types) == 0 && p.Type != "" {
+		types = []string{p.Type}
+	}
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if isOfType(t, value) {
+			return true
+		}
+	}
+	s.add(instancePath, schemaPath, "type", "must be of type %s, not %s", strings.Join(types, " or "), jsonType(value))
+	return false
+}
+
+func (s *validation) validateNumber(p *Property, n json.Number, instancePath, schemaPath string) {
+	if flags, ok := p.Flags(); ok {
+		var mask int64
+		for _, value := range flags {
+			mask |= value
+		}
+		if i, err := strconv.ParseInt(string(n), 10, 64); err == nil && i&^mask != 0 {
+			s.add(instancePath, schemaPath, FlagsExtension, "must only combine the flags %s", strings.Join(flagNames(flags), ", "))
+		}
+	}
+	f, err := n.Float64()
+	if err != nil {
+		// out of the range of floats, the bounds can't be checked
+		return
+	}
+	if isNonFinite(n) && (p.Type != "" || len(p.Types) > 0) && !p.AllowsNaN() {
+		s.add(instancePath, schemaPath, AllowNaNExtension, "must be a finite number, not %s", n)
+		return
+	}
+	if p.Minimum != nil && f < *p.Minimum {
+		s.add(instancePath, schemaPath, "minimum", "must be greater than or equal to %v", *p.Minimum)
+	} else if p.Minimum != nil && f == *p.Minimum && p.Extensions["exclusiveMinimum"] == true {
+		// the bounds of draft-04
+		s.add(instancePath, schemaPath, "exclusiveMinimum", "must be greater than %v", *p.Minimum)
+	}
+	if p.Maximum != nil && f > *p.Maximum {
+		s.add(instancePath, schemaPath, "maximum", "must be less than or equal to %v", *p.Maximum)
+	} else if p.Maximum != nil && f == *p.Maximum && p.Extensions["exclusiveMaximum"] == true {
+		s.add(instancePath, schemaPath, "exclusiveMaximum", "must be less than %v", *p.Maximum)
+	}
+	if p.ExclusiveMinimum != nil && f <= *p.ExclusiveMinimum {
+		s.add(instancePath, schemaPath, "exclusiveMinimum", "must be greater than %v", *p.ExclusiveMinimum)
+	}
+	if p.ExclusiveMaximum != nil && f >= *p.ExclusiveMaximum {
+		s.add(instancePath, schemaPath, "exclusiveMaximum", "must be less than %v", *p.ExclusiveMaximum)
+	}
+	if p.MultipleOf != nil && *p.MultipleOf > 0 {
+		q := f / *p.MultipleOf
+		// a quotient overflowing to infinity isn't an integer either
+		if math.IsInf(q, 0) || math.Abs(q-math.Round(q)) > 1e-9 {
+			s.add(instancePath, schemaPath, "multipleOf", "must be a multiple of %v", *p.MultipleOf)
+		}
+	}
+}
+
+func (s *validation) validateString(p *Property, str string, instancePath, schemaPath string) {
+	length := int64(utf8.RuneCountInString(str))
+	if p.MinLength != nil && length < *p.MinLength {
+		s.add(instancePath, schemaPath, "minLength", "must be at least %d characters long", *p.MinLength)
+	}
+	if p.MaxLength != nil && length > *p.MaxLength {
+		s.add(instancePath, schemaPath, "maxLength", "must be at most %d characters long", *p.MaxLength)
+	}
+	if p.Pattern != "" && !s.validator.patterns[p.Pattern].MatchString(str) {
+		s.add(instancePath, schemaPath, "pattern", "must match the pattern %s", p.Pattern)
+	}
+	if check, ok := formatCheckers[p.Format]; ok && s.validator.formats && !check(str) {
+		s.add(instancePath, schemaPath, "format", "must be a valid %s", p.Format)
+	}
+}
+
+func (s *validation) validateObject(p *Property, object map[string]interface{}, instancePath, schemaPath string) {
+	for _, name := range p.Required {
+		if _, ok := object[name]; !ok {
+			s.add(instancePath, schemaPath, "required", "missing required property %s", name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := instancePath + "/" + escapePointer(name)
+		if _, ok := p.Properties[name]; ok {
+			s.validate(p.Properties[name], object[name], propertyPath, schemaPath+"/properties/"+escapePointer(name))
+		} else if _, ok := p.Properties[".*"]; ok {
+			// the values of maps
+			s.validate(p.Properties[".*"], object[name], propertyPath, schemaPath+"/properties/.*")
+		} else if p.AdditionalPropertiesSchema != nil {
+			s.validate(p.AdditionalPropertiesSchema, object[name], propertyPath, schemaPath+"/additionalProperties")
+		} else if p.NoAdditionalProperties {
+			s.add(propertyPath, schemaPath, "additionalProperties", "property %s is not allowed", name)
+		}
+	}
+
+	for _, name := range sortedPropertyNames(p.Dependencies) {
+		if _, ok := object[name]; ok {
+			s.validate(p.Dependencies[name], object, instancePath, schemaPath+"/dependencies/"+escapePointer(name))
+		}
+	}
+	for _, name := range sortedDependentRequiredNames(p.DependentRequired) {
+		if _, ok := object[name]; !ok {
+			continue
+		}
+		for _, required := range p.DependentRequired[name] {
+			if _, ok := object[required]; !ok {
+				s.add(instancePath, schemaPath, "dependentRequired", "missing property %s, required with %s", required, name)
+			}
+		}
+	}
+	for _, name := range sortedPropertyNames(p.DependentSchemas) {
+		if _, ok := object[name]; ok {
+			s.validate(p.DependentSchemas[name], object, instancePath, schemaPath+"/dependentSchemas/"+escapePointer(name))
+		}
+	}
+}
+
+func (s *validation) validateComposition(p *Property, value interface{}, instancePath, schemaPath string) {
+	if len(p.AnyOf) > 0 {
+		matched := false
+		for i, branch := range p.AnyOf {
+			if s.matches(branch, value, instancePath, fmt.Sprintf("%s/anyOf/%d", schemaPath, i)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if i, ok := nullableBranch(p.AnyOf); ok && value != nil {
+				// the value of a pointer: the violations of the value are more helpful
+				s.validate(p.AnyOf[i], value, instancePath, fmt.Sprintf("%s/anyOf/%d", schemaPath, i))
+			} else {
+				s.add(instancePath, schemaPath, "anyOf", "must match at least one of the schemas")
+			}
+		}
+	}
+	if len(p.OneOf) > 0 {
+		matched := 0
+		for i, branch := range p.OneOf {
+			if s.matches(branch, value, instancePath, fmt.Sprintf("%s/oneOf/%d", schemaPath, i)) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			s.add(instancePath, schemaPath, "oneOf", "must match exactly one of the schemas, matches %d", matched)
+		}
+	}
+	if p.Not != nil && s.matches(p.Not, value, instancePath, schemaPath+"/not") {
+		s.add(instancePath, schemaPath, "not", "must not match the schema")
+	}
+}
+
+// nullableBranch returns the index of the branch which isn't {"type": "null"}
+// in the anyOf emitted for pointers.
+func nullableBranch(branches []*Property) (int, bool) {
+	if len(branches) != 2 {
+		return 0, false
+	}
+	for i, branch := range branches {
+		if branch.Type == "null" && len(branch.Types) == 0 {
+			return 1 - i, true
+		}
+	}
+	return 0, false
+}
+
+func sortedDependentRequiredNames(m map[string][]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonType returns the JSON type of a decoded value.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func isOfType(t string, value interface{}) bool {
+	if t == "integer" {
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return jsonType(value) == t
+}
+
+// jsonEqual reports whether two values are equal once encoded as JSON.
+func jsonEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(normalizeJSON(a), normalizeJSON(b))
+}
+
+func normalizeJSON(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+func quoteList(values []interface{}) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = formatValue(v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,298 @@
+package jsonschema
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type validateSuite struct{}
+
+var _ = Suite(&validateSuite{})
+
+type ExampleJSONValidatedLine struct {
+	SKU      string `json:"sku" pattern:"^[A-Z]{3}-[0-9]+$" required:"true"`
+	Quantity int    `json:"quantity" min:"1" max:"100"`
+}
+
+type ExampleJSONValidatedOrder struct {
+	ID       string                     `json:"id" required:"true" minLength:"3" maxLength:"8"`
+	Status   string                     `json:"status" enum:"open|closed"`
+	Note     *string                    `json:"note"`
+	Lines    []ExampleJSONValidatedLine `json:"lines"`
+	Tags     map[string]string          `json:"tags"`
+	Discount float64                    `json:"discount" exclusiveMax:"1"`
+}
+
+func validatedOrderSchema() *JSONSchema {
+	return NewGenerator().
+		WithDefinition("line", ExampleJSONValidatedLine{}).
+		WithRoot(&ExampleJSONValidatedOrder{}).
+		MustGenerate()
+}
+
+func (self *validateSuite) TestValidate(c *C) {
+	js := validatedOrderSchema()
+
+	errs, err := js.Validate([]byte(`{
+		"id": "o-1",
+		"status": "open",
+		"note": null,
+		"lines": [{"sku": "ABC-1", "quantity": 2}],
+		"tags": {"a": "b"},
+		"discount": 0.5
+	}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	errs, err = js.Validate([]byte(`{
+		"id": "o",
+		"status": "pending",
+		"note": 1,
+		"lines": [{"sku": "abc", "quantity": 0}, {"quantity": 1.5}],
+		"tags": {"a": 1},
+		"discount": 1
+	}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{
+		{InstancePath: "/discount", SchemaPath: "/properties/discount/exclusiveMaximum", Keyword: "exclusiveMaximum", Message: "must be less than 1"},
+		{InstancePath: "/id", SchemaPath: "/properties/id/minLength", Keyword: "minLength", Message: "must be at least 3 characters long"},
+		{InstancePath: "/lines/0/quantity", SchemaPath: "/properties/lines/items/$ref/properties/quantity/minimum", Keyword: "minimum", Message: "must be greater than or equal to 1"},
+		{InstancePath: "/lines/0/sku", SchemaPath: "/properties/lines/items/$ref/properties/sku/pattern", Keyword: "pattern", Message: "must match the pattern ^[A-Z]{3}-[0-9]+$"},
+		{InstancePath: "/lines/1", SchemaPath: "/properties/lines/items/$ref/required", Keyword: "required", Message: "missing required property sku"},
+		{InstancePath: "/lines/1/quantity", SchemaPath: "/properties/lines/items/$ref/properties/quantity/type", Keyword: "type", Message: "must be of type integer, not number"},
+		{InstancePath: "/note", SchemaPath: "/properties/note/anyOf/0/type", Keyword: "type", Message: "must be of type string, not number"},
+		{InstancePath: "/status", SchemaPath: "/properties/status/enum", Keyword: "enum", Message: `must be one of "open", "closed"`},
+		{InstancePath: "/tags/a", SchemaPath: "/properties/tags/properties/.*/type", Keyword: "type", Message: "must be of type string, not number"},
+	})
+	c.Assert(errs[0].Error(), Equals, "/discount: must be less than 1")
+
+	errs, err = js.Validate([]byte(`[]`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{
+		{SchemaPath: "/type", Keyword: "type", Message: "must be of type object, not array"},
+	})
+	c.Assert(errs[0].Error(), Equals, "must be of type object, not array")
+}
+
+func (self *validateSuite) TestValidateInvalidDocument(c *C) {
+	js := validatedOrderSchema()
+	_, err := js.Validate([]byte(`{"id": `))
+	c.Assert(err, ErrorMatches, "invalid JSON document: .*")
+	_, err = js.Validate([]byte(`{} {}`))
+	c.Assert(err, ErrorMatches, "invalid JSON document: data after the top-level value")
+}
+
+func (self *validateSuite) TestValidateKeywords(c *C) {
+	for _, t := range []struct {
+		schema string
+		doc    string
+		errors []string
+	}{
+		{`{"type": "integer"}`, `10000000000000000000000`, nil},
+		{`{"type": "integer"}`, `1.0`, nil},
+		{`{"type": ["string", "null"]}`, `null`, nil},
+		{`{"type": ["string", "null"]}`, `true`, []string{"type"}},
+		{`{"multipleOf": 0.1}`, `0.3`, nil},
+		{`{"multipleOf": 2}`, `3`, []string{"multipleOf"}},
+		{`{"type": "integer", "multipleOf": 0.123456789}`, `1e308`, []string{"multipleOf"}},
+		{`{"maxLength": 2}`, `"éé"`, nil},
+		{`{"const": 1}`, `1.0`, nil},
+		{`{"const": {"a": [1]}}`, `{"a": [2]}`, []string{"const"}},
+		{`{"oneOf": [{"type": "string"}, {"maxLength": 2}]}`, `"abc"`, nil},
+		{`{"oneOf": [{"type": "string"}, {"maxLength": 2}]}`, `"ab"`, []string{"oneOf"}},
+		{`{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, `true`, []string{"anyOf"}},
+		{`{"not": {"type": "string"}}`, `"a"`, []string{"not"}},
+		{`{"additionalProperties": {"type": "integer"}}`, `{"a": "b"}`, []string{"type"}},
+		{`{"properties": {"a": {}}, "additionalProperties": {"type": "integer"}}`, `{"a": "b"}`, nil},
+		{`{"properties": {"a": {}}, "additionalProperties": false}`, `{"a": 1, "b": 2}`, []string{"additionalProperties"}},
+		{`{"properties": {"a": {}}, "additionalProperties": true}`, `{"a": 1, "b": 2}`, nil},
+		{`{"enum": [1, "a", null]}`, `1.0`, nil},
+		{`{"enum": [1, "a", null]}`, `null`, nil},
+		{`{"enum": [1, "a", null]}`, `2`, []string{"enum"}},
+		{`{"dependentRequired": {"a": ["b", "c"]}}`, `{"a": 1, "c": 1}`, []string{"dependentRequired"}},
+		{`{"dependentSchemas": {"a": {"not": {"required": ["b"]}}}}`, `{"a": 1, "b": 1}`, []string{"not"}},
+		{`{"dependencies": {"a": {"required": ["b"]}}}`, `{"a": 1}`, []string{"required"}},
+		{`{"dependencies": {"a": {"required": ["b"]}}}`, `{"b": 1}`, nil},
+		{`{"definitions": {"list": {"type": "array", "items": {"$ref": "#"}}}, "$ref": "#/definitions/list"}`, `[[], [[1]]]`, []string{"type"}},
+		{`{"properties": {"a": {"type": "integer"}, "b": {"$ref": "#/properties/a"}}}`, `{"b": "c"}`, []string{"type"}},
+		{`{"definitions": {"a/b": {"items": {"type": "string"}}}, "items": {"$ref": "#/definitions/a~1b/items"}}`, `[[1]]`, []string{"type"}},
+		{`{"definitions": {"100%": {"type": "string"}}, "items": {"$ref": "#/definitions/100%25"}}`, `[1]`, []string{"type"}},
+		{`{"anyOf": [{"type": "string"}, {"$ref": "#/anyOf/0"}]}`, `1`, []string{"anyOf"}},
+	} {
+		js := &JSONSchema{}
+		c.Assert(js.UnmarshalJSON([]byte(t.schema)), IsNil, Commentf("%s", t.schema))
+		errs, err := js.Validate([]byte(t.doc))
+		c.Assert(err, IsNil, Commentf("%s", t.schema))
+		var keywords []string
+		for _, e := range errs {
+			keywords = append(keywords, e.Keyword)
+		}
+		c.Check(keywords, DeepEquals, t.errors, Commentf("%s against %s: %v", t.doc, t.schema, errs))
+	}
+}
+
+func (self *validateSuite) TestNewValidator(c *C) {
+	_, err := NewValidator(&JSONSchema{Property: Property{Pattern: "("}})
+	c.Assert(err, ErrorMatches, `invalid pattern "\(": .*`)
+
+	_, err = NewValidator(&JSONSchema{Property: Property{Items: &Property{Ref: "#/definitions/missing"}}})
+	c.Assert(err, ErrorMatches, "unresolvable reference #/definitions/missing")
+	_, err = NewValidator(&JSONSchema{Property: Property{Items: &Property{Ref: "#/anyOf/1"}}})
+	c.Assert(err, ErrorMatches, "unresolvable reference #/anyOf/1")
+	_, err = NewValidator(&JSONSchema{Property: Property{Items: &Property{Ref: "#anchor"}}})
+	c.Assert(err, ErrorMatches, "unresolvable reference #anchor")
+
+	js := validatedOrderSchema()
+	v, err := NewValidator(js)
+	c.Assert(err, IsNil)
+
+	// the validator isn't affected by later changes to the schema
+	js.Required = append(js.Required, "status")
+	errs, err := v.Validate([]byte(`{"id": "abc"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+}
+
+func (self *validateSuite) TestValidatorLimits(c *C) {
+	_, err := NewValidator(&JSONSchema{Property: Property{Pattern: "(a{30}){30}"}}, ValidatorLimits{MaxPatternComplexity: 500})
+	c.Assert(err, ErrorMatches, `pattern "\(a\{30\}\)\{30\}" exceeds the maximum complexity of 500`)
+	_, err = NewValidator(&JSONSchema{Property: Property{Pattern: "^[a-z]+$"}}, ValidatorLimits{MaxPatternComplexity: 1000})
+	c.Assert(err, IsNil)
+
+	v, err := NewValidator(validatedOrderSchema(), ValidatorLimits{MaxDocumentSize: 16})
+	c.Assert(err, IsNil)
+	_, err = v.Validate([]byte(`{"id": "abcdefghijklmnop"}`))
+	c.Assert(err, ErrorMatches, "the document exceeds the maximum size of 16 bytes")
+	_, err = v.ValidateAt("/id", []byte(`"abcdefghijklmnop"`))
+	c.Assert(err, ErrorMatches, "the document exceeds the maximum size of 16 bytes")
+
+	// a list nested deeper than the references allowed
+	list := &JSONSchema{
+		Property: Property{Ref: "#/definitions/list"},
+		Definitions: map[string]Property{
+			"list": {Type: "object", Properties: map[string]*Property{"next": {Ref: "#/definitions/list"}}},
+		},
+	}
+	v, err = NewValidator(list, ValidatorLimits{MaxRefDepth: 3})
+	c.Assert(err, IsNil)
+	errs, err := v.Validate([]byte(`{"next": {"next": {}}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	_, err = v.Validate([]byte(`{"next": {"next": {"next": {}}}}`))
+	c.Assert(err, ErrorMatches, `the maximum \$ref depth of 3 is exceeded at /\$ref/properties/next/\$ref/properties/next/\$ref/properties/next/\$ref`)
+	_, err = v.ValidateOutput([]byte(`{"next": {"next": {"next": {}}}}`), OutputBasic)
+	c.Assert(err, NotNil)
+}
+
+type testValidationMetrics struct {
+	mu          sync.Mutex
+	validations int
+	invalid     int
+	failures    map[string]int
+}
+
+func (m *testValidationMetrics) ObserveValidation(duration time.Duration, valid bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.validations++
+	if !valid {
+		m.invalid++
+	}
+}
+
+func (m *testValidationMetrics) CountFailure(keyword string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[keyword]++
+}
+
+func (self *validateSuite) TestValidationMetrics(c *C) {
+	metrics := &testValidationMetrics{failures: map[string]int{}}
+	v, err := NewValidator(validatedOrderSchema(), ValidatorLimits{MaxDocumentSize: 100})
+	c.Assert(err, IsNil)
+	v = v.WithMetrics(metrics)
+
+	_, err = v.Validate([]byte(`{"id": "o-1"}`))
+	c.Assert(err, IsNil)
+	_, err = v.Validate([]byte(`{"id": "o", "status": "pending"}`))
+	c.Assert(err, IsNil)
+	_, err = v.ValidateAt("/id", []byte(`"o"`))
+	c.Assert(err, IsNil)
+	_, err = v.ValidateOutput([]byte(`{}`), OutputFlag)
+	c.Assert(err, IsNil)
+	// not observed
+	_, err = v.Validate([]byte(`{`))
+	c.Assert(err, NotNil)
+
+	c.Assert(metrics.validations, Equals, 4)
+	c.Assert(metrics.invalid, Equals, 3)
+	c.Assert(metrics.failures, DeepEquals, map[string]int{"minLength": 2, "enum": 1, "required": 1})
+}
+
+func (self *validateSuite) TestValidateContext(c *C) {
+	v, err := NewValidator(validatedOrderSchema())
+	c.Assert(err, IsNil)
+	doc := []byte(`{"id": "o-1", "lines": [` + strings.Repeat(`{"sku": "ABC-1"},`, 999) + `{"sku": "ABC-1"}]}`)
+
+	errs, err := v.ValidateContext(context.Background(), doc)
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = v.ValidateContext(ctx, doc)
+	c.Assert(err, Equals, context.Canceled)
+	_, err = v.ValidateAtContext(ctx, "/id", []byte(`"o-1"`))
+	c.Assert(err, Equals, context.Canceled)
+	_, err = v.ValidateOutputContext(ctx, doc, OutputBasic)
+	c.Assert(err, Equals, context.Canceled)
+
+	// canceled during the validation
+	ctx, cancel = context.WithCancel(context.Background())
+	s := &validation{validator: v, refs: map[string]bool{}, bounds: &validationBounds{ctx: ctx}}
+	parsed, err := decodeDocument(doc)
+	c.Assert(err, IsNil)
+	cancel()
+	s.validate(v.root, parsed, "", "")
+	c.Assert(s.bounds.err, Equals, context.Canceled)
+	c.Assert(s.bounds.steps < 2000, Equals, true)
+}
+
+type ExampleJSONFormats struct {
+	Created time.Time `json:"created"`
+	Day     time.Time `json:"day" timeFormat:"date"`
+	Email   string    `json:"email" format:"email"`
+	ID      string    `json:"id" format:"uuid"`
+	Host    string    `json:"host" format:"ipv4"`
+	Color   string    `json:"color" format:"color"`
+}
+
+func (self *validateSuite) TestFormatAssertion(c *C) {
+	j := NewGenerator().WithRoot(&ExampleJSONFormats{}).MustGenerate()
+	doc := []byte(`{"created": "yesterday", "day": "2024-02-30", "email": "nobody",
+		"id": "42", "host": "::1", "color": "blue"}`)
+	errs, err := j.Validate(doc)
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	v, err := NewValidator(j)
+	c.Assert(err, IsNil)
+	errs, err = v.WithFormatAssertion().Validate(doc)
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{
+		{InstancePath: "/created", SchemaPath: "/properties/created/format", Keyword: "format", Message: "must be a valid date-time"},
+		{InstancePath: "/day", SchemaPath: "/properties/day/format", Keyword: "format", Message: "must be a valid date"},
+		{InstancePath: "/email", SchemaPath: "/properties/email/format", Keyword: "format", Message: "must be a valid email"},
+		{InstancePath: "/host", SchemaPath: "/properties/host/format", Keyword: "format", Message: "must be a valid ipv4"},
+		{InstancePath: "/id", SchemaPath: "/properties/id/format", Keyword: "format", Message: "must be a valid uuid"},
+	})
+
+	errs, err = v.Validate([]byte(`{"created": "2024-12-31T23:59:58.5+01:00", "day": "2024-02-29",
+		"email": "jane@example.com", "id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "host": "10.0.0.1"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+}
@@ -0,0 +1,108 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidateAt validates fragment, a JSON document, as the value at the JSON
+// pointer of the documents described by the schema, against the subschema
+// describing it, e.g. to validate the value of a PATCH operation or a single
+// form field. Violations are reported at their location in the whole document.
+func (d *JSONSchema) ValidateAt(pointer string, fragment []byte) (ValidationErrors, error) {
+	v, err := NewValidator(d)
+	if err != nil {
+		return nil, err
+	}
+	return v.ValidateAt(pointer, fragment)
+}
+
+// ValidateAt validates fragment as the value at the JSON pointer of the
+// documents. It returns an error if fragment isn't a JSON document or if the
+// schema doesn't describe the values at pointer.
+func (v *Validator) ValidateAt(pointer string, fragment []byte) (ValidationErrors, error) {
+	return v.ValidateAtContext(context.Background(), pointer, fragment)
+}
+
+// ValidateAtContext validates fragment as ValidateAt does, and stops with the
+// error of ctx once it is done, as ValidateContext does.
+func (v *Validator) ValidateAtContext(ctx context.Context, pointer string, fragment []byte) (ValidationErrors, error) {
+	p, schemaPath, err := v.schemaAt(pointer)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := v.decode(ctx, fragment)
+	if err != nil {
+		return nil, err
+	}
+	s, err := v.run(ctx, p, doc, pointer, schemaPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.errors, nil
+}
+
+// schemaAt returns the subschema describing the values at the JSON pointer
+// of the documents, and its location in the schema.
+func (v *Validator) schemaAt(pointer string) (*Property, string, error) {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return nil, "", fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	p, schemaPath := v.root, ""
+	var err error
+	if pointer == "" {
+		return p, schemaPath, nil
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		p, schemaPath, err = v.dereference(p, schemaPath)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if p.Type == "array" {
+			if _, err := strconv.Atoi(token); err != nil && token != "-" {
+				return nil, "", fmt.Errorf("invalid array index %q in %s", token, pointer)
+			}
+			if p.Items == nil {
+				return nil, "", fmt.Errorf("the schema doesn't describe the values at %s", pointer)
+			}
+			p, schemaPath = p.Items, schemaPath+"/items"
+			continue
+		}
+
+		name := unescapePointer(token)
+		switch {
+		case p.Properties[name] != nil:
+			p, schemaPath = p.Properties[name], schemaPath+"/properties/"+token
+		case p.Properties[".*"] != nil:
+			p, schemaPath = p.Properties[".*"], schemaPath+"/properties/.*"
+		case p.AdditionalPropertiesSchema != nil:
+			p, schemaPath = p.AdditionalPropertiesSchema, schemaPath+"/additionalProperties"
+		default:
+			return nil, "", fmt.Errorf("the schema doesn't describe the values at %s", pointer)
+		}
+	}
+	return p, schemaPath, nil
+}
+
+// dereference follows the $refs of p, and the value branch of the anyOf
+// emitted for pointers, to the schema describing the properties or items.
+func (v *Validator) dereference(p *Property, schemaPath string) (*Property, string, error) {
+	for i := 0; ; i++ {
+		if i > len(v.definitions)+1 {
+			return nil, "", fmt.Errorf("the reference at %s loops", schemaPath)
+		}
+		if p.Ref != "" {
+			p, schemaPath = v.resolve(p.Ref), schemaPath+"/$ref"
+			continue
+		}
+		if branch, ok := nullableBranch(p.AnyOf); ok && p.Type == "" {
+			p, schemaPath = p.AnyOf[branch], fmt.Sprintf("%s/anyOf/%d", schemaPath, branch)
+			continue
+		}
+		return p, schemaPath, nil
+	}
+}
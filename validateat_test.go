@@ -0,0 +1,74 @@
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type validateAtSuite struct{}
+
+var _ = Suite(&validateAtSuite{})
+
+type ExampleJSONValidatedCart struct {
+	Order *ExampleJSONValidatedOrder `json:"order"`
+}
+
+func (self *validateAtSuite) TestValidateAt(c *C) {
+	js := NewGenerator().
+		WithDefinition("line", ExampleJSONValidatedLine{}).
+		WithDefinition("order", ExampleJSONValidatedOrder{}).
+		WithRoot(&ExampleJSONValidatedCart{}).
+		MustGenerate()
+	v, err := NewValidator(js)
+	c.Assert(err, IsNil)
+
+	errs, err := v.ValidateAt("/order/lines/0", []byte(`{"sku": "abc", "quantity": 1}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{{
+		InstancePath: "/order/lines/0/sku",
+		SchemaPath:   "/properties/order/$ref/properties/lines/items/$ref/properties/sku/pattern",
+		Keyword:      "pattern",
+		Message:      "must match the pattern ^[A-Z]{3}-[0-9]+$",
+	}})
+
+	errs, err = v.ValidateAt("/order/lines/-", []byte(`{"sku": "ABC-1"}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	errs, err = v.ValidateAt("/order/tags/a~1b", []byte(`1`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, DeepEquals, ValidationErrors{{
+		InstancePath: "/order/tags/a~1b",
+		SchemaPath:   "/properties/order/$ref/properties/tags/properties/.*/type",
+		Keyword:      "type",
+		Message:      "must be of type string, not number",
+	}})
+
+	errs, err = v.ValidateAt("", []byte(`{"order": {"id": "abc"}}`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+
+	errs, err = js.ValidateAt("/order/status", []byte(`"open"`))
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+}
+
+func (self *validateAtSuite) TestValidateAtErrors(c *C) {
+	v, err := NewValidator(validatedOrderSchema())
+	c.Assert(err, IsNil)
+
+	_, err = v.ValidateAt("id", []byte(`"a"`))
+	c.Assert(err, ErrorMatches, `invalid JSON pointer "id"`)
+	_, err = v.ValidateAt("/missing", []byte(`"a"`))
+	c.Assert(err, ErrorMatches, "the schema doesn't describe the values at /missing")
+	_, err = v.ValidateAt("/lines/first", []byte(`{}`))
+	c.Assert(err, ErrorMatches, `invalid array index "first" in /lines/first`)
+	_, err = v.ValidateAt("/id", []byte(`"a`))
+	c.Assert(err, ErrorMatches, "invalid JSON document: .*")
+
+	js := &JSONSchema{
+		Definitions: map[string]Property{"a": {Ref: "#/definitions/b"}, "b": {Ref: "#/definitions/a"}},
+		Property:    Property{Ref: "#/definitions/a"},
+	}
+	_, err = js.ValidateAt("/x", []byte(`1`))
+	c.Assert(err, ErrorMatches, "the reference at .* loops")
+}
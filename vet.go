@@ -0,0 +1,385 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TagProblem is a problem with the tags of a struct field reported by VetTypes.
+type TagProblem struct {
+	Pos token.Position
+	// Field is the path of the field, e.g. "example.com/shop.Order.Lines".
+	Field   string
+	Message string
+}
+
+func (p TagProblem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.Pos, p.Field, p.Message)
+}
+
+// tags of other libraries, which look like misspellings of generator tags
+var otherTags = map[string]bool{
+	"bson": true, "env": true, "xml": true, "yaml": true, "toml": true,
+	"form": true, "db": true, "sql": true, "uri": true,
+}
+
+// VetTypes reports the problems with the tags of the struct types of the
+// packages matching pattern, e.g. "./..." in CI, without generating their
+// schemas: misspelled tags, values which can't be parsed, tags which have no
+// effect on the type of the field or along with other tags, references to
+// unknown properties and inconsistent constraints. The packages are read
+// from source, so no instance of the types is needed.
+func VetTypes(pattern string) ([]TagProblem, error) {
+	pkgs, err := loadPackages(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var problems []TagProblem
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					spec := spec.(*ast.TypeSpec)
+					ast.Inspect(spec.Type, func(n ast.Node) bool {
+						if st, ok := n.(*ast.StructType); ok {
+							problems = append(problems, vetStruct(pkg, pkg.Path+"."+spec.Name.Name, st)...)
+						}
+						return true
+					})
+				}
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Pos, problems[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return problems, nil
+}
+
+func vetStruct(pkg *loadedPackage, path string, st *ast.StructType) []TagProblem {
+	type field struct {
+		name string
+		pos  token.Pos
+		tag  reflect.StructTag
+		t    types.Type
+	}
+	var fields []field
+	properties := map[string]bool{}
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			raw, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				continue
+			}
+			tag = reflect.StructTag(raw)
+		}
+		names := f.Names
+		if len(names) == 0 {
+			// an embedded field is named after its type
+			name := types.ExprString(f.Type)
+			name = name[strings.LastIndexAny(name, "*.")+1:]
+			names = []*ast.Ident{{Name: name, NamePos: f.Pos()}}
+		}
+		for _, ident := range names {
+			fields = append(fields, field{ident.Name, ident.Pos(), tag, pkg.Info.TypeOf(f.Type)})
+			name, _ := parseTag(tag.Get("json"))
+			if ast.IsExported(ident.Name) && name != "-" {
+				if name == "" {
+					name = ident.Name
+				}
+				properties[name] = true
+			}
+		}
+	}
+
+	var problems []TagProblem
+	for _, f := range fields {
+		if f.tag == "" {
+			continue
+		}
+		for _, message := range vetTag(f.tag, f.t, ast.IsExported(f.name), properties) {
+			problems = append(problems, TagProblem{
+				Pos:     pkg.Fset.Position(f.pos),
+				Field:   path + "." + f.name,
+				Message: message,
+			})
+		}
+	}
+	return problems
+}
+
+// vetTag returns the problems with the tags of a field of type t, which is
+// nil when unknown. The tags of unexported fields apply to their struct.
+func vetTag(tag reflect.StructTag, t types.Type, exported bool, properties map[string]bool) []string {
+	jsType := "object"
+	if exported {
+		jsType = schemaTypeOf(t)
+	} else {
+		t = nil
+	}
+
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	check := func(err error) {
+		if err != nil {
+			add("%s", err)
+		}
+	}
+
+	for _, kv := range structTagPairs(tag) {
+		if generatorTags[kv[0]] || otherTags[kv[0]] {
+			continue
+		}
+		if suggestion, ok := suggestTag(kv[0]); ok {
+			add("unknown tag %s, did you mean %s?", kv[0], suggestion)
+		}
+	}
+
+	_, opts := parseTag(tag.Get("json"))
+	if _, required := tag.Lookup("required"); required && opts.Contains("omitempty") {
+		add(`"required" tag has no effect along with omitempty`)
+	}
+
+	p := &Property{Type: jsType}
+	for _, name := range stringTags {
+		if _, ok := tag.Lookup(name); ok && jsType != "" && jsType != "string" {
+			add("%q tag has no effect on %s values", name, jsType)
+		}
+	}
+	for _, name := range numberTags {
+		if _, ok := tag.Lookup(name); ok && jsType != "" && jsType != "number" && jsType != "integer" {
+			add("%q tag has no effect on %s values", name, jsType)
+		}
+	}
+	for _, name := range arrayTags {
+		if _, ok := tag.Lookup(name); ok && jsType != "" && jsType != "array" {
+			add("%q tag has no effect on %s values", name, jsType)
+		}
+	}
+	for _, name := range []string{"minLength", "maxLength", "minItems", "maxItems"} {
+		if raw, ok := tag.Lookup(name); ok {
+			if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+				add("invalid %q tag value %q", name, raw)
+			}
+		}
+	}
+	for _, name := range numberTags {
+		if raw, ok := tag.Lookup(name); ok {
+			if _, err := strconv.ParseFloat(raw, 64); err != nil {
+				add("invalid %q tag value %q", name, raw)
+			}
+		}
+	}
+	if raw, ok := tag.Lookup("const"); ok && (jsType == "number" || jsType == "integer") {
+		if _, err := parseType(raw, jsType); err != nil {
+			add(`invalid "const" tag value %q`, raw)
+		}
+	}
+	if raw, ok := tag.Lookup("pattern"); ok {
+		if pattern, err := expandPattern(raw); err != nil {
+			add("%s", err)
+		} else if _, err := regexp.Compile(pattern); err != nil {
+			add(`invalid "pattern" tag value %q: %s`, raw, err)
+		}
+	}
+	if raw, ok := tag.Lookup("extensions"); ok {
+		var extensions map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &extensions); err != nil {
+			add(`invalid "extensions" tag value %q: %s`, raw, err)
+		}
+	}
+
+	_, err := rawSchemaFromTags(&tag)
+	check(err)
+	check(p.addDeprecationFromTags(&tag))
+	check(p.addSensitiveFromTags(&tag))
+	check(p.addClassificationFromTags(&tag))
+	check(p.addNaNFromTags(&tag, jsType))
+	if exported {
+		check(p.addExampleFromTags(&tag, jsType))
+	}
+	if raw, ok := tag.Lookup("bytesFormat"); ok {
+		if err := (&Property{}).setBytesFormat(raw); err != nil {
+			add(`invalid "bytesFormat" tag value: %s`, err)
+		} else if !isByteSlice(t) && t != nil {
+			add(`"bytesFormat" tag on %s, which is not a byte slice`, t)
+		}
+	}
+	if _, ok := tag.Lookup("oneOf"); ok && !isInterfaceOrRawMessage(t) && t != nil {
+		add(`"oneOf" tag on %s, which is neither an interface nor a json.RawMessage`, t)
+	}
+	if exported {
+		_, err := typesFromTags(&tag)
+		check(err)
+		if _, ok, err := parseFlags(&tag); err != nil {
+			add("%s", err)
+		} else if ok && jsType != "" && jsType != "integer" {
+			add(`"flags" tag on a %s property`, jsType)
+		}
+	}
+	if raw, ok := tag.Lookup("timeEncoding"); ok {
+		if err := newNode().setTimeEncoding(TimeEncoding(raw)); err != nil {
+			add(`invalid "timeEncoding" tag value: %s`, err)
+		} else if !isTime(t) && t != nil {
+			add(`"timeEncoding" tag on %s, which is not a time.Time`, t)
+		}
+	}
+	if raw, ok := tag.Lookup("utc"); ok {
+		if _, err := strconv.ParseBool(raw); err != nil {
+			add(`invalid "utc" tag value %q`, raw)
+		} else if !isTime(t) && t != nil {
+			add(`"utc" tag on %s, which is not a time.Time`, t)
+		}
+	}
+	if raw, ok := tag.Lookup("timeFormat"); ok {
+		if err := (&Property{}).setTimeFormat(raw); err != nil {
+			add(`invalid "timeFormat" tag value %q: %s`, raw, err)
+		} else if !isTime(t) && t != nil {
+			add(`"timeFormat" tag on %s, which is not a time.Time`, t)
+		}
+	}
+	if _, ok := tag.Lookup("values"); ok && !isMap(t) && t != nil {
+		add(`"values" tag on %s, which is not a map`, t)
+	}
+	if raw, ok := tag.Lookup("valuesType"); ok && !valueTypes[raw] {
+		add(`invalid "valuesType" tag value %q`, raw)
+	}
+
+	rule := crossFieldRuleFromTags("", "", &tag)
+	for _, other := range append(append([]string{}, rule.requiredWith...), rule.exclusiveWith...) {
+		if !properties[other] {
+			add("unknown property %s in cross-field tags", other)
+		}
+	}
+
+	var inconsistencies []Inconsistency
+	p.addValidatorsFromTags(&tag, jsType)
+	checkConsistency(&inconsistencies, "", p)
+	for _, i := range inconsistencies {
+		add("%s", i.Message)
+	}
+	return problems
+}
+
+// schemaTypeOf returns the JSON type of the values of type t, or "" when
+// unknown.
+func schemaTypeOf(t types.Type) string {
+	if t == nil {
+		return ""
+	}
+	for {
+		p, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = p.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		if m, ok := formatMapping[named.String()]; ok {
+			return m[0]
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return "string"
+		case u.Info()&types.IsBoolean != 0:
+			return "boolean"
+		case u.Info()&types.IsInteger != 0:
+			return "integer"
+		case u.Info()&types.IsFloat != 0:
+			return "number"
+		}
+	case *types.Slice:
+		if isByteSlice(t) {
+			return "string"
+		}
+		return "array"
+	case *types.Array:
+		return "array"
+	case *types.Map, *types.Struct:
+		return "object"
+	}
+	return ""
+}
+
+func isByteSlice(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return isByteSlice(p.Elem())
+	}
+	s, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	b, ok := s.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Byte
+}
+
+func isInterfaceOrRawMessage(t types.Type) bool {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return isInterfaceOrRawMessage(p.Elem())
+	}
+	return types.IsInterface(t) || types.TypeString(t, nil) == "encoding/json.RawMessage"
+}
+
+func isTime(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return isTime(p.Elem())
+	}
+	return types.TypeString(t, nil) == "time.Time"
+}
+
+func isMap(t types.Type) bool {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		return isMap(p.Elem())
+	}
+	_, ok := t.Underlying().(*types.Map)
+	return ok
+}
+
+// suggestTag returns the generator tag name is likely a misspelling of.
+func suggestTag(name string) (string, bool) {
+	best, bestDistance := "", 0
+	for known := range generatorTags {
+		if strings.EqualFold(name, known) {
+			return known, true
+		}
+		d := editDistance(name, known)
+		limit := 1
+		if len(known) >= 8 {
+			limit = 2
+		}
+		if len(known) >= 4 && d <= limit && (best == "" || d < bestDistance || d == bestDistance && known < best) {
+			best, bestDistance = known, d
+		}
+	}
+	return best, best != ""
+}
@@ -0,0 +1,42 @@
+//go:build !tinygo
+
+package jsonschema
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type vetSuite struct{}
+
+var _ = Suite(&vetSuite{})
+
+func (self *vetSuite) TestVetTypes(c *C) {
+	problems, err := VetTypes("./testdata/vet")
+	c.Assert(err, IsNil)
+
+	messages := []string{}
+	for _, p := range problems {
+		messages = append(messages, p.Field+": "+p.Message)
+	}
+	c.Assert(messages, DeepEquals, []string{
+		`github.com/naveego/go-json-schema/testdata/vet.Order.meta: "minLength" tag has no effect on object values`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.ID: unknown tag minLenght, did you mean minLength?`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.ID: invalid "maxLength" tag value "eight"`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Quantity: "pattern" tag has no effect on integer values`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Quantity: no value is within minimum 10 and maximum 1`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Comment: unknown tag Description, did you mean description?`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Comment: "required" tag has no effect along with omitempty`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Card: unknown property iban in cross-field tags`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Created: "bytesFormat" tag on time.Time, which is not a byte slice`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Tags: invalid "deprecated" tag value "yes": strconv.ParseBool: parsing "yes": invalid syntax`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.SKU: const "c" is not one of the enum values "a", "b"`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Phone: unknown pattern @phone in "pattern" tag`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Payload: "oneOf" tag on string, which is neither an interface nor a json.RawMessage`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Ref: unknown type text in "types" tag`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Mode: invalid flag "read" in "flags" tag`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Shipped: "timeFormat" tag on string, which is not a time.Time`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Paid: invalid "timeEncoding" tag value: unknown time encoding "epoch"`,
+		`github.com/naveego/go-json-schema/testdata/vet.Order.Closed: invalid "utc" tag value "yes"`,
+	})
+	c.Assert(problems[0].Pos.Line, Equals, 6)
+}